@@ -0,0 +1,176 @@
+package blackbox
+
+import "time"
+
+// defaultAdaptiveInterval is the measurement interval used when
+// AdaptiveConfig.Interval is zero.
+const defaultAdaptiveInterval = time.Second
+
+// AdaptiveConfig configures the adaptive limit returned by NewAdaptive.
+type AdaptiveConfig struct {
+	// Min and Max bound the limit, which starts at Min. Min defaults to 1
+	// and Max to Min.
+	Min int
+	Max int
+	// Interval is how often the producer and consumer rates are measured and
+	// the limit adjusted. Defaults to one second.
+	Interval time.Duration
+	// Clock is used to time the intervals. When nil, the wall clock is used.
+	Clock Clock
+}
+
+// adaptiveBox bounds a box with a limit that follows the producer and
+// consumer rates.
+type adaptiveBox[T any] struct {
+	box   BlackBox[T]
+	cfg   AdaptiveConfig
+	limit int
+	next  time.Time
+	name  string // see WithName
+	// puts, gets and rejected count the calls of the current interval, and
+	// peak is the largest size seen during it.
+	puts     int
+	gets     int
+	rejected int
+	peak     int
+}
+
+// NewAdaptive wraps box so that Put accepts at most MaxSize items, a limit
+// between cfg.Min and cfg.Max adjusted every cfg.Interval: when producers
+// outpace consumers and Put had to reject items, the limit grows by the
+// excess of puts over gets so the queue absorbs the burst; when consumers
+// keep up and the box stayed at most half full, the limit halves, but never
+// below the items queued. The limit starts at cfg.Min, or at the size of box
+// if larger. box itself should hold at least cfg.Max items.
+// Only calls made through the returned box are counted.
+// Returns a concrete instance of adaptive blackbox.
+func NewAdaptive[T any](box BlackBox[T], cfg AdaptiveConfig) *adaptiveBox[T] {
+	if cfg.Min < 1 {
+		cfg.Min = 1
+	}
+	if cfg.Max < cfg.Min {
+		cfg.Max = cfg.Min
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaultAdaptiveInterval
+	}
+	if cfg.Clock == nil {
+		cfg.Clock = systemClock{}
+	}
+	a := &adaptiveBox[T]{box: box, cfg: cfg, limit: cfg.Min, peak: box.Size()}
+	if a.peak > a.limit {
+		a.limit = minInt(a.peak, cfg.Max)
+	}
+	a.next = cfg.Clock.Now().Add(cfg.Interval)
+	return a
+}
+
+// WithAdaptiveMaxSize replaces a fixed max size with a limit between min and
+// max that follows the producer and consumer rates, see NewAdaptive. The
+// limit is adjusted every second, timed by the clock set with WithClock.
+// Once the limit is reached, Put returns ErrBlackBoxFull whatever the
+// overflow policy. It cannot be combined with WithMaxSize or StrategyRing.
+func WithAdaptiveMaxSize(min, max int) Option {
+	return func(c *config) {
+		c.adaptiveMin = min
+		c.adaptiveMax = max
+		c.useAdaptive = true
+	}
+}
+
+// adjust updates the limit once the current interval is over.
+func (a *adaptiveBox[T]) adjust() {
+	now := a.cfg.Clock.Now()
+	if now.Before(a.next) {
+		return
+	}
+	size := a.box.Size()
+	switch {
+	case a.rejected > 0 && a.puts > a.gets:
+		a.limit += a.puts - a.gets
+		if a.limit > a.cfg.Max {
+			a.limit = a.cfg.Max
+		}
+	case a.gets >= a.puts && a.peak <= a.limit/2:
+		a.limit /= 2
+		if a.limit < size {
+			a.limit = size
+		}
+		if a.limit < a.cfg.Min {
+			a.limit = a.cfg.Min
+		}
+	}
+	a.puts, a.gets, a.rejected, a.peak = 0, 0, 0, size
+	a.next = now.Add(a.cfg.Interval)
+}
+
+func (a *adaptiveBox[T]) Put(item T) error {
+	a.adjust()
+	a.puts++
+	if size := a.box.Size(); size >= a.limit {
+		a.rejected++
+		return fullError(a.name, size, a.limit)
+	}
+	err := a.box.Put(item)
+	if size := a.box.Size(); size > a.peak {
+		a.peak = size
+	}
+	return err
+}
+
+func (a *adaptiveBox[T]) Get() (T, error) {
+	a.adjust()
+	item, err := a.box.Get()
+	if err == nil {
+		a.gets++
+	}
+	return item, err
+}
+
+func (a *adaptiveBox[T]) Peek() (T, error) {
+	return a.box.Peek()
+}
+
+func (a *adaptiveBox[T]) Size() int {
+	return a.box.Size()
+}
+
+// MaxSize returns the current limit.
+func (a *adaptiveBox[T]) MaxSize() int {
+	a.adjust()
+	return a.limit
+}
+
+func (a *adaptiveBox[T]) IsFull() bool {
+	a.adjust()
+	return a.box.Size() >= a.limit
+}
+
+func (a *adaptiveBox[T]) IsEmpty() bool {
+	return a.box.IsEmpty()
+}
+
+func (a *adaptiveBox[T]) Clean() {
+	a.box.Clean()
+}
+
+func (a *adaptiveBox[T]) Items() []T {
+	return a.box.Items()
+}
+
+func (a *adaptiveBox[T]) forEach(fn func(item T) bool) {
+	eachItem(a.box, fn)
+}
+
+// mutatesOnRead reports true: the limit changes as time passes, so MaxSize
+// and IsFull must not be cached by a concurrent wrapper.
+func (a *adaptiveBox[T]) mutatesOnRead() bool {
+	return true
+}
+
+func (a *adaptiveBox[T]) drawPlan() drawPlan[T] {
+	return planOf(a.box)
+}
+
+// Compile-time assertion that adaptiveBox implements BlackBox[T].
+var _ BlackBox[any] = (*adaptiveBox[any])(nil)
@@ -0,0 +1,90 @@
+package blackbox
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAdaptive(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	box := NewAdaptive[int](NewFIFO[int](0, 0), AdaptiveConfig{Min: 2, Max: 10, Interval: time.Second, Clock: clock})
+	if box.MaxSize() != 2 {
+		t.Errorf("Expected the limit to start at 2, got %d", box.MaxSize())
+	}
+
+	// A burst of 6 puts without consumers overflows the limit.
+	for i := 0; i < 6; i++ {
+		err := box.Put(i)
+		if i >= 2 && !errors.Is(err, ErrBlackBoxFull) {
+			t.Errorf("Expected ErrBlackBoxFull for put %d, got %v", i, err)
+		}
+	}
+	if !box.IsFull() {
+		t.Error("Expected the box to be full")
+	}
+	clock.Advance(time.Second)
+	if box.MaxSize() != 8 {
+		t.Errorf("Expected the limit to grow by the 6 excess puts to 8, got %d", box.MaxSize())
+	}
+
+	// Another burst is capped at Max.
+	for i := 0; i < 20; i++ {
+		box.Put(i)
+	}
+	clock.Advance(time.Second)
+	if box.MaxSize() != 10 || box.Size() != 8 {
+		t.Errorf("Expected limit 10 with 8 items, got %d and %d", box.MaxSize(), box.Size())
+	}
+
+	// Consumers catch up: the limit halves while the box stays half empty,
+	// but not below the queued items or Min.
+	for i := 0; i < 6; i++ {
+		box.Get()
+	}
+	clock.Advance(time.Second)
+	box.Get()
+	if box.MaxSize() != 10 {
+		t.Errorf("Expected the limit to stay at 10 after a busy interval, got %d", box.MaxSize())
+	}
+	clock.Advance(time.Second)
+	if box.MaxSize() != 5 {
+		t.Errorf("Expected the limit to halve to 5, got %d", box.MaxSize())
+	}
+	clock.Advance(time.Second)
+	if box.MaxSize() != 2 {
+		t.Errorf("Expected the limit to halve to 2, got %d", box.MaxSize())
+	}
+	clock.Advance(time.Second)
+	if box.MaxSize() != 2 {
+		t.Errorf("Expected the limit to stay at Min, got %d", box.MaxSize())
+	}
+}
+
+func TestWithAdaptiveMaxSize(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	box := New[int](WithStrategy(StrategyLIFO), WithAdaptiveMaxSize(1, 4), WithClock(clock))
+	box.Put(1)
+	if err := box.Put(2); !errors.Is(err, ErrBlackBoxFull) {
+		t.Errorf("Expected ErrBlackBoxFull, got %v", err)
+	}
+	clock.Advance(time.Second)
+	if err := box.Put(2); err != nil || box.MaxSize() != 3 {
+		t.Errorf("Expected the limit to grow to 3, got %d, %v", box.MaxSize(), err)
+	}
+
+	if _, err := NewE[int](WithAdaptiveMaxSize(4, 2)); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("Expected ErrInvalidOption for inverted bounds, got %v", err)
+	}
+	if _, err := NewE[int](WithAdaptiveMaxSize(1, 2), WithMaxSize(8)); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("Expected ErrInvalidOption with a fixed max size, got %v", err)
+	}
+
+	from := NewFrom([]int{1, 2, 3}, WithAdaptiveMaxSize(1, 8))
+	if from.MaxSize() != 3 {
+		t.Errorf("Expected the limit to start at the size, got %d", from.MaxSize())
+	}
+	if !NewConcurrent(from).IsFull() {
+		t.Error("Expected the concurrent box to be full")
+	}
+}
@@ -0,0 +1,252 @@
+package blackbox
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+var ErrAuditTampered = errors.New("blackbox audit log was tampered with")
+
+// AuditRecord is the record of one Get in an audit log. Records are chained:
+// Hash covers the record and the Hash of the previous record, so editing,
+// inserting or deleting a record breaks the chain, see VerifyAudit.
+type AuditRecord struct {
+	Seq  uint64    `json:"seq"`
+	Time time.Time `json:"time"`
+	// Item is the JSON encoding of the item, or of its %v formatting when the
+	// item is not JSON-serializable.
+	Item json.RawMessage `json:"item"`
+	// Remaining is the size of the box after the Get.
+	Remaining int `json:"remaining"`
+	// RNG is the number of values drawn from the RNG before the Get, when the
+	// box is a Random box with a reproducible RNG, see SeedState.
+	RNG  *uint64 `json:"rng,omitempty"`
+	Prev string  `json:"prev"`
+	Hash string  `json:"hash"`
+}
+
+// hash returns the hash of the record chained to the previous record.
+func (r AuditRecord) hash() (string, error) {
+	r.Hash = ""
+	data, err := json.Marshal(r)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(append([]byte(r.Prev+"\n"), data...))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// rngPositioner is implemented by the boxes of this package that know how
+// many values their RNG has produced.
+type rngPositioner interface {
+	rngPosition() (uint64, bool)
+}
+
+func (b *randomBox[T]) rngPosition() (uint64, bool) {
+	if b.src == nil {
+		return 0, false
+	}
+	return b.src.draws, true
+}
+
+func (t *timedBox[T]) rngPosition() (uint64, bool) {
+	if p, ok := t.box.(rngPositioner); ok {
+		return p.rngPosition()
+	}
+	return 0, false
+}
+
+// auditBox writes a record of every successful Get to an audit log.
+type auditBox[T any] struct {
+	box   BlackBox[T]
+	enc   *json.Encoder
+	clock Clock
+	seq   uint64
+	prev  string
+	err   error
+}
+
+// NewAudit wraps box so that every successful Get writes an AuditRecord as a
+// line of JSON to w, e.g. a file or an AuditLog, producing a tamper-evident
+// record of a raffle. Get still returns the item when writing fails; Err
+// reports the first write error. Only WithClock is used from opts.
+// Returns a concrete instance of audit blackbox.
+func NewAudit[T any](box BlackBox[T], w io.Writer, opts ...Option) *auditBox[T] {
+	return &auditBox[T]{box: box, enc: json.NewEncoder(w), clock: parseOptions(opts).now()}
+}
+
+// WithAudit writes a record of every Get to w, see NewAudit.
+func WithAudit(w io.Writer) Option {
+	return func(c *config) {
+		c.audit = w
+	}
+}
+
+// Err returns the first error writing to the audit log, if any.
+func (a *auditBox[T]) Err() error {
+	return a.err
+}
+
+// record writes the record of a Get of item made at the given RNG position.
+func (a *auditBox[T]) record(item T, rng uint64, hasRNG bool) {
+	r := AuditRecord{
+		Seq:       a.seq + 1,
+		Time:      a.clock.Now(),
+		Remaining: a.box.Size(),
+		Prev:      a.prev,
+	}
+	if hasRNG {
+		r.RNG = &rng
+	}
+	data, err := json.Marshal(item)
+	if err != nil {
+		data, _ = json.Marshal(fmt.Sprintf("%v", item))
+	}
+	r.Item = data
+	if r.Hash, err = r.hash(); err == nil {
+		err = a.enc.Encode(r)
+	}
+	if err != nil {
+		if a.err == nil {
+			a.err = err
+		}
+		return
+	}
+	a.seq, a.prev = r.Seq, r.Hash
+}
+
+func (a *auditBox[T]) Put(item T) error {
+	return a.box.Put(item)
+}
+
+func (a *auditBox[T]) Get() (T, error) {
+	var rng uint64
+	var hasRNG bool
+	if p, ok := a.box.(rngPositioner); ok {
+		rng, hasRNG = p.rngPosition()
+	}
+	item, err := a.box.Get()
+	if err == nil {
+		a.record(item, rng, hasRNG)
+	}
+	return item, err
+}
+
+func (a *auditBox[T]) Peek() (T, error) {
+	return a.box.Peek()
+}
+
+func (a *auditBox[T]) Size() int {
+	return a.box.Size()
+}
+
+func (a *auditBox[T]) MaxSize() int {
+	return a.box.MaxSize()
+}
+
+func (a *auditBox[T]) IsFull() bool {
+	return a.box.IsFull()
+}
+
+func (a *auditBox[T]) IsEmpty() bool {
+	return a.box.IsEmpty()
+}
+
+func (a *auditBox[T]) Clean() {
+	a.box.Clean()
+}
+
+func (a *auditBox[T]) Items() []T {
+	return a.box.Items()
+}
+
+func (a *auditBox[T]) forEach(fn func(item T) bool) {
+	eachItem(a.box, fn)
+}
+
+func (a *auditBox[T]) mutatesOnRead() bool {
+	return mutatesOnRead(a.box)
+}
+
+func (a *auditBox[T]) mutatesOnPeek() bool {
+	return mutatesOnPeek(a.box)
+}
+
+func (a *auditBox[T]) drawPlan() drawPlan[T] {
+	return planOf(a.box)
+}
+
+// Compile-time assertion that auditBox implements BlackBox[T].
+var _ BlackBox[any] = (*auditBox[any])(nil)
+
+// AuditLog is an in-memory audit log: an io.Writer for WithAudit and
+// NewAudit that keeps the records. It is safe for concurrent use.
+type AuditLog struct {
+	mu      sync.Mutex
+	buf     []byte
+	records []AuditRecord
+}
+
+// Write parses the records written by an audit blackbox.
+func (l *AuditLog) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.buf = append(l.buf, p...)
+	for {
+		i := bytes.IndexByte(l.buf, '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+		var r AuditRecord
+		if err := json.Unmarshal(l.buf[:i], &r); err != nil {
+			return len(p), err
+		}
+		l.records = append(l.records, r)
+		l.buf = l.buf[i+1:]
+	}
+}
+
+// Records returns the records written so far.
+func (l *AuditLog) Records() []AuditRecord {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	records := make([]AuditRecord, len(l.records))
+	copy(records, l.records)
+	return records
+}
+
+// VerifyAudit reads an audit log written by an audit blackbox and checks its
+// hash chain. It returns the number of records, and an error wrapping
+// ErrAuditTampered if a record was edited, inserted, reordered or deleted.
+// Deleting records at the end of the log can't be detected this way; publish
+// the Hash of the last record to guard against it.
+func VerifyAudit(r io.Reader) (int, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	prev := ""
+	n := 0
+	for scanner.Scan() {
+		n++
+		var rec AuditRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			return n - 1, fmt.Errorf("blackbox: line %d: %w", n, err)
+		}
+		hash, err := rec.hash()
+		if err != nil {
+			return n - 1, err
+		}
+		if rec.Seq != uint64(n) || rec.Prev != prev || rec.Hash != hash {
+			return n - 1, fmt.Errorf("%w: record %d", ErrAuditTampered, n)
+		}
+		prev = rec.Hash
+	}
+	return n, scanner.Err()
+}
@@ -0,0 +1,91 @@
+package blackbox
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAuditLog(t *testing.T) {
+	log := &AuditLog{}
+	box := NewFrom([]string{"a", "b", "c"}, WithSeed(1), WithAudit(log))
+	var drawn []string
+	for !box.IsEmpty() {
+		item, _ := box.Get()
+		drawn = append(drawn, item)
+	}
+	box.Get()
+	box.Peek()
+
+	records := log.Records()
+	if len(records) != 3 {
+		t.Fatalf("Expected 3 records, got %d", len(records))
+	}
+	for i, r := range records {
+		if r.Seq != uint64(i+1) {
+			t.Errorf("Expected seq %d, got %d", i+1, r.Seq)
+		}
+		if string(r.Item) != `"`+drawn[i]+`"` {
+			t.Errorf("Expected item %q, got %s", drawn[i], r.Item)
+		}
+		if r.Remaining != 2-i {
+			t.Errorf("Expected %d remaining, got %d", 2-i, r.Remaining)
+		}
+		if r.RNG == nil {
+			t.Errorf("Expected the RNG position of record %d", i+1)
+		}
+		if r.Time.IsZero() {
+			t.Errorf("Expected a timestamp on record %d", i+1)
+		}
+	}
+	if *records[0].RNG != 0 || *records[1].RNG == 0 {
+		t.Errorf("Expected RNG positions to advance from 0, got %d and %d", *records[0].RNG, *records[1].RNG)
+	}
+}
+
+func TestVerifyAudit(t *testing.T) {
+	var buf bytes.Buffer
+	box := NewAudit[int](NewFIFOFrom([]int{1, 2, 3}, 0), &buf)
+	box.clock = &fakeClock{now: time.Unix(0, 0)}
+	for i := 0; i < 3; i++ {
+		box.Get()
+	}
+	if err := box.Err(); err != nil {
+		t.Fatal(err)
+	}
+	log := buf.String()
+	if n, err := VerifyAudit(strings.NewReader(log)); err != nil || n != 3 {
+		t.Errorf("Expected 3 valid records, got %d (%v)", n, err)
+	}
+	if strings.Contains(log, `"rng"`) {
+		t.Errorf("Expected no RNG position for a FIFO box, got %s", log)
+	}
+
+	tampered := strings.Replace(log, `"item":2`, `"item":5`, 1)
+	if _, err := VerifyAudit(strings.NewReader(tampered)); !errors.Is(err, ErrAuditTampered) {
+		t.Errorf("Expected ErrAuditTampered for an edited record, got %v", err)
+	}
+	lines := strings.SplitAfter(log, "\n")
+	deleted := lines[0] + lines[2]
+	if _, err := VerifyAudit(strings.NewReader(deleted)); !errors.Is(err, ErrAuditTampered) {
+		t.Errorf("Expected ErrAuditTampered for a deleted record, got %v", err)
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestAuditWriteError(t *testing.T) {
+	box := NewAudit[int](NewFIFOFrom([]int{1}, 0), failingWriter{})
+	if item, err := box.Get(); err != nil || item != 1 {
+		t.Errorf("Expected Get to return 1 despite the audit error, got %d (%v)", item, err)
+	}
+	if box.Err() == nil {
+		t.Error("Expected the write error from Err")
+	}
+}
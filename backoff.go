@@ -0,0 +1,55 @@
+package blackbox
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+)
+
+// PutWithBackoff puts item into box, retrying while Put fails with
+// ErrBlackBoxFull after delays computed by backoff, until the item is put or
+// ctx is done, in which case ctx.Err() is returned. Other errors of Put are
+// returned at once. Unlike PutCtx, it works with any box, including boxes
+// filled by other processes such as a Redis box; the jitter of backoff keeps
+// many producers from retrying at once. A zero backoff.Initial retries every
+// millisecond.
+func PutWithBackoff[T any](ctx context.Context, box BlackBox[T], item T, backoff Backoff) error {
+	if backoff.Initial <= 0 {
+		backoff.Initial = time.Millisecond
+	}
+	for attempt := 1; ; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		err := box.Put(item)
+		if !errors.Is(err, ErrBlackBoxFull) {
+			return err
+		}
+		timer := time.NewTimer(backoff.Delay(attempt, backoffRand))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// backoffRand draws the jitter of PutWithBackoff from the package-level
+// math/rand functions, which are safe for concurrent use.
+var backoffRand = rand.New(globalSource{})
+
+// globalSource adapts the package-level math/rand functions to rand.Source.
+// Seed is a no-op.
+type globalSource struct{}
+
+func (globalSource) Seed(int64) {}
+
+func (globalSource) Int63() int64 {
+	return rand.Int63()
+}
+
+func (globalSource) Uint64() uint64 {
+	return rand.Uint64()
+}
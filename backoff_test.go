@@ -0,0 +1,68 @@
+package blackbox
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fullFor is a box whose Put fails with ErrBlackBoxFull the first n times.
+type fullFor struct {
+	BlackBox[int]
+	n     int
+	calls int
+}
+
+func (f *fullFor) Put(item int) error {
+	f.calls++
+	if f.calls <= f.n {
+		return fullError("test", 0, 0)
+	}
+	return f.BlackBox.Put(item)
+}
+
+func TestPutWithBackoff(t *testing.T) {
+	box := &fullFor{BlackBox: NewFIFO[int](0, 0), n: 3}
+	backoff := Backoff{Initial: time.Millisecond, Max: 2 * time.Millisecond, Jitter: 0.5}
+	if err := PutWithBackoff[int](context.Background(), box, 1, backoff); err != nil {
+		t.Fatalf("Expected the item to be put, got %v", err)
+	}
+	if box.calls != 4 || box.Size() != 1 {
+		t.Errorf("Expected 4 attempts and 1 item, got %d and %d", box.calls, box.Size())
+	}
+
+	full := NewFIFOFrom([]int{1}, 1)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := PutWithBackoff[int](ctx, full, 2, Backoff{}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+
+	invalid := New[int](WithPutValidator(func(int) error { return errors.New("invalid") }))
+	if err := PutWithBackoff(context.Background(), invalid, 1, backoff); !errors.Is(err, ErrInvalidItem) {
+		t.Errorf("Expected other errors to be returned at once, got %v", err)
+	}
+}
+
+func TestPutWithBackoffConcurrent(t *testing.T) {
+	backoff := Backoff{Initial: time.Millisecond, Max: 2 * time.Millisecond, Jitter: 1}
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			box := &fullFor{BlackBox: NewFIFO[int](0, 0), n: 5}
+			errs <- PutWithBackoff[int](context.Background(), box, i, backoff)
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Errorf("Expected the item to be put, got %v", err)
+		}
+	}
+}
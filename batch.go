@@ -0,0 +1,40 @@
+package blackbox
+
+// PutBatch inserts items in order under a single lock and returns how many
+// were inserted. It stops at the first item the box rejects and returns that
+// error, so items[n:] were not inserted.
+func (c *concurrentBox[T]) PutBatch(items []T) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n := 0
+	var err error
+	for _, item := range items {
+		if err = c.box.Put(item); err != nil {
+			break
+		}
+		n++
+	}
+	if n > 0 {
+		c.broadcast()
+	}
+	return n, err
+}
+
+// GetBatch removes and returns up to n items in retrieval order under a
+// single lock. It returns an empty slice if the box is empty.
+func (c *concurrentBox[T]) GetBatch(n int) []T {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	items := make([]T, 0, minInt(n, c.box.Size()))
+	for len(items) < n {
+		item, err := c.box.Get()
+		if err != nil {
+			break
+		}
+		items = append(items, item)
+	}
+	if len(items) > 0 {
+		c.broadcast()
+	}
+	return items
+}
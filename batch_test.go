@@ -0,0 +1,44 @@
+package blackbox
+
+import "testing"
+
+func TestBatch(t *testing.T) {
+	box := NewConcurrent[int](NewFIFO[int](4, 4))
+	n, err := box.PutBatch([]int{1, 2, 3, 4, 5})
+	if n != 4 || err != ErrBlackBoxFull {
+		t.Errorf("Expected 4 items put and ErrBlackBoxFull, got %d (%v)", n, err)
+	}
+	if box.Size() != 4 {
+		t.Errorf("Expected size 4, got %d", box.Size())
+	}
+
+	if items := box.GetBatch(3); !EqualInts(items, []int{1, 2, 3}) {
+		t.Errorf("Expected [1 2 3], got %v", items)
+	}
+	if items := box.GetBatch(3); !EqualInts(items, []int{4}) {
+		t.Errorf("Expected [4], got %v", items)
+	}
+	if items := box.GetBatch(3); len(items) != 0 || !box.IsEmpty() {
+		t.Errorf("Expected no items, got %v", items)
+	}
+}
+
+func BenchmarkConcurrentFIFO_PutBatch(b *testing.B) {
+	cb := NewConcurrent[int](NewFIFO[int](0, b.N))
+	batch := make([]int, 64)
+	b.ResetTimer()
+	for i := 0; i < b.N; i += len(batch) {
+		_, _ = cb.PutBatch(batch)
+	}
+}
+
+func BenchmarkConcurrentFIFO_GetBatch(b *testing.B) {
+	cb := NewConcurrent[int](NewFIFO[int](0, b.N))
+	for i := 0; i < b.N; i++ {
+		_ = cb.Put(i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i += 64 {
+		_ = cb.GetBatch(64)
+	}
+}
@@ -0,0 +1,393 @@
+package blackbox
+
+import (
+	"bufio"
+	"bytes"
+	"encoding"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// binaryMagic starts every binary encoding, followed by binaryVersion, the
+// Envelope version of the encoded fields.
+const (
+	binaryMagic   = "BBX"
+	binaryVersion = EnvelopeVersion
+)
+
+// maxBinaryLen bounds the lengths read from a binary encoding, so a corrupt
+// input cannot make the decoder allocate unbounded memory.
+const maxBinaryLen = 1 << 30
+
+var ErrInvalidBinary = errors.New("blackbox binary encoding is invalid")
+
+// ItemCodec encodes and decodes single items for the binary format.
+type ItemCodec[T any] struct {
+	Encode func(item T) ([]byte, error)
+	Decode func(data []byte) (T, error)
+}
+
+// DefaultItemCodec returns the codec used by MarshalBinary and UnmarshalBinary.
+// It supports strings, byte slices, booleans, integers, floats and types
+// implementing encoding.BinaryMarshaler and encoding.BinaryUnmarshaler; other
+// types fail with ErrNotSerializable.
+func DefaultItemCodec[T any]() ItemCodec[T] {
+	return ItemCodec[T]{Encode: encodeItem[T], Decode: decodeItem[T]}
+}
+
+func encodeItem[T any](item T) ([]byte, error) {
+	switch v := any(item).(type) {
+	case string:
+		return []byte(v), nil
+	case []byte:
+		return v, nil
+	case bool:
+		if v {
+			return []byte{1}, nil
+		}
+		return []byte{0}, nil
+	case int:
+		return varintBytes(int64(v)), nil
+	case int8:
+		return varintBytes(int64(v)), nil
+	case int16:
+		return varintBytes(int64(v)), nil
+	case int32:
+		return varintBytes(int64(v)), nil
+	case int64:
+		return varintBytes(v), nil
+	case uint:
+		return uvarintBytes(uint64(v)), nil
+	case uint8:
+		return []byte{v}, nil
+	case uint16:
+		return uvarintBytes(uint64(v)), nil
+	case uint32:
+		return uvarintBytes(uint64(v)), nil
+	case uint64:
+		return uvarintBytes(v), nil
+	case float32:
+		buf := make([]byte, 4)
+		binary.LittleEndian.PutUint32(buf, math.Float32bits(v))
+		return buf, nil
+	case float64:
+		buf := make([]byte, 8)
+		binary.LittleEndian.PutUint64(buf, math.Float64bits(v))
+		return buf, nil
+	case encoding.BinaryMarshaler:
+		return v.MarshalBinary()
+	}
+	return nil, fmt.Errorf("%w: no binary codec for %T", ErrNotSerializable, item)
+}
+
+// decodeItem decodes data into a new item, the reverse of encodeItem.
+func decodeItem[T any](data []byte) (T, error) {
+	var item T
+	var err error
+	switch p := any(&item).(type) {
+	case *string:
+		*p = string(data)
+	case *[]byte:
+		*p = append([]byte(nil), data...)
+	case *bool:
+		if len(data) != 1 || data[0] > 1 {
+			return item, ErrInvalidBinary
+		}
+		*p = data[0] == 1
+	case *int:
+		var v int64
+		v, err = readVarint(data, math.MinInt, math.MaxInt)
+		*p = int(v)
+	case *int8:
+		var v int64
+		v, err = readVarint(data, math.MinInt8, math.MaxInt8)
+		*p = int8(v)
+	case *int16:
+		var v int64
+		v, err = readVarint(data, math.MinInt16, math.MaxInt16)
+		*p = int16(v)
+	case *int32:
+		var v int64
+		v, err = readVarint(data, math.MinInt32, math.MaxInt32)
+		*p = int32(v)
+	case *int64:
+		*p, err = readVarint(data, math.MinInt64, math.MaxInt64)
+	case *uint:
+		var v uint64
+		v, err = readUvarint(data, math.MaxUint)
+		*p = uint(v)
+	case *uint8:
+		if len(data) != 1 {
+			return item, ErrInvalidBinary
+		}
+		*p = data[0]
+	case *uint16:
+		var v uint64
+		v, err = readUvarint(data, math.MaxUint16)
+		*p = uint16(v)
+	case *uint32:
+		var v uint64
+		v, err = readUvarint(data, math.MaxUint32)
+		*p = uint32(v)
+	case *uint64:
+		*p, err = readUvarint(data, math.MaxUint64)
+	case *float32:
+		if len(data) != 4 {
+			return item, ErrInvalidBinary
+		}
+		*p = math.Float32frombits(binary.LittleEndian.Uint32(data))
+	case *float64:
+		if len(data) != 8 {
+			return item, ErrInvalidBinary
+		}
+		*p = math.Float64frombits(binary.LittleEndian.Uint64(data))
+	case encoding.BinaryUnmarshaler:
+		err = p.UnmarshalBinary(data)
+	default:
+		err = fmt.Errorf("%w: no binary codec for %T", ErrNotSerializable, item)
+	}
+	return item, err
+}
+
+func varintBytes(x int64) []byte {
+	buf := make([]byte, binary.MaxVarintLen64)
+	return buf[:binary.PutVarint(buf, x)]
+}
+
+func uvarintBytes(x uint64) []byte {
+	buf := make([]byte, binary.MaxVarintLen64)
+	return buf[:binary.PutUvarint(buf, x)]
+}
+
+// readVarint decodes data, which must hold exactly one varint within [min, max].
+func readVarint(data []byte, min, max int64) (int64, error) {
+	v, n := binary.Varint(data)
+	if n <= 0 || n != len(data) || v < min || v > max {
+		return 0, ErrInvalidBinary
+	}
+	return v, nil
+}
+
+// readUvarint decodes data, which must hold exactly one uvarint up to max.
+func readUvarint(data []byte, max uint64) (uint64, error) {
+	v, n := binary.Uvarint(data)
+	if n <= 0 || n != len(data) || v > max {
+		return 0, ErrInvalidBinary
+	}
+	return v, nil
+}
+
+// EncodeBinary streams the binary encoding of box to w, encoding every item
+// with codec. The versioned format holds the strategy, maximum size, seed and
+// items of an Envelope, with varint sizes and length-prefixed items.
+func EncodeBinary[T any](w io.Writer, box BlackBox[T], codec ItemCodec[T]) error {
+	e, err := asEnveloper(box)
+	if err != nil {
+		return err
+	}
+	env, err := e.envelope()
+	if err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(w)
+	bw.WriteString(binaryMagic)
+	bw.WriteByte(binaryVersion)
+	writeBytes(bw, []byte(env.Strategy))
+	bw.Write(uvarintBytes(uint64(env.MaxSize)))
+	if env.Seed != nil {
+		bw.WriteByte(1)
+		bw.Write(varintBytes(*env.Seed))
+	} else {
+		bw.WriteByte(0)
+	}
+	bw.Write(uvarintBytes(uint64(len(env.Items))))
+	for _, item := range env.Items {
+		data, err := codec.Encode(item)
+		if err != nil {
+			return err
+		}
+		writeBytes(bw, data)
+	}
+	return bw.Flush()
+}
+
+func writeBytes(w *bufio.Writer, data []byte) {
+	w.Write(uvarintBytes(uint64(len(data))))
+	w.Write(data)
+}
+
+// DecodeBinary reads a box encoded by EncodeBinary from r, decoding every item
+// with codec. Like NewFromJSON, opts are applied after the encoded strategy,
+// maximum size and seed. Unless r implements io.ByteReader, it is buffered and
+// DecodeBinary may read past the end of the encoding.
+func DecodeBinary[T any](r io.Reader, codec ItemCodec[T], opts ...Option) (BlackBox[T], error) {
+	env, err := readEnvelope(r, codec)
+	if err != nil {
+		return nil, err
+	}
+	return newFromEnvelope(env, opts)
+}
+
+func readEnvelope[T any](r io.Reader, codec ItemCodec[T]) (Envelope[T], error) {
+	var env Envelope[T]
+	br, ok := r.(byteReader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+
+	header := make([]byte, len(binaryMagic)+1)
+	if _, err := io.ReadFull(br, header); err != nil {
+		return env, fmt.Errorf("%w: %v", ErrInvalidBinary, err)
+	}
+	if string(header[:len(binaryMagic)]) != binaryMagic {
+		return env, ErrInvalidBinary
+	}
+	if v := header[len(binaryMagic)]; v > binaryVersion {
+		return env, fmt.Errorf("%w: unsupported version %d", ErrInvalidBinary, v)
+	}
+	env.Version = int(header[len(binaryMagic)])
+
+	strategy, err := readBytes(br)
+	if err != nil {
+		return env, err
+	}
+	env.Strategy = string(strategy)
+	maxSize, err := readLen(br)
+	if err != nil {
+		return env, err
+	}
+	env.MaxSize = maxSize
+	flags, err := br.ReadByte()
+	if err != nil || flags > 1 {
+		return env, ErrInvalidBinary
+	}
+	if flags == 1 {
+		seed, err := binary.ReadVarint(br)
+		if err != nil {
+			return env, ErrInvalidBinary
+		}
+		env.Seed = &seed
+	}
+	count, err := readLen(br)
+	if err != nil {
+		return env, err
+	}
+	env.Items = make([]T, 0, minInt(count, 1024))
+	for i := 0; i < count; i++ {
+		data, err := readBytes(br)
+		if err != nil {
+			return env, err
+		}
+		item, err := codec.Decode(data)
+		if err != nil {
+			return env, err
+		}
+		env.Items = append(env.Items, item)
+	}
+	if env.Version != EnvelopeVersion {
+		if err := migrateHeader(&env); err != nil {
+			return env, err
+		}
+	}
+	return env, nil
+}
+
+type byteReader interface {
+	io.Reader
+	io.ByteReader
+}
+
+func readLen(r io.ByteReader) (int, error) {
+	n, err := binary.ReadUvarint(r)
+	if err != nil || n > maxBinaryLen {
+		return 0, ErrInvalidBinary
+	}
+	return int(n), nil
+}
+
+func readBytes(r byteReader) ([]byte, error) {
+	n, err := readLen(r)
+	if err != nil {
+		return nil, err
+	}
+	// ReadAll grows the buffer as data arrives instead of trusting n upfront.
+	data, err := io.ReadAll(io.LimitReader(r, int64(n)))
+	if err != nil || len(data) != n {
+		return nil, ErrInvalidBinary
+	}
+	return data, nil
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func marshalBinaryBox[T any](box BlackBox[T]) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := EncodeBinary(&buf, box, DefaultItemCodec[T]()); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func unmarshalBinaryBox[T any](e enveloper[T], data []byte) error {
+	env, err := readEnvelope(bytes.NewReader(data), DefaultItemCodec[T]())
+	if err != nil {
+		return err
+	}
+	return e.load(env)
+}
+
+// MarshalBinary encodes the box with DefaultItemCodec, see EncodeBinary.
+func (b *fifoBox[T]) MarshalBinary() ([]byte, error) { return marshalBinaryBox[T](b) }
+
+// UnmarshalBinary is the binary counterpart of UnmarshalJSON.
+func (b *fifoBox[T]) UnmarshalBinary(data []byte) error { return unmarshalBinaryBox[T](b, data) }
+
+// MarshalBinary encodes the box with DefaultItemCodec, see EncodeBinary.
+func (b *dequeBox[T]) MarshalBinary() ([]byte, error) { return marshalBinaryBox[T](b) }
+
+// UnmarshalBinary is the binary counterpart of UnmarshalJSON.
+func (b *dequeBox[T]) UnmarshalBinary(data []byte) error { return unmarshalBinaryBox[T](b, data) }
+
+// MarshalBinary encodes the box with DefaultItemCodec, see EncodeBinary.
+func (b *lifoBox[T]) MarshalBinary() ([]byte, error) { return marshalBinaryBox[T](b) }
+
+// UnmarshalBinary is the binary counterpart of UnmarshalJSON.
+func (b *lifoBox[T]) UnmarshalBinary(data []byte) error { return unmarshalBinaryBox[T](b, data) }
+
+// MarshalBinary encodes the box with DefaultItemCodec, see EncodeBinary.
+func (b *randomBox[T]) MarshalBinary() ([]byte, error) { return marshalBinaryBox[T](b) }
+
+// UnmarshalBinary is the binary counterpart of UnmarshalJSON.
+func (b *randomBox[T]) UnmarshalBinary(data []byte) error { return unmarshalBinaryBox[T](b, data) }
+
+// MarshalBinary encodes the box with DefaultItemCodec, see EncodeBinary.
+func (b *sortedBox[T]) MarshalBinary() ([]byte, error) { return marshalBinaryBox[T](b) }
+
+// UnmarshalBinary is the binary counterpart of UnmarshalJSON.
+func (b *sortedBox[T]) UnmarshalBinary(data []byte) error { return unmarshalBinaryBox[T](b, data) }
+
+// MarshalBinary encodes the box with DefaultItemCodec, see EncodeBinary.
+func (t *timedBox[T]) MarshalBinary() ([]byte, error) { return marshalBinaryBox[T](t) }
+
+// UnmarshalBinary is the binary counterpart of UnmarshalJSON.
+func (t *timedBox[T]) UnmarshalBinary(data []byte) error { return unmarshalBinaryBox[T](t, data) }
+
+// MarshalBinary encodes the box with DefaultItemCodec, see EncodeBinary.
+func (c *categoryBox[T, K]) MarshalBinary() ([]byte, error) { return marshalBinaryBox[T](c) }
+
+// UnmarshalBinary is the binary counterpart of UnmarshalJSON.
+func (c *categoryBox[T, K]) UnmarshalBinary(data []byte) error { return unmarshalBinaryBox[T](c, data) }
+
+// MarshalBinary encodes the box with DefaultItemCodec, see EncodeBinary.
+func (c *concurrentBox[T]) MarshalBinary() ([]byte, error) { return marshalBinaryBox[T](c) }
+
+// UnmarshalBinary is the binary counterpart of UnmarshalJSON.
+func (c *concurrentBox[T]) UnmarshalBinary(data []byte) error { return unmarshalBinaryBox[T](c, data) }
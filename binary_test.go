@@ -0,0 +1,112 @@
+package blackbox
+
+import (
+	"bytes"
+	"encoding"
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestBinaryRoundTrip(t *testing.T) {
+	boxes := map[string]BlackBox[int]{
+		"FIFO":   NewFIFOFrom[int]([]int{1, -2, 300}, 5),
+		"LIFO":   NewLIFOFrom[int]([]int{1, -2, 300}, 5),
+		"Random": NewFrom[int]([]int{1, -2, 300}, WithSeed(9), WithMaxSize(5)),
+		"Timed":  NewFrom[int]([]int{1, -2, 300}, WithStrategy(StrategyFIFO), WithMaxSize(5), WithTTL(time.Hour)),
+	}
+	for name, box := range boxes {
+		data, err := box.(encoding.BinaryMarshaler).MarshalBinary()
+		if err != nil {
+			t.Fatalf("%s: Failed to marshal: %v", name, err)
+		}
+		restored, err := DecodeBinary[int](bytes.NewReader(data), DefaultItemCodec[int]())
+		if err != nil {
+			t.Fatalf("%s: Failed to decode: %v", name, err)
+		}
+		if restored.MaxSize() != 5 {
+			t.Errorf("%s: Expected max size 5, got %d", name, restored.MaxSize())
+		}
+		if want, got := Drain(box), Drain(restored); !EqualInts(got, want) {
+			t.Errorf("%s: Expected %v, got %v", name, want, got)
+		}
+	}
+}
+
+func TestBinaryUnmarshal(t *testing.T) {
+	src := NewLIFOFrom[float64]([]float64{1.5, math.Inf(-1)}, 0)
+	data, _ := src.MarshalBinary()
+
+	dst := NewLIFO[float64](0, 0)
+	if err := dst.UnmarshalBinary(data); err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+	if item, _ := dst.Get(); !math.IsInf(item, -1) {
+		t.Errorf("Expected -Inf, got %v", item)
+	}
+
+	for _, bad := range [][]byte{nil, []byte("BBX\x02"), data[:len(data)-1]} {
+		if err := dst.UnmarshalBinary(bad); !errors.Is(err, ErrInvalidBinary) {
+			t.Errorf("Input=%q Expected ErrInvalidBinary, got %v", bad, err)
+		}
+	}
+}
+
+func TestBinaryItemCodec(t *testing.T) {
+	type point struct{ x, y int }
+	codec := ItemCodec[point]{
+		Encode: func(p point) ([]byte, error) {
+			return []byte(strconv.Itoa(p.x) + "," + strconv.Itoa(p.y)), nil
+		},
+		Decode: func(data []byte) (point, error) {
+			var p point
+			parts := bytes.SplitN(data, []byte(","), 2)
+			p.x, _ = strconv.Atoi(string(parts[0]))
+			p.y, _ = strconv.Atoi(string(parts[1]))
+			return p, nil
+		},
+	}
+
+	box := NewFIFOFrom[point]([]point{{1, 2}, {3, 4}}, 0)
+	if _, err := box.MarshalBinary(); !errors.Is(err, ErrNotSerializable) {
+		t.Errorf("Expected ErrNotSerializable without codec, got %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := EncodeBinary[point](&buf, box, codec); err != nil {
+		t.Fatalf("Failed to encode: %v", err)
+	}
+	restored, err := DecodeBinary[point](&buf, codec)
+	if err != nil {
+		t.Fatalf("Failed to decode: %v", err)
+	}
+	if item, _ := restored.Get(); item != (point{1, 2}) {
+		t.Errorf("Expected {1 2}, got %v", item)
+	}
+}
+
+func TestDefaultItemCodec(t *testing.T) {
+	strings := DefaultItemCodec[string]()
+	data, _ := strings.Encode("héllo")
+	if s, err := strings.Decode(data); s != "héllo" || err != nil {
+		t.Errorf("Expected héllo, got %q, %v", s, err)
+	}
+
+	int8s := DefaultItemCodec[int8]()
+	big, _ := DefaultItemCodec[int]().Encode(1000)
+	if _, err := int8s.Decode(big); !errors.Is(err, ErrInvalidBinary) {
+		t.Errorf("Expected ErrInvalidBinary for out of range int8, got %v", err)
+	}
+
+	times := DefaultItemCodec[time.Time]()
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	data, err := times.Encode(now)
+	if err != nil {
+		t.Fatalf("Failed to encode time: %v", err)
+	}
+	if decoded, err := times.Decode(data); err != nil || !decoded.Equal(now) {
+		t.Errorf("Expected %v, got %v, %v", now, decoded, err)
+	}
+}
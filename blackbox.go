@@ -2,6 +2,7 @@ package blackbox
 
 import (
 	"errors"
+	"io"
 	"math/rand"
 	"time"
 )
@@ -9,6 +10,9 @@ import (
 var (
 	ErrEmptyBlackBox = errors.New("blackbox is empty")
 	ErrBlackBoxFull  = errors.New("blackbox is full")
+	ErrCategoryFull  = errors.New("blackbox category is full")
+	ErrInvalidWeight = errors.New("blackbox weight must be a finite non-negative number")
+	ErrShed          = errors.New("blackbox shed the item under load")
 )
 
 const (
@@ -24,10 +28,12 @@ const (
 // Method behavior (common across implementations):
 //   - Put(item T) error
 //     Insert an item into the blackbox. If the blackbox has a configured
-//     maximum capacity and is already full, Put returns ErrBlackBoxFull.
+//     maximum capacity and is already full, Put returns ErrBlackBoxFull, or a
+//     *FullError matching it for boxes named with WithName.
 //   - Get() (T, error)
 //     Remove and return an item according to the configured retrieval strategy.
-//     If the blackbox is empty, Get returns a zero value of T and ErrEmptyBlackBox.
+//     If the blackbox is empty, Get returns a zero value of T and ErrEmptyBlackBox,
+//     or an *EmptyError matching it for boxes named with WithName.
 //   - Peek() (T, error)
 //     Return an item according to the configured retrieval strategy without
 //     removing it. If the blackbox is empty, Peek returns a zero value of T
@@ -47,7 +53,7 @@ const (
 //     Return a copy of all items in the blackbox.
 //
 // Implementations returned by New[T] honor these semantics but differ in
-// selection behavior (StrategyFIFO, StrategyLIFO, StrategyRandom).
+// selection behavior (StrategyFIFO, StrategyLIFO, StrategyRandom, StrategyRing).
 type BlackBox[T any] interface {
 	Put(item T) error
 	Get() (T, error)
@@ -67,6 +73,16 @@ const (
 	StrategyRandom Strategy = iota // Default: random retrieval
 	StrategyFIFO                   // First In First Out
 	StrategyLIFO                   // Last In First Out
+	StrategyRing                   // First In First Out, Put overwrites the oldest item once full
+)
+
+// OverflowPolicy defines what Put does when a bounded blackbox is full
+type OverflowPolicy int
+
+const (
+	OverflowError      OverflowPolicy = iota // Default: Put returns ErrBlackBoxFull
+	OverflowDropOldest                       // Evict the oldest item to make room for the new one
+	OverflowDropNewest                       // Discard the new item, Put returns nil
 )
 
 // config holds common configuration
@@ -76,7 +92,31 @@ type config struct {
 	initialCapacity int
 	seed            int64
 	useSeed         bool
+	source          rand.Source
+	userRand        *rand.Rand
 	useMaxSize      bool
+	overflow        OverflowPolicy
+	onEvict         any // func(item T), see WithEvictCallback
+	ttl             time.Duration
+	useTTL          bool
+	retention       time.Duration
+	adaptiveMin     int
+	adaptiveMax     int
+	useAdaptive     bool
+	tickets         bool
+	shedAt          float64
+	lockFree        bool
+	chunkSize       int
+	ageBias         func(age time.Duration) float64
+	compression     *Compression
+	audit           io.Writer
+	clock           Clock
+	name            string
+	// capacityArg is the value passed to WithInitialCapacity, kept for NewE.
+	capacityArg int
+	// decorators holds func(BlackBox[T]) BlackBox[T] values registered by
+	// typed options; they are applied in order by New, NewFrom and NewFromBlackBox.
+	decorators []any
 }
 
 // Option is a function that configures the blackbox
@@ -97,17 +137,172 @@ func WithMaxSize(size int) Option {
 	}
 }
 
+// WithName names the blackbox, e.g. after the queue it holds. Put then
+// returns a *FullError and Get and Peek an *EmptyError carrying the name, so
+// logs tell which box failed; they still match ErrBlackBoxFull and
+// ErrEmptyBlackBox with errors.Is. Unnamed boxes return the sentinel errors
+// themselves.
+func WithName(name string) Option {
+	return func(c *config) {
+		c.name = name
+	}
+}
+
+// WithOverflowPolicy sets what Put does when the blackbox has reached its
+// maximum size, instead of always returning ErrBlackBoxFull.
+//
+// OverflowDropOldest evicts the item that has been in the box the longest:
+// the head for FIFO and the bottom of the stack for LIFO. The Random strategy
+// only tracks insertion order when WithAgeBias is used; otherwise it evicts a
+// randomly chosen item.
+func WithOverflowPolicy(policy OverflowPolicy) Option {
+	return func(c *config) {
+		c.overflow = policy
+	}
+}
+
+// WithEvictCallback registers fn to be called with every item the blackbox
+// removes on its own: items evicted or discarded by the overflow policy and
+// items removed by Clean. Use it to release resources tied to those items.
+// fn must not call back into the blackbox.
+func WithEvictCallback[T any](fn func(item T)) Option {
+	return func(c *config) {
+		c.onEvict = fn
+	}
+}
+
+// WithTTL makes items expire d after they were put. Expired items are
+// silently skipped by Get, Peek, Size and Items, and reported to the evict
+// callback. A d of 0 means items only expire when put with PutWithTTL.
+// Boxes created with WithTTL implement Expirable[T].
+func WithTTL(d time.Duration) Option {
+	return func(c *config) {
+		c.ttl = d
+		c.useTTL = true
+	}
+}
+
+// WithTickets makes the blackbox hand out a Ticket for every item put with
+// PutTicket, to Cancel or Inspect that exact item later even if equal items
+// are queued. Boxes created with WithTickets implement Ticketer[T]; they
+// ignore WithLockFree, whose boxes cannot remove items.
+// Combined with WithTTL, items expire as usual but PutWithTTL is not available.
+func WithTickets() Option {
+	return func(c *config) {
+		c.tickets = true
+	}
+}
+
+// WithLoadShedding makes Put randomly reject items with ErrShed once the box
+// holds more than fraction of MaxSize, with a probability rising linearly from
+// 0 at that point to 1 when the box is full (random early drop). This avoids
+// the hard cliff of a full box under overload. It has no effect on unbounded
+// boxes. See NewLoadShedding.
+func WithLoadShedding(fraction float64) Option {
+	return func(c *config) {
+		c.shedAt = fraction
+	}
+}
+
 // WithSeed sets a custom random seed for reproducible random behavior (Random Strategy)
 func WithSeed(seed int64) Option {
 	return func(c *config) {
+		c.resetRand()
 		c.seed = seed
 		c.useSeed = true
 	}
 }
 
+// WithCryptoRand makes the Random strategy draw items using crypto/rand, e.g.
+// for prize draws that must not be predictable. It is slower than the default
+// RNG and, unlike WithSeed, not reproducible. The last of WithSeed,
+// WithCryptoRand, WithRandSource and WithRand wins.
+func WithCryptoRand() Option {
+	return WithRandSource(cryptoSource{})
+}
+
+// WithRandSource makes the Random strategy draw items from src. Each box
+// created with the option gets its own *rand.Rand, but they all share src.
+func WithRandSource(src rand.Source) Option {
+	return func(c *config) {
+		c.resetRand()
+		c.source = src
+	}
+}
+
+// WithRand makes the Random strategy draw items from r. A *rand.Rand is not
+// goroutine-safe, so do not share r between boxes used concurrently.
+func WithRand(r *rand.Rand) Option {
+	return func(c *config) {
+		c.resetRand()
+		c.userRand = r
+	}
+}
+
+// resetRand clears the RNG configuration, so the last RNG option wins.
+func (c *config) resetRand() {
+	c.seed = 0
+	c.useSeed = false
+	c.source = nil
+	c.userRand = nil
+}
+
+// WithAgeBias makes the Random strategy select items with a probability
+// proportional to fn(age), where age is how long the item has been in the box.
+// For example, fn returning math.Exp(-age.Hours()) strongly favours fresh items
+// while still occasionally returning old ones. Negative weights count as zero;
+// when every weight is zero, selection falls back to uniform.
+func WithAgeBias(fn func(age time.Duration) float64) Option {
+	return func(c *config) {
+		c.ageBias = fn
+	}
+}
+
+// WithCategoryLimit limits how many items of the same category, as returned
+// by keyFn, can be in the box at once. Put returns ErrCategoryFull when the
+// item's category already holds maxPerKey items, so one category cannot
+// consume the whole box. With WithOverflowPolicy, the policy also applies
+// within the category: OverflowDropOldest evicts the oldest item of the
+// category and OverflowDropNewest discards the new item, both reported to
+// the evict callback. See NewCategoryLimitPolicy.
+func WithCategoryLimit[T any, K comparable](keyFn func(T) K, maxPerKey int) Option {
+	return func(c *config) {
+		c.decorators = append(c.decorators, func(box BlackBox[T]) BlackBox[T] {
+			cat := NewCategoryLimitPolicy(box, keyFn, maxPerKey, c.overflow)
+			cat.onEvict, _ = c.onEvict.(func(item T))
+			return cat
+		})
+	}
+}
+
+// WithLockFree makes StrategyLIFO boxes use the lock-free implementation of
+// NewLockFreeLIFO, and bounded StrategyFIFO boxes the lock-free ring of
+// NewMPMCFIFO, both safe for concurrent use without NewConcurrent.
+// OverflowDropOldest cannot be implemented without a lock, so it keeps the
+// regular box, as do unbounded FIFO boxes and boxes created with WithTTL or
+// WithRetention, which must remove expired items. StrategyRandom ignores
+// this option.
+func WithLockFree() Option {
+	return func(c *config) {
+		c.lockFree = true
+	}
+}
+
+// WithChunkSize makes StrategyFIFO boxes store items in linked chunks of the
+// given size, see NewChunkedFIFO, so that growing never copies the whole
+// buffer. Other strategies ignore this option.
+func WithChunkSize(size int) Option {
+	return func(c *config) {
+		if size > 0 {
+			c.chunkSize = size
+		}
+	}
+}
+
 // WithInitialCapacity sets the initial capacity to avoid early reallocations
 func WithInitialCapacity(capacity int) Option {
 	return func(c *config) {
+		c.capacityArg = capacity
 		if capacity > 0 {
 			c.initialCapacity = capacity
 		}
@@ -130,33 +325,186 @@ func parseOptions(opts []Option) config {
 	return cfg
 }
 
+// rng creates the RNG for the Random strategy from the configured source,
+// seeded with the configured seed or a time-based seed. src is set when the
+// state of the RNG can be reproduced, see Clone.
+func (c config) rng() (rng *rand.Rand, src *seededSource) {
+	if c.userRand != nil {
+		return c.userRand, nil
+	}
+	if c.source != nil {
+		return rand.New(c.source), nil
+	}
+	if c.useSeed {
+		src = newSeededSource(c.seed)
+	} else {
+		src = newSeededSource(time.Now().UnixNano())
+	}
+	return rand.New(src), src
+}
+
+// decorate wraps box with the decorators registered by typed options.
+// Decorators registered for a different item type are ignored.
+func decorate[T any](box BlackBox[T], cfg config) BlackBox[T] {
+	for _, d := range cfg.decorators {
+		if wrap, ok := d.(func(BlackBox[T]) BlackBox[T]); ok {
+			box = wrap(box)
+		}
+	}
+	return box
+}
+
+// configure applies the options that are stored on the concrete blackboxes.
+func configure[T any](box BlackBox[T], cfg config) BlackBox[T] {
+	onEvict, _ := cfg.onEvict.(func(item T))
+	switch b := box.(type) {
+	case *fifoBox[T]:
+		b.overflow = cfg.overflow
+		b.onEvict = onEvict
+		b.name = cfg.name
+	case *lifoBox[T]:
+		b.overflow = cfg.overflow
+		b.onEvict = onEvict
+		b.name = cfg.name
+	case *lockFreeLIFO[T]:
+		b.dropNewest = cfg.overflow == OverflowDropNewest
+		b.onEvict = onEvict
+		b.name = cfg.name
+	case *mpmcFIFO[T]:
+		b.dropNewest = cfg.overflow == OverflowDropNewest
+		b.onEvict = onEvict
+		b.name = cfg.name
+	case *chunkedFIFO[T]:
+		b.overflow = cfg.overflow
+		b.onEvict = onEvict
+		b.name = cfg.name
+	case *randomBox[T]:
+		b.overflow = cfg.overflow
+		b.onEvict = onEvict
+		b.name = cfg.name
+		if cfg.ageBias != nil {
+			b.setAgeBias(cfg.ageBias, cfg.now().Now)
+		}
+	}
+	return box
+}
+
+// build creates the blackbox of the configured strategy, holding a copy of data
+// when fromData is set, with the options stored on the concrete blackboxes applied.
+func build[T any](cfg config, data []T, fromData bool) BlackBox[T] {
+	var box BlackBox[T]
+	switch cfg.strategy {
+	case StrategyFIFO:
+		if cfg.lockFree && cfg.overflow != OverflowDropOldest && cfg.maxSize > 0 {
+			if fromData {
+				box = NewMPMCFIFOFrom[T](data, cfg.maxSize)
+			} else {
+				box = NewMPMCFIFO[T](cfg.maxSize)
+			}
+		} else if cfg.chunkSize > 0 {
+			if fromData {
+				box = NewChunkedFIFOFrom[T](data, cfg.maxSize, cfg.chunkSize)
+			} else {
+				box = NewChunkedFIFO[T](cfg.maxSize, cfg.chunkSize)
+			}
+		} else if fromData {
+			box = NewFIFOFrom[T](data, cfg.maxSize)
+		} else {
+			box = NewFIFO[T](cfg.maxSize, cfg.initialCapacity)
+		}
+	case StrategyLIFO:
+		if cfg.lockFree && cfg.overflow != OverflowDropOldest {
+			if fromData {
+				box = NewLockFreeLIFOFrom[T](data, cfg.maxSize)
+			} else {
+				box = NewLockFreeLIFO[T](cfg.maxSize)
+			}
+		} else if fromData {
+			box = NewLIFOFrom[T](data, cfg.maxSize)
+		} else {
+			box = NewLIFO[T](cfg.maxSize, cfg.initialCapacity)
+		}
+	case StrategyRing:
+		size := cfg.maxSize
+		if size < 1 {
+			size = 1
+		}
+		var b *fifoBox[T]
+		if fromData {
+			b = NewFIFOFrom[T](data, size)
+		} else {
+			b = NewFIFO[T](size, size)
+		}
+		b.overwrite = true
+		box = b
+	case StrategyRandom:
+		fallthrough
+	default:
+		rng, src := cfg.rng()
+		var b *randomBox[T]
+		if fromData {
+			b = NewRandomFrom[T](data, cfg.maxSize, rng)
+		} else {
+			b = NewRandom[T](cfg.maxSize, cfg.initialCapacity, rng)
+		}
+		b.src = src
+		box = b
+	}
+	return configure(box, cfg)
+}
+
+// buildTimed creates the blackbox of the configured strategy like build,
+// within a timed box when WithTTL is used.
+func buildTimed[T any](cfg config, data []T, fromData bool) BlackBox[T] {
+	if cfg.useTTL {
+		return newTimedBox(cfg, data, fromData)
+	}
+	return build(cfg, data, fromData)
+}
+
+// assemble creates the complete blackbox for cfg, including the decorators.
+func assemble[T any](cfg config, data []T, fromData bool) BlackBox[T] {
+	if cfg.useAdaptive {
+		cfg.maxSize = 0
+	}
+	var box BlackBox[T]
+	if cfg.tickets {
+		box = newTicketBox(cfg, data, fromData)
+	} else {
+		box = buildTimed(cfg, data, fromData)
+	}
+	if cfg.useAdaptive {
+		a := NewAdaptive(box, AdaptiveConfig{Min: cfg.adaptiveMin, Max: cfg.adaptiveMax, Clock: cfg.now()})
+		a.name = cfg.name
+		box = a
+	}
+	if cfg.audit != nil {
+		a := NewAudit(box, cfg.audit)
+		a.clock = cfg.now()
+		box = a
+	}
+	if cfg.shedAt > 0 {
+		rng, _ := cfg.rng()
+		box = NewLoadShedding(box, cfg.shedAt, rng)
+	}
+	return decorate(box, cfg)
+}
+
 // New creates a new BlackBox with the specified options.
 //
 // The returned implementation depends on the configured Strategy:
 //   - StrategyFIFO -> FIFO behavior (first inserted is first returned)
 //   - StrategyLIFO -> LIFO behavior (last inserted is first returned)
 //   - StrategyRandom -> Random selection behavior (requires an RNG)
+//   - StrategyRing -> FIFO behavior with a fixed size set by WithMaxSize, where
+//     Put overwrites the oldest item once full (1 item without WithMaxSize)
 //
 // For the Random strategy, if WithSeed was used the RNG will be seeded with
-// the provided seed for reproducible behavior; otherwise a time-based seed is used.
+// the provided seed for reproducible behavior; WithCryptoRand, WithRandSource
+// and WithRand use the given randomness instead; otherwise a time-based seed is used.
 func New[T any](opts ...Option) BlackBox[T] {
 	cfg := parseOptions(opts)
-	switch cfg.strategy {
-	case StrategyFIFO:
-		return NewFIFO[T](cfg.maxSize, cfg.initialCapacity)
-	case StrategyLIFO:
-		return NewLIFO[T](cfg.maxSize, cfg.initialCapacity)
-	case StrategyRandom:
-		fallthrough
-	default:
-		var rng *rand.Rand
-		if cfg.useSeed {
-			rng = rand.New(rand.NewSource(cfg.seed))
-		} else {
-			rng = rand.New(rand.NewSource(time.Now().UnixNano()))
-		}
-		return NewRandom[T](cfg.maxSize, cfg.initialCapacity, rng)
-	}
+	return assemble[T](cfg, nil, false)
 }
 
 // NewFrom creates a new BlackBox with existing data and the specified options
@@ -167,22 +515,7 @@ func NewFrom[T any](data []T, opts ...Option) BlackBox[T] {
 	if cfg.maxSize > 0 && cfg.maxSize < len(data) {
 		cfg.maxSize = len(data)
 	}
-	switch cfg.strategy {
-	case StrategyFIFO:
-		return NewFIFOFrom[T](data, cfg.maxSize)
-	case StrategyLIFO:
-		return NewLIFOFrom[T](data, cfg.maxSize)
-	case StrategyRandom:
-		fallthrough
-	default:
-		var rng *rand.Rand
-		if cfg.useSeed {
-			rng = rand.New(rand.NewSource(cfg.seed))
-		} else {
-			rng = rand.New(rand.NewSource(time.Now().UnixNano()))
-		}
-		return NewRandomFrom[T](data, cfg.maxSize, rng)
-	}
+	return assemble(cfg, data, true)
 }
 
 // NewFromBlackBox creates a new BlackBox with existing data and the specified options
@@ -198,20 +531,5 @@ func NewFromBlackBox[T any](box BlackBox[T], opts ...Option) BlackBox[T] {
 	} else {
 		cfg.maxSize = box.MaxSize()
 	}
-	switch cfg.strategy {
-	case StrategyFIFO:
-		return NewFIFOFromBlackBox[T](box, cfg.maxSize)
-	case StrategyLIFO:
-		return NewLIFOFromBlackBox[T](box, cfg.maxSize)
-	case StrategyRandom:
-		fallthrough
-	default:
-		var rng *rand.Rand
-		if cfg.useSeed {
-			rng = rand.New(rand.NewSource(cfg.seed))
-		} else {
-			rng = rand.New(rand.NewSource(time.Now().UnixNano()))
-		}
-		return NewRandomFromBlackBox[T](box, cfg.maxSize, rng)
-	}
+	return assemble(cfg, box.Items(), true)
 }
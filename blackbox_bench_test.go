@@ -118,3 +118,64 @@ func BenchmarkConcreteRandomGet(b *testing.B) {
 		_, _ = box.Get()
 	}
 }
+
+func BenchmarkConcreteSortedPut(b *testing.B) {
+	rng := rand.New(rand.NewSource(42))
+	box := NewSorted[int](func(a, b int) bool { return a < b }, 0)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		box.Put(rng.Int())
+	}
+}
+
+func BenchmarkConcreteSortedGet(b *testing.B) {
+	rng := rand.New(rand.NewSource(42))
+	box := NewSorted[int](func(a, b int) bool { return a < b }, 0)
+	for i := 0; i < b.N; i++ {
+		box.Put(rng.Int())
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = box.Get()
+	}
+}
+
+func BenchmarkConcurrentFIFOParallel(b *testing.B) {
+	box := NewConcurrent[int](NewFIFO[int](0, 1024))
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			box.Put(1)
+			_, _ = box.Get()
+		}
+	})
+}
+
+func BenchmarkShardedFIFOParallel(b *testing.B) {
+	box := NewSharded[int](16, func() BlackBox[int] { return NewFIFO[int](0, 64) })
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			box.Put(1)
+			_, _ = box.Get()
+		}
+	})
+}
+
+func BenchmarkLockFreeLIFOParallel(b *testing.B) {
+	box := NewLockFreeLIFO[int](0)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			box.Put(1)
+			_, _ = box.Get()
+		}
+	})
+}
+
+func BenchmarkMPMCFIFOParallel(b *testing.B) {
+	box := NewMPMCFIFO[int](1024)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			box.Put(1)
+			_, _ = box.Get()
+		}
+	})
+}
@@ -1,6 +1,7 @@
 package blackbox
 
 import (
+	"encoding/json"
 	"math/rand"
 	"testing"
 	"time"
@@ -50,6 +51,75 @@ func TestFIFOStrategy(t *testing.T) {
 	}
 }
 
+func TestRingStrategy(t *testing.T) {
+	box := New[int](WithStrategy(StrategyRing), WithMaxSize(3))
+	for i := 1; i <= 5; i++ {
+		if err := box.Put(i); err != nil {
+			t.Fatalf("Failed to put item %d: %v", i, err)
+		}
+	}
+	if !EqualInts(box.Items(), []int{3, 4, 5}) {
+		t.Errorf("Expected the last 3 items, got %v", box.Items())
+	}
+	if item, _ := box.Get(); item != 3 {
+		t.Errorf("Expected the oldest item 3, got %d", item)
+	}
+
+	// Overwriting wins over the overflow policy.
+	ring := New[int](WithStrategy(StrategyRing), WithMaxSize(1), WithOverflowPolicy(OverflowDropNewest))
+	ring.Put(1)
+	ring.Put(2)
+	if item, _ := ring.Peek(); item != 2 {
+		t.Errorf("Expected 2 to overwrite 1, got %d", item)
+	}
+
+	if size := New[int](WithStrategy(StrategyRing)).MaxSize(); size != 1 {
+		t.Errorf("Expected a default size of 1, got %d", size)
+	}
+	if size := NewFrom[int]([]int{1, 2}, WithStrategy(StrategyRing)).MaxSize(); size != 2 {
+		t.Errorf("Expected the size to fit the items, got %d", size)
+	}
+
+	data, _ := json.Marshal(box)
+	restored, err := NewFromJSON[int](data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	restored.Put(6)
+	restored.Put(7)
+	if !EqualInts(restored.Items(), []int{5, 6, 7}) {
+		t.Errorf("Expected the restored ring to keep overwriting, got %v (%s)", restored.Items(), data)
+	}
+
+	unbounded, err := NewFromJSON[int]([]byte(`{"strategy":"ring","items":[1,2]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	unbounded.Put(3)
+	if !EqualInts(unbounded.Items(), []int{2, 3}) {
+		t.Errorf("Expected a ring without a max size to stay bounded, got %v", unbounded.Items())
+	}
+}
+
+func TestRingBuffer(t *testing.T) {
+	box := NewRingBuffer[string](2)
+	for _, event := range []string{"a", "b", "c"} {
+		if err := box.Put(event); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if items := box.Items(); len(items) != 2 || items[0] != "b" || items[1] != "c" {
+		t.Errorf("Expected [b c], got %v", items)
+	}
+
+	one := NewRingBuffer[int](0)
+	one.Put(1)
+	one.Put(2)
+	if one.MaxSize() != 1 || !EqualInts(one.Items(), []int{2}) {
+		t.Errorf("Expected a size below 1 to make a ring of 1, got %d and %v", one.MaxSize(), one.Items())
+	}
+}
+
 func TestLIFOStrategy(t *testing.T) {
 	box := New[int](WithStrategy(StrategyLIFO))
 
@@ -85,6 +155,30 @@ func TestLIFOStrategy(t *testing.T) {
 	}
 }
 
+func TestLIFODropOldest(t *testing.T) {
+	var evicted []int
+	box := NewLIFODropOldest[int](3, 0)
+	box.onEvict = func(item int) { evicted = append(evicted, item) }
+	for i := 1; i <= 1000; i++ {
+		if err := box.Put(i); err != nil {
+			t.Fatalf("Failed to put item %d: %v", i, err)
+		}
+	}
+	if box.Size() != 3 || len(evicted) != 997 || evicted[0] != 1 {
+		t.Errorf("Expected the oldest 997 items to be evicted, got size %d and %d evicted", box.Size(), len(evicted))
+	}
+	if cap(box.items) > 16 {
+		t.Errorf("Expected evictions not to grow the stack, got capacity %d", cap(box.items))
+	}
+	var got []int
+	for !box.IsEmpty() {
+		item, _ := box.Get()
+		got = append(got, item)
+	}
+	if !EqualInts(got, []int{1000, 999, 998}) {
+		t.Errorf("Expected the last 3 items in LIFO order, got %v", got)
+	}
+}
 func TestRandomStrategy(t *testing.T) {
 	box := New[int](WithStrategy(StrategyRandom))
 
@@ -621,3 +715,98 @@ func TestNewFromConcrete(t *testing.T) {
 		t.Errorf("Expected fifoBox should be 2, got %d", fifoBox.Size())
 	}
 }
+
+func TestOverflowPolicy(t *testing.T) {
+	tests := []struct {
+		strategy Strategy
+		policy   OverflowPolicy
+		want     []int
+	}{
+		{StrategyFIFO, OverflowDropOldest, []int{3, 4, 5}},
+		{StrategyFIFO, OverflowDropNewest, []int{1, 2, 3}},
+		{StrategyLIFO, OverflowDropOldest, []int{3, 4, 5}},
+		{StrategyLIFO, OverflowDropNewest, []int{1, 2, 3}},
+		{StrategyRandom, OverflowDropNewest, []int{1, 2, 3}},
+	}
+	for _, tt := range tests {
+		box := New[int](WithStrategy(tt.strategy), WithMaxSize(3), WithOverflowPolicy(tt.policy))
+		for i := 1; i <= 5; i++ {
+			if err := box.Put(i); err != nil {
+				t.Fatalf("Strategy=%v Policy=%v Failed to put item %d: %v", tt.strategy, tt.policy, i, err)
+			}
+		}
+		if got := box.Items(); !EqualInts(got, tt.want) {
+			t.Errorf("Strategy=%v Policy=%v Expected items %v, got %v", tt.strategy, tt.policy, tt.want, got)
+		}
+	}
+
+	// Random evicts some item to make room, always keeping the newest.
+	box := New[int](WithMaxSize(3), WithOverflowPolicy(OverflowDropOldest))
+	for i := 1; i <= 5; i++ {
+		box.Put(i)
+	}
+	if box.Size() != 3 || !ContainsInt(box.Items(), 5) {
+		t.Errorf("Expected 3 items including 5, got %v", box.Items())
+	}
+
+	// With age bias, Random knows the insertion order.
+	box = New[int](WithMaxSize(2), WithOverflowPolicy(OverflowDropOldest), WithAgeBias(func(time.Duration) float64 { return 1 }))
+	rb := box.(*randomBox[int])
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	rb.now = clock.Now
+	for i := 1; i <= 4; i++ {
+		box.Put(i)
+		clock.Advance(time.Second)
+	}
+	if items := box.Items(); !ContainsInt(items, 3) || !ContainsInt(items, 4) {
+		t.Errorf("Expected items 3 and 4, got %v", items)
+	}
+
+	// Default keeps returning ErrBlackBoxFull.
+	box = New[int](WithStrategy(StrategyLIFO), WithMaxSize(1))
+	box.Put(1)
+	if err := box.Put(2); err != ErrBlackBoxFull {
+		t.Errorf("Expected ErrBlackBoxFull, got %v", err)
+	}
+}
+
+func TestEvictCallback(t *testing.T) {
+	strategies := []Strategy{StrategyFIFO, StrategyLIFO, StrategyRandom}
+	for _, strategy := range strategies {
+		var evicted []int
+		onEvict := WithEvictCallback(func(item int) {
+			evicted = append(evicted, item)
+		})
+
+		box := New[int](WithStrategy(strategy), WithMaxSize(2), WithOverflowPolicy(OverflowDropNewest), onEvict)
+		box.Put(1)
+		box.Put(2)
+		box.Put(3)
+		if !EqualInts(evicted, []int{3}) {
+			t.Errorf("Strategy=%v Expected discarded new item to be evicted, got %v", strategy, evicted)
+		}
+
+		evicted = nil
+		box.Clean()
+		if len(evicted) != 2 || !ContainsInt(evicted, 1) || !ContainsInt(evicted, 2) {
+			t.Errorf("Strategy=%v Expected Clean() to evict all items, got %v", strategy, evicted)
+		}
+
+		evicted = nil
+		box = NewFrom[int]([]int{1, 2}, WithStrategy(strategy), WithMaxSize(2), WithOverflowPolicy(OverflowDropOldest), onEvict)
+		box.Put(3)
+		if len(evicted) != 1 || ContainsInt(box.Items(), evicted[0]) || !ContainsInt(box.Items(), 3) {
+			t.Errorf("Strategy=%v Expected one old item evicted, got %v with items %v", strategy, evicted, box.Items())
+		}
+		if strategy != StrategyRandom && evicted[0] != 1 {
+			t.Errorf("Strategy=%v Expected oldest item 1 to be evicted, got %v", strategy, evicted)
+		}
+
+		// Get is not an eviction.
+		evicted = nil
+		box.Get()
+		if len(evicted) != 0 {
+			t.Errorf("Strategy=%v Expected Get not to evict, got %v", strategy, evicted)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+// Package blackboxtest provides helpers for testing BlackBox implementations
+// and the code that uses them.
+//
+// RunConformance checks that a custom backend honors the BlackBox semantics:
+//
+//	func TestConformance(t *testing.T) {
+//		blackboxtest.RunConformance(t, func(maxSize int) blackbox.BlackBox[int] {
+//			return mybackend.NewFIFO[int](newStore(t), maxSize)
+//		})
+//	}
+package blackboxtest
+
+import (
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/raditzlawliet/blackbox"
+)
+
+// Factory returns a new, empty box holding at most maxSize items, where 0
+// means unlimited. It is called once per subtest, so the boxes it returns
+// must not share their items.
+type Factory func(maxSize int) blackbox.BlackBox[int]
+
+// RunConformance runs the BlackBox conformance suite as subtests of t:
+//   - a new box is empty, and Get and Peek return ErrEmptyBlackBox
+//   - every item put is returned exactly once by Get, in any order
+//   - Peek returns a queued item without removing it
+//   - a bounded box reports MaxSize, IsFull once full, and Put returns an
+//     error matching ErrBlackBoxFull without changing the items
+//   - an unbounded box has a MaxSize of 0 and is never full
+//   - Clean empties the box, which stays usable
+//   - Items returns a copy of the queued items
+//
+// The suite does not check the retrieval order, which depends on the strategy.
+func RunConformance(t *testing.T, factory Factory) {
+	t.Run("Empty", func(t *testing.T) { testEmpty(t, factory(0)) })
+	t.Run("PutGet", func(t *testing.T) { testPutGet(t, factory(0)) })
+	t.Run("Peek", func(t *testing.T) { testPeek(t, factory(0)) })
+	t.Run("MaxSize", func(t *testing.T) { testMaxSize(t, factory(3)) })
+	t.Run("Unbounded", func(t *testing.T) { testUnbounded(t, factory(0)) })
+	t.Run("Clean", func(t *testing.T) { testClean(t, factory(0)) })
+	t.Run("Items", func(t *testing.T) { testItems(t, factory(0)) })
+}
+
+func testEmpty(t *testing.T, box blackbox.BlackBox[int]) {
+	expectEmpty(t, box)
+	if box.IsFull() {
+		t.Error("Expected an empty box not to be full")
+	}
+}
+
+func testPutGet(t *testing.T, box blackbox.BlackBox[int]) {
+	put(t, box, 10)
+	if box.Size() != 10 || box.IsEmpty() {
+		t.Fatalf("Expected 10 items, got %d", box.Size())
+	}
+	expectItems(t, box, 10)
+
+	got := make([]int, 0, 10)
+	for i := 0; i < 10; i++ {
+		item, err := box.Get()
+		if err != nil {
+			t.Fatalf("Get %d failed: %v", i, err)
+		}
+		got = append(got, item)
+		if box.Size() != 9-i {
+			t.Errorf("Expected %d items after Get, got %d", 9-i, box.Size())
+		}
+	}
+	if !isSequence(got, 10) {
+		t.Errorf("Expected each item once, got %v", got)
+	}
+	expectEmpty(t, box)
+}
+
+func testPeek(t *testing.T, box blackbox.BlackBox[int]) {
+	put(t, box, 5)
+	for i := 0; i < 3; i++ {
+		item, err := box.Peek()
+		if err != nil {
+			t.Fatalf("Peek failed: %v", err)
+		}
+		if item < 0 || item >= 5 {
+			t.Errorf("Expected Peek to return a queued item, got %d", item)
+		}
+	}
+	if box.Size() != 5 {
+		t.Errorf("Expected Peek to keep the items, got %d", box.Size())
+	}
+	expectItems(t, box, 5)
+}
+
+func testMaxSize(t *testing.T, box blackbox.BlackBox[int]) {
+	if box.MaxSize() != 3 {
+		t.Errorf("Expected MaxSize 3, got %d", box.MaxSize())
+	}
+	put(t, box, 3)
+	if !box.IsFull() {
+		t.Error("Expected the box to be full")
+	}
+	if err := box.Put(3); !errors.Is(err, blackbox.ErrBlackBoxFull) {
+		t.Errorf("Expected ErrBlackBoxFull, got %v", err)
+	}
+	if box.Size() != 3 {
+		t.Errorf("Expected 3 items, got %d", box.Size())
+	}
+	expectItems(t, box, 3)
+
+	if _, err := box.Get(); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if box.IsFull() {
+		t.Error("Expected the box not to be full after Get")
+	}
+	if err := box.Put(3); err != nil {
+		t.Errorf("Expected Put to succeed after Get, got %v", err)
+	}
+}
+
+func testUnbounded(t *testing.T, box blackbox.BlackBox[int]) {
+	if box.MaxSize() != 0 {
+		t.Errorf("Expected MaxSize 0, got %d", box.MaxSize())
+	}
+	put(t, box, 100)
+	if box.IsFull() {
+		t.Error("Expected an unbounded box never to be full")
+	}
+}
+
+func testClean(t *testing.T, box blackbox.BlackBox[int]) {
+	put(t, box, 5)
+	box.Clean()
+	expectEmpty(t, box)
+
+	put(t, box, 2)
+	if box.Size() != 2 {
+		t.Errorf("Expected the box to be usable after Clean, got %d items", box.Size())
+	}
+}
+
+func testItems(t *testing.T, box blackbox.BlackBox[int]) {
+	put(t, box, 3)
+	items := box.Items()
+	for i := range items {
+		items[i] = -1
+	}
+	expectItems(t, box, 3)
+}
+
+// put puts the items 0 to n-1.
+func put(t *testing.T, box blackbox.BlackBox[int], n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		if err := box.Put(i); err != nil {
+			t.Fatalf("Put %d failed: %v", i, err)
+		}
+	}
+}
+
+// expectItems checks that Items holds the items 0 to n-1 in any order.
+func expectItems(t *testing.T, box blackbox.BlackBox[int], n int) {
+	t.Helper()
+	if items := box.Items(); !isSequence(items, n) {
+		t.Errorf("Expected the items 0 to %d, got %v", n-1, items)
+	}
+}
+
+func expectEmpty(t *testing.T, box blackbox.BlackBox[int]) {
+	t.Helper()
+	if box.Size() != 0 || !box.IsEmpty() {
+		t.Errorf("Expected an empty box, got %d items", box.Size())
+	}
+	if items := box.Items(); len(items) != 0 {
+		t.Errorf("Expected no items, got %v", items)
+	}
+	if _, err := box.Get(); !errors.Is(err, blackbox.ErrEmptyBlackBox) {
+		t.Errorf("Expected ErrEmptyBlackBox from Get, got %v", err)
+	}
+	if _, err := box.Peek(); !errors.Is(err, blackbox.ErrEmptyBlackBox) {
+		t.Errorf("Expected ErrEmptyBlackBox from Peek, got %v", err)
+	}
+}
+
+// isSequence reports whether items holds 0 to n-1 in any order.
+func isSequence(items []int, n int) bool {
+	if len(items) != n {
+		return false
+	}
+	sorted := append([]int(nil), items...)
+	sort.Ints(sorted)
+	for i, item := range sorted {
+		if item != i {
+			return false
+		}
+	}
+	return true
+}
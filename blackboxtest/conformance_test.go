@@ -0,0 +1,41 @@
+package blackboxtest
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/raditzlawliet/blackbox"
+)
+
+func TestConformance(t *testing.T) {
+	factories := map[string]Factory{
+		"Random": func(maxSize int) blackbox.BlackBox[int] {
+			return blackbox.NewRandom[int](maxSize, 0, rand.New(rand.NewSource(1)))
+		},
+		"FIFO":        func(maxSize int) blackbox.BlackBox[int] { return blackbox.NewFIFO[int](maxSize, 0) },
+		"LIFO":        func(maxSize int) blackbox.BlackBox[int] { return blackbox.NewLIFO[int](maxSize, 0) },
+		"Deque":       func(maxSize int) blackbox.BlackBox[int] { return blackbox.NewDeque[int](maxSize, 0) },
+		"FIFOList":    func(maxSize int) blackbox.BlackBox[int] { return blackbox.NewFIFOList[int](maxSize) },
+		"ChunkedFIFO": func(maxSize int) blackbox.BlackBox[int] { return blackbox.NewChunkedFIFO[int](maxSize, 4) },
+		"LockFree":    func(maxSize int) blackbox.BlackBox[int] { return blackbox.NewLockFreeLIFO[int](maxSize) },
+		"Sorted": func(maxSize int) blackbox.BlackBox[int] {
+			return blackbox.NewSorted(func(a, b int) bool { return a < b }, maxSize)
+		},
+		"Concurrent": func(maxSize int) blackbox.BlackBox[int] {
+			return blackbox.NewConcurrent[int](blackbox.NewFIFO[int](maxSize, 0))
+		},
+		"TTL": func(maxSize int) blackbox.BlackBox[int] {
+			return blackbox.New[int](blackbox.WithMaxSize(maxSize), blackbox.WithTTL(time.Hour))
+		},
+		"Tickets": func(maxSize int) blackbox.BlackBox[int] {
+			return blackbox.New[int](blackbox.WithMaxSize(maxSize), blackbox.WithTickets())
+		},
+		"Metered": func(maxSize int) blackbox.BlackBox[int] {
+			return blackbox.NewMetered[int](blackbox.NewLIFO[int](maxSize, 0))
+		},
+	}
+	for name, factory := range factories {
+		t.Run(name, func(t *testing.T) { RunConformance(t, factory) })
+	}
+}
@@ -0,0 +1,183 @@
+package blackboxtest
+
+import (
+	"sync"
+	"time"
+
+	"github.com/raditzlawliet/blackbox"
+)
+
+// EveryCall scripts every call of a method that has no action of its own,
+// see Mock.OnPut.
+const EveryCall = 0
+
+// Action scripts one call of a Mock method.
+type Action struct {
+	// Latency is slept before the call runs.
+	Latency time.Duration
+	// Err, if set, is returned instead of calling the underlying box.
+	Err error
+}
+
+// Mock is a BlackBox[T] whose Put, Get and Peek calls can be scripted to fail
+// or be delayed, e.g. to simulate a full, empty or flaky box when testing a
+// consumer. Unscripted calls go to the underlying box. It is safe for
+// concurrent use when the underlying box is.
+type Mock[T any] struct {
+	box blackbox.BlackBox[T]
+
+	mu    sync.Mutex
+	put   script
+	get   script
+	peek  script
+	items []T
+}
+
+// script holds the actions of one method and counts its calls.
+type script struct {
+	calls   int
+	actions map[int]Action
+}
+
+// next counts a call and returns its action.
+func (s *script) next() Action {
+	s.calls++
+	if a, ok := s.actions[s.calls]; ok {
+		return a
+	}
+	return s.actions[EveryCall]
+}
+
+func (s *script) set(call int, a Action) {
+	if s.actions == nil {
+		s.actions = make(map[int]Action)
+	}
+	s.actions[call] = a
+}
+
+// NewMock creates a Mock backed by box, or by an unbounded FIFO box if box is nil.
+func NewMock[T any](box blackbox.BlackBox[T]) *Mock[T] {
+	if box == nil {
+		box = blackbox.NewFIFO[T](0, 0)
+	}
+	return &Mock[T]{box: box}
+}
+
+// OnPut scripts the given call of Put, counting from 1, or every call with
+// EveryCall. For example OnPut(3, Action{Err: blackbox.ErrBlackBoxFull})
+// makes the third Put fail without inserting the item.
+func (m *Mock[T]) OnPut(call int, a Action) *Mock[T] {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.put.set(call, a)
+	return m
+}
+
+// OnGet scripts the given call of Get, counting from 1, or every call with
+// EveryCall. A failing Get leaves the items in the box.
+func (m *Mock[T]) OnGet(call int, a Action) *Mock[T] {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.get.set(call, a)
+	return m
+}
+
+// OnPeek scripts the given call of Peek, counting from 1, or every call with
+// EveryCall.
+func (m *Mock[T]) OnPeek(call int, a Action) *Mock[T] {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.peek.set(call, a)
+	return m
+}
+
+// PutCalls returns the number of Put calls so far, failed ones included.
+func (m *Mock[T]) PutCalls() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.put.calls
+}
+
+// GetCalls returns the number of Get calls so far, failed ones included.
+func (m *Mock[T]) GetCalls() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.get.calls
+}
+
+// PeekCalls returns the number of Peek calls so far, failed ones included.
+func (m *Mock[T]) PeekCalls() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.peek.calls
+}
+
+// Puts returns the items passed to Put so far, failed ones included.
+func (m *Mock[T]) Puts() []T {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]T(nil), m.items...)
+}
+
+func (m *Mock[T]) Put(item T) error {
+	m.mu.Lock()
+	a := m.put.next()
+	m.items = append(m.items, item)
+	m.mu.Unlock()
+	time.Sleep(a.Latency)
+	if a.Err != nil {
+		return a.Err
+	}
+	return m.box.Put(item)
+}
+
+func (m *Mock[T]) Get() (T, error) {
+	m.mu.Lock()
+	a := m.get.next()
+	m.mu.Unlock()
+	time.Sleep(a.Latency)
+	if a.Err != nil {
+		var zero T
+		return zero, a.Err
+	}
+	return m.box.Get()
+}
+
+func (m *Mock[T]) Peek() (T, error) {
+	m.mu.Lock()
+	a := m.peek.next()
+	m.mu.Unlock()
+	time.Sleep(a.Latency)
+	if a.Err != nil {
+		var zero T
+		return zero, a.Err
+	}
+	return m.box.Peek()
+}
+
+func (m *Mock[T]) Size() int {
+	return m.box.Size()
+}
+
+func (m *Mock[T]) MaxSize() int {
+	return m.box.MaxSize()
+}
+
+func (m *Mock[T]) IsFull() bool {
+	return m.box.IsFull()
+}
+
+func (m *Mock[T]) IsEmpty() bool {
+	return m.box.IsEmpty()
+}
+
+func (m *Mock[T]) Clean() {
+	m.box.Clean()
+}
+
+func (m *Mock[T]) Items() []T {
+	return m.box.Items()
+}
+
+// Compile-time assertion that Mock implements BlackBox[T].
+var _ blackbox.BlackBox[any] = (*Mock[any])(nil)
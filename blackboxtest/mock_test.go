@@ -0,0 +1,73 @@
+package blackboxtest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/raditzlawliet/blackbox"
+)
+
+func TestMock(t *testing.T) {
+	flaky := errors.New("flaky")
+	m := NewMock[int](nil).
+		OnPut(3, Action{Err: blackbox.ErrBlackBoxFull}).
+		OnGet(1, Action{Err: flaky})
+
+	for i := 1; i <= 4; i++ {
+		err := m.Put(i)
+		if i == 3 && !errors.Is(err, blackbox.ErrBlackBoxFull) {
+			t.Errorf("Expected ErrBlackBoxFull on the third Put, got %v", err)
+		}
+		if i != 3 && err != nil {
+			t.Errorf("Expected Put %d to succeed, got %v", i, err)
+		}
+	}
+	if items := m.Items(); len(items) != 3 || items[2] != 4 {
+		t.Errorf("Expected [1 2 4], got %v", items)
+	}
+	if puts := m.Puts(); len(puts) != 4 || m.PutCalls() != 4 {
+		t.Errorf("Expected 4 recorded puts, got %v", puts)
+	}
+
+	if _, err := m.Get(); err != flaky {
+		t.Errorf("Expected the scripted error, got %v", err)
+	}
+	if item, err := m.Get(); err != nil || item != 1 {
+		t.Errorf("Expected 1, got %d, %v", item, err)
+	}
+	if m.GetCalls() != 2 || m.Size() != 2 {
+		t.Errorf("Expected 2 calls and 2 items, got %d and %d", m.GetCalls(), m.Size())
+	}
+}
+
+func TestMockEveryCall(t *testing.T) {
+	m := NewMock[int](blackbox.NewLIFO[int](0, 0)).
+		OnPeek(EveryCall, Action{Err: blackbox.ErrEmptyBlackBox}).
+		OnPeek(2, Action{})
+	m.Put(1)
+	for i := 1; i <= 3; i++ {
+		_, err := m.Peek()
+		if (i == 2) != (err == nil) {
+			t.Errorf("Peek %d: unexpected error %v", i, err)
+		}
+	}
+	if m.PeekCalls() != 3 {
+		t.Errorf("Expected 3 calls, got %d", m.PeekCalls())
+	}
+
+	m.OnGet(EveryCall, Action{Latency: 20 * time.Millisecond})
+	start := time.Now()
+	if item, _ := m.Get(); item != 1 {
+		t.Errorf("Expected 1, got %d", item)
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("Expected the latency to be injected, took %v", elapsed)
+	}
+}
+
+func TestMockConformance(t *testing.T) {
+	RunConformance(t, func(maxSize int) blackbox.BlackBox[int] {
+		return NewMock[int](blackbox.NewFIFO[int](maxSize, 0))
+	})
+}
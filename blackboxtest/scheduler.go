@@ -0,0 +1,236 @@
+package blackboxtest
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+
+	"github.com/raditzlawliet/blackbox"
+)
+
+// ErrScheduleDiverged is returned by Scheduler.Run when a replayed schedule
+// no longer matches what the workers do.
+var ErrScheduleDiverged = errors.New("blackboxtest: schedule diverged")
+
+// Step is one turn of a Scheduler: the worker that ran and the box call it
+// ran, or "start" for the code before its first call.
+type Step struct {
+	Worker string
+	Op     string
+}
+
+func (s Step) String() string {
+	return s.Worker + ":" + s.Op
+}
+
+// Scheduler runs concurrent workers one at a time, switching between them
+// only at calls to the boxes wrapped with Schedule, so that a test sees the
+// same interleaving of Put and Get calls on every run instead of relying on
+// sleeps. The order is drawn from a seed, or replayed from the steps of an
+// earlier run, e.g. logged when a test failed.
+//
+// Workers must not block on anything but scheduled boxes, such as channels
+// or the blocking Get of a concurrent box, since the others are paused
+// meanwhile and Run would deadlock.
+type Scheduler struct {
+	rng    *rand.Rand
+	replay []Step
+
+	mu      sync.Mutex
+	running bool
+	current *worker
+	workers []*worker
+	steps   []Step
+	parked  chan struct{}
+}
+
+type worker struct {
+	name string
+	fn   func()
+	turn chan struct{}
+	op   string
+	done bool
+	err  error
+}
+
+// NewScheduler creates a Scheduler that picks the next worker at random
+// from seed; the same seed gives the same interleaving.
+func NewScheduler(seed int64) *Scheduler {
+	return &Scheduler{rng: rand.New(rand.NewSource(seed)), parked: make(chan struct{})}
+}
+
+// NewReplayScheduler creates a Scheduler that replays steps, as returned by
+// Steps after an earlier run.
+func NewReplayScheduler(steps []Step) *Scheduler {
+	return &Scheduler{replay: steps, parked: make(chan struct{})}
+}
+
+// Go adds a worker that runs fn when Run is called.
+func (s *Scheduler) Go(name string, fn func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.workers = append(s.workers, &worker{name: name, fn: fn, turn: make(chan struct{}), op: "start"})
+}
+
+// Run runs the workers until they all return. It returns the first panic of
+// a worker as an error, or an error matching ErrScheduleDiverged when a
+// replayed schedule does not match, leaving the remaining workers parked.
+// Run can be called once.
+func (s *Scheduler) Run() error {
+	s.mu.Lock()
+	s.running = true
+	workers := s.workers
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.running = false
+		s.current = nil
+		s.mu.Unlock()
+	}()
+
+	for _, w := range workers {
+		go s.start(w)
+	}
+	var runErr error
+	for {
+		var runnable []*worker
+		for _, w := range workers {
+			if !w.done {
+				runnable = append(runnable, w)
+			}
+		}
+		if len(runnable) == 0 {
+			break
+		}
+		w, err := s.pick(runnable)
+		if err != nil {
+			return err
+		}
+		s.mu.Lock()
+		s.steps = append(s.steps, Step{Worker: w.name, Op: w.op})
+		s.current = w
+		s.mu.Unlock()
+		w.turn <- struct{}{}
+		<-s.parked
+		if w.err != nil && runErr == nil {
+			runErr = w.err
+		}
+	}
+	return runErr
+}
+
+// Steps returns the interleaving of the last Run, to replay it with
+// NewReplayScheduler.
+func (s *Scheduler) Steps() []Step {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Step(nil), s.steps...)
+}
+
+// start runs a worker once it gets its first turn.
+func (s *Scheduler) start(w *worker) {
+	<-w.turn
+	defer func() {
+		if r := recover(); r != nil {
+			w.err = fmt.Errorf("blackboxtest: worker %s panicked: %v", w.name, r)
+		}
+		w.done = true
+		s.parked <- struct{}{}
+	}()
+	w.fn()
+}
+
+// pick selects the worker of the next step.
+func (s *Scheduler) pick(runnable []*worker) (*worker, error) {
+	if s.replay == nil {
+		return runnable[s.rng.Intn(len(runnable))], nil
+	}
+	n := len(s.steps)
+	if n >= len(s.replay) {
+		return nil, fmt.Errorf("%w: step %d is past the end of the schedule", ErrScheduleDiverged, n)
+	}
+	want := s.replay[n]
+	for _, w := range runnable {
+		if w.name == want.Worker && w.op == want.Op {
+			return w, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: step %d expected %s", ErrScheduleDiverged, n, want)
+}
+
+// yield parks the running worker before it calls op, until the scheduler
+// gives it the next turn. Calls made outside Run go through at once.
+func (s *Scheduler) yield(op string) {
+	s.mu.Lock()
+	w := s.current
+	if !s.running || w == nil {
+		s.mu.Unlock()
+		return
+	}
+	s.mu.Unlock()
+	w.op = op
+	s.parked <- struct{}{}
+	<-w.turn
+}
+
+// scheduledBox is a BlackBox[T] whose calls are switching points of a Scheduler.
+type scheduledBox[T any] struct {
+	box   blackbox.BlackBox[T]
+	sched *Scheduler
+}
+
+// Schedule wraps box so that every call from a worker of s is a point where
+// s may switch to another worker. The calls run one at a time, so box does
+// not need to be goroutine-safe.
+// Returns a concrete instance of scheduled blackbox.
+func Schedule[T any](s *Scheduler, box blackbox.BlackBox[T]) *scheduledBox[T] {
+	return &scheduledBox[T]{box: box, sched: s}
+}
+
+func (b *scheduledBox[T]) Put(item T) error {
+	b.sched.yield("Put")
+	return b.box.Put(item)
+}
+
+func (b *scheduledBox[T]) Get() (T, error) {
+	b.sched.yield("Get")
+	return b.box.Get()
+}
+
+func (b *scheduledBox[T]) Peek() (T, error) {
+	b.sched.yield("Peek")
+	return b.box.Peek()
+}
+
+func (b *scheduledBox[T]) Size() int {
+	b.sched.yield("Size")
+	return b.box.Size()
+}
+
+func (b *scheduledBox[T]) MaxSize() int {
+	return b.box.MaxSize()
+}
+
+func (b *scheduledBox[T]) IsFull() bool {
+	b.sched.yield("IsFull")
+	return b.box.IsFull()
+}
+
+func (b *scheduledBox[T]) IsEmpty() bool {
+	b.sched.yield("IsEmpty")
+	return b.box.IsEmpty()
+}
+
+func (b *scheduledBox[T]) Clean() {
+	b.sched.yield("Clean")
+	b.box.Clean()
+}
+
+func (b *scheduledBox[T]) Items() []T {
+	b.sched.yield("Items")
+	return b.box.Items()
+}
+
+// Compile-time assertion that scheduledBox implements BlackBox[T].
+var _ blackbox.BlackBox[any] = (*scheduledBox[any])(nil)
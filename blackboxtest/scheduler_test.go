@@ -0,0 +1,104 @@
+package blackboxtest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/raditzlawliet/blackbox"
+)
+
+// race runs two consumers that check IsEmpty before Get, a check-then-act
+// bug that fails when both see the single item, and returns their errors.
+func race(s *Scheduler) (errs []error) {
+	box := Schedule[int](s, blackbox.NewFIFO[int](0, 0))
+	box.Put(1)
+	for i := 0; i < 2; i++ {
+		s.Go(fmt.Sprint("consumer", i), func() {
+			if !box.IsEmpty() {
+				if _, err := box.Get(); err != nil {
+					errs = append(errs, err)
+				}
+			}
+		})
+	}
+	if err := s.Run(); err != nil {
+		panic(err)
+	}
+	return errs
+}
+
+func TestSchedulerDeterministic(t *testing.T) {
+	var seed int64 = -1
+	for i := int64(0); i < 100 && seed < 0; i++ {
+		if len(race(NewScheduler(i))) > 0 {
+			seed = i
+		}
+	}
+	if seed < 0 {
+		t.Fatal("Expected a seed to interleave the consumers")
+	}
+
+	s := NewScheduler(seed)
+	errs := race(s)
+	if len(errs) != 1 || !errors.Is(errs[0], blackbox.ErrEmptyBlackBox) {
+		t.Errorf("Expected the seed to reproduce ErrEmptyBlackBox, got %v", errs)
+	}
+	again := NewScheduler(seed)
+	race(again)
+	if fmt.Sprint(again.Steps()) != fmt.Sprint(s.Steps()) {
+		t.Errorf("Expected the same steps, got %v and %v", s.Steps(), again.Steps())
+	}
+
+	replay := NewReplayScheduler(s.Steps())
+	if errs := race(replay); len(errs) != 1 {
+		t.Errorf("Expected the replay to reproduce the failure, got %v", errs)
+	}
+	if fmt.Sprint(replay.Steps()) != fmt.Sprint(s.Steps()) {
+		t.Errorf("Expected the replayed steps %v, got %v", s.Steps(), replay.Steps())
+	}
+}
+
+func TestSchedulerProducerConsumer(t *testing.T) {
+	s := NewScheduler(1)
+	box := Schedule[int](s, blackbox.NewFIFO[int](2, 0))
+	var got []int
+	s.Go("producer", func() {
+		for i := 0; i < 5; {
+			if box.Put(i) == nil {
+				i++
+			}
+		}
+	})
+	s.Go("consumer", func() {
+		for len(got) < 5 {
+			if item, err := box.Get(); err == nil {
+				got = append(got, item)
+			}
+		}
+	})
+	if err := s.Run(); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if fmt.Sprint(got) != "[0 1 2 3 4]" {
+		t.Errorf("Expected [0 1 2 3 4], got %v", got)
+	}
+	if steps := s.Steps(); len(steps) == 0 || steps[0].Op != "start" {
+		t.Errorf("Expected the steps to start with a start step, got %v", steps)
+	}
+}
+
+func TestSchedulerErrors(t *testing.T) {
+	s := NewScheduler(1)
+	s.Go("panicky", func() { panic("boom") })
+	if err := s.Run(); err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("Expected the panic as an error, got %v", err)
+	}
+
+	replay := NewReplayScheduler([]Step{{Worker: "other", Op: "start"}})
+	replay.Go("worker", func() {})
+	if err := replay.Run(); !errors.Is(err, ErrScheduleDiverged) {
+		t.Errorf("Expected ErrScheduleDiverged, got %v", err)
+	}
+}
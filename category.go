@@ -0,0 +1,179 @@
+package blackbox
+
+// categoryBox enforces a per-category ceiling on top of any BlackBox[T].
+type categoryBox[T any, K comparable] struct {
+	box    BlackBox[T]
+	key    func(T) K
+	limit  int
+	counts map[K]int
+	// size is the size of box after the last call; when it differs, items
+	// left the box on their own, e.g. evicted or expired, and counts is rebuilt.
+	size int
+	// overflow decides what Put does when the category is full, and onEvict
+	// receives the items it evicts or discards.
+	overflow OverflowPolicy
+	onEvict  func(item T)
+}
+
+// NewCategoryLimit wraps box so that at most maxPerKey items of the same
+// category, as returned by keyFn, can be in the box at once. Put returns
+// ErrCategoryFull when the category is at its limit. Items already in box
+// are counted but never rejected. A maxPerKey of 0 means unlimited.
+func NewCategoryLimit[T any, K comparable](box BlackBox[T], keyFn func(T) K, maxPerKey int) *categoryBox[T, K] {
+	c := &categoryBox[T, K]{
+		box:   box,
+		key:   keyFn,
+		limit: maxPerKey,
+	}
+	c.sync()
+	return c
+}
+
+// NewCategoryLimitPolicy is like NewCategoryLimit, but Put applies policy
+// within the category when it is at its limit: OverflowDropOldest evicts
+// the oldest item of the category, in the order of Items, to make room, and
+// OverflowDropNewest discards the new item and returns nil. Evicting needs
+// box to implement Remover[T]; otherwise Put returns ErrCategoryFull.
+func NewCategoryLimitPolicy[T any, K comparable](box BlackBox[T], keyFn func(T) K, maxPerKey int, policy OverflowPolicy) *categoryBox[T, K] {
+	c := NewCategoryLimit(box, keyFn, maxPerKey)
+	c.overflow = policy
+	return c
+}
+
+// sync rebuilds the counts from the items of the box.
+func (c *categoryBox[T, K]) sync() {
+	c.counts = make(map[K]int)
+	eachItem(c.box, func(item T) bool {
+		c.counts[c.key(item)]++
+		return true
+	})
+	c.size = c.box.Size()
+}
+
+// check rebuilds the counts if items left the box without going through it.
+func (c *categoryBox[T, K]) check() {
+	if c.box.Size() != c.size {
+		c.sync()
+	}
+}
+
+// evict reports an item removed by the box itself to the evict callback.
+func (c *categoryBox[T, K]) evict(item T) {
+	if c.onEvict != nil {
+		c.onEvict(item)
+	}
+}
+
+// CategorySize returns the number of items of the given category in the box.
+func (c *categoryBox[T, K]) CategorySize(key K) int {
+	c.check()
+	return c.counts[key]
+}
+
+func (c *categoryBox[T, K]) Put(item T) error {
+	c.check()
+	k := c.key(item)
+	if c.limit > 0 && c.counts[k] >= c.limit {
+		switch c.overflow {
+		case OverflowDropNewest:
+			c.evict(item)
+			return nil
+		case OverflowDropOldest:
+			if !c.evictOldest(k) {
+				return ErrCategoryFull
+			}
+		default:
+			return ErrCategoryFull
+		}
+	}
+	if err := c.box.Put(item); err != nil {
+		return err
+	}
+	c.counts[k]++
+	c.size++
+	c.check()
+	return nil
+}
+
+// evictOldest removes the first item of category k in the order of Items.
+func (c *categoryBox[T, K]) evictOldest(k K) bool {
+	r, ok := c.box.(Remover[T])
+	if !ok {
+		return false
+	}
+	found := false
+	removed := r.TakeFunc(func(item T) bool {
+		if found || c.key(item) != k {
+			return false
+		}
+		found = true
+		return true
+	})
+	for _, item := range removed {
+		c.release(item)
+		c.evict(item)
+	}
+	return len(removed) > 0
+}
+
+func (c *categoryBox[T, K]) Get() (T, error) {
+	c.check()
+	item, err := c.box.Get()
+	if err != nil {
+		return item, err
+	}
+	c.release(item)
+	return item, nil
+}
+
+// release uncounts an item that left the box.
+func (c *categoryBox[T, K]) release(item T) {
+	k := c.key(item)
+	if c.counts[k] <= 1 {
+		delete(c.counts, k)
+	} else {
+		c.counts[k]--
+	}
+	c.size--
+}
+
+func (c *categoryBox[T, K]) Peek() (T, error) {
+	return c.box.Peek()
+}
+
+func (c *categoryBox[T, K]) Size() int {
+	return c.box.Size()
+}
+
+func (c *categoryBox[T, K]) MaxSize() int {
+	return c.box.MaxSize()
+}
+
+func (c *categoryBox[T, K]) IsFull() bool {
+	return c.box.IsFull()
+}
+
+func (c *categoryBox[T, K]) IsEmpty() bool {
+	return c.box.IsEmpty()
+}
+
+func (c *categoryBox[T, K]) Clean() {
+	c.box.Clean()
+	c.counts = make(map[K]int)
+	c.size = 0
+}
+
+func (c *categoryBox[T, K]) Items() []T {
+	return c.box.Items()
+}
+
+func (c *categoryBox[T, K]) mutatesOnRead() bool {
+	return mutatesOnRead(c.box)
+}
+
+func (c *categoryBox[T, K]) mutatesOnPeek() bool {
+	return mutatesOnPeek(c.box)
+}
+
+// Compile-time assertion that categoryBox implements BlackBox[T].
+var _ BlackBox[any] = (*categoryBox[any, string])(nil)
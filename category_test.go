@@ -0,0 +1,133 @@
+package blackbox
+
+import (
+	"testing"
+	"time"
+)
+
+type categoryJob struct {
+	customer string
+	id       int
+}
+
+func TestCategoryLimit(t *testing.T) {
+	box := New[categoryJob](
+		WithStrategy(StrategyFIFO),
+		WithMaxSize(10),
+		WithCategoryLimit(func(j categoryJob) string { return j.customer }, 2),
+	)
+
+	for i := 1; i <= 2; i++ {
+		if err := box.Put(categoryJob{customer: "a", id: i}); err != nil {
+			t.Fatalf("Failed to put job %d: %v", i, err)
+		}
+	}
+	if err := box.Put(categoryJob{customer: "a", id: 3}); err != ErrCategoryFull {
+		t.Errorf("Expected ErrCategoryFull, got %v", err)
+	}
+	if err := box.Put(categoryJob{customer: "b", id: 4}); err != nil {
+		t.Errorf("Expected other category to be accepted, got %v", err)
+	}
+	if box.Size() != 3 {
+		t.Errorf("Expected size 3, got %d", box.Size())
+	}
+
+	// Getting a job frees a slot in its category.
+	if job, _ := box.Get(); job.id != 1 {
+		t.Errorf("Expected job 1, got %d", job.id)
+	}
+	if err := box.Put(categoryJob{customer: "a", id: 5}); err != nil {
+		t.Errorf("Expected freed slot to be reusable, got %v", err)
+	}
+
+	box.Clean()
+	for i := 0; i < 2; i++ {
+		if err := box.Put(categoryJob{customer: "a", id: i}); err != nil {
+			t.Errorf("Expected Clean() to reset category counts, got %v", err)
+		}
+	}
+}
+
+func TestCategoryLimitRespectsInnerBox(t *testing.T) {
+	box := NewCategoryLimit[int, bool](NewLIFOFrom[int]([]int{1, 3, 5}, 4), func(i int) bool { return i%2 == 0 }, 2)
+	if got := box.CategorySize(false); got != 3 {
+		t.Errorf("Expected existing items to be counted, got %d", got)
+	}
+	if err := box.Put(7); err != ErrCategoryFull {
+		t.Errorf("Expected ErrCategoryFull, got %v", err)
+	}
+	if err := box.Put(2); err != nil {
+		t.Errorf("Failed to put item: %v", err)
+	}
+	if err := box.Put(4); err != ErrBlackBoxFull {
+		t.Errorf("Expected ErrBlackBoxFull from inner box, got %v", err)
+	}
+	if got := box.CategorySize(true); got != 1 {
+		t.Errorf("Expected rejected Put not to be counted, got %d", got)
+	}
+	for !box.IsEmpty() {
+		box.Get()
+	}
+	if got := box.CategorySize(false); got != 0 {
+		t.Errorf("Expected counts to drain, got %d", got)
+	}
+}
+
+func TestTypedOptionForOtherTypeIsIgnored(t *testing.T) {
+	box := New[int](WithCategoryLimit(func(s string) string { return s }, 1))
+	box.Put(1)
+	if err := box.Put(1); err != nil {
+		t.Errorf("Expected option for another item type to be ignored, got %v", err)
+	}
+}
+
+func TestCategoryLimitReleasesExpiredAndEvicted(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	id := func(s string) string { return s }
+	box := New[string](WithStrategy(StrategyFIFO), WithTTL(time.Second), WithClock(clock), WithCategoryLimit(id, 1))
+	box.Put("a")
+	clock.Advance(2 * time.Second)
+	if err := box.Put("a"); err != nil {
+		t.Errorf("Expected the expired item to free its category, got %v", err)
+	}
+
+	// Items evicted by the box overflow policy free their category too.
+	evicting := New[string](WithStrategy(StrategyFIFO), WithMaxSize(2), WithOverflowPolicy(OverflowDropOldest), WithCategoryLimit(id, 1))
+	evicting.Put("a")
+	evicting.Put("b")
+	evicting.Put("c")
+	if err := evicting.Put("a"); err != nil {
+		t.Errorf("Expected the evicted item to free its category, got %v", err)
+	}
+}
+
+func TestCategoryLimitPolicy(t *testing.T) {
+	customer := func(j categoryJob) string { return j.customer }
+	var evicted []int
+	box := New[categoryJob](
+		WithStrategy(StrategyFIFO),
+		WithOverflowPolicy(OverflowDropOldest),
+		WithEvictCallback(func(j categoryJob) { evicted = append(evicted, j.id) }),
+		WithCategoryLimit(customer, 2),
+	)
+	for i := 1; i <= 4; i++ {
+		box.Put(categoryJob{customer: "a", id: i})
+	}
+	box.Put(categoryJob{customer: "b", id: 5})
+	var ids []int
+	for _, j := range box.Items() {
+		ids = append(ids, j.id)
+	}
+	if !EqualInts(ids, []int{3, 4, 5}) || !EqualInts(evicted, []int{1, 2}) {
+		t.Errorf("Expected the oldest jobs of the category to be evicted, got %v and %v", ids, evicted)
+	}
+
+	newest := NewCategoryLimitPolicy[categoryJob, string](NewLIFO[categoryJob](0, 0), customer, 1, OverflowDropNewest)
+	newest.Put(categoryJob{customer: "a", id: 1})
+	if err := newest.Put(categoryJob{customer: "a", id: 2}); err != nil {
+		t.Errorf("Expected the new job to be discarded silently, got %v", err)
+	}
+	if item, _ := newest.Peek(); newest.Size() != 1 || item.id != 1 {
+		t.Errorf("Expected only job 1, got %v", newest.Items())
+	}
+}
@@ -0,0 +1,81 @@
+package blackbox
+
+import "errors"
+
+// chainBox overflows from a primary box into a secondary one.
+type chainBox[T any] struct {
+	primary   BlackBox[T]
+	secondary BlackBox[T]
+}
+
+// NewChain returns a box that puts items into primary and overflows into
+// secondary once primary is full, and gets items from primary before
+// secondary. This enables hot/cold tiering, e.g. a memory primary backed by
+// a disk secondary such as NewSpill.
+// Returns a concrete instance of chain blackbox.
+func NewChain[T any](primary, secondary BlackBox[T]) *chainBox[T] {
+	return &chainBox[T]{primary: primary, secondary: secondary}
+}
+
+func (c *chainBox[T]) Put(item T) error {
+	if err := c.primary.Put(item); !errors.Is(err, ErrBlackBoxFull) {
+		return err
+	}
+	return c.secondary.Put(item)
+}
+
+func (c *chainBox[T]) Get() (T, error) {
+	if !c.primary.IsEmpty() {
+		return c.primary.Get()
+	}
+	return c.secondary.Get()
+}
+
+func (c *chainBox[T]) Peek() (T, error) {
+	if !c.primary.IsEmpty() {
+		return c.primary.Peek()
+	}
+	return c.secondary.Peek()
+}
+
+func (c *chainBox[T]) Size() int {
+	return c.primary.Size() + c.secondary.Size()
+}
+
+// MaxSize returns the combined maximum size, 0 if either box is unbounded.
+func (c *chainBox[T]) MaxSize() int {
+	p, s := c.primary.MaxSize(), c.secondary.MaxSize()
+	if p == 0 || s == 0 {
+		return 0
+	}
+	return p + s
+}
+
+func (c *chainBox[T]) IsFull() bool {
+	return c.primary.IsFull() && c.secondary.IsFull()
+}
+
+func (c *chainBox[T]) IsEmpty() bool {
+	return c.primary.IsEmpty() && c.secondary.IsEmpty()
+}
+
+func (c *chainBox[T]) Clean() {
+	c.primary.Clean()
+	c.secondary.Clean()
+}
+
+// Items returns the items of primary followed by the items of secondary.
+func (c *chainBox[T]) Items() []T {
+	return append(c.primary.Items(), c.secondary.Items()...)
+}
+
+func (c *chainBox[T]) mutatesOnRead() bool {
+	return mutatesOnRead(c.primary) || mutatesOnRead(c.secondary)
+}
+
+func (c *chainBox[T]) mutatesOnPeek() bool {
+	return mutatesOnPeek(c.primary) || mutatesOnPeek(c.secondary)
+}
+
+// Compile-time assertion that chainBox implements BlackBox[T].
+var _ BlackBox[any] = (*chainBox[any])(nil)
@@ -0,0 +1,43 @@
+package blackbox
+
+import "testing"
+
+func TestChain(t *testing.T) {
+	box := NewChain[int](NewFIFO[int](2, 2), NewFIFO[int](2, 2))
+	if box.MaxSize() != 4 {
+		t.Errorf("Expected MaxSize 4, got %d", box.MaxSize())
+	}
+	for i := 1; i <= 4; i++ {
+		if err := box.Put(i); err != nil {
+			t.Fatalf("Failed to put item %d: %v", i, err)
+		}
+	}
+	if err := box.Put(5); err != ErrBlackBoxFull {
+		t.Errorf("Expected ErrBlackBoxFull, got %v", err)
+	}
+	if !box.IsFull() || box.Size() != 4 {
+		t.Errorf("Expected a full chain of 4 items, got size %d", box.Size())
+	}
+	if !EqualInts(box.primary.Items(), []int{1, 2}) || !EqualInts(box.secondary.Items(), []int{3, 4}) {
+		t.Errorf("Expected overflow into secondary, got %v and %v", box.primary.Items(), box.secondary.Items())
+	}
+
+	box.Get()
+	box.Put(6)
+	var got []int
+	for !box.IsEmpty() {
+		item, _ := box.Get()
+		got = append(got, item)
+	}
+	if !EqualInts(got, []int{2, 6, 3, 4}) {
+		t.Errorf("Expected primary items first [2 6 3 4], got %v", got)
+	}
+	if _, err := box.Peek(); err != ErrEmptyBlackBox {
+		t.Errorf("Expected ErrEmptyBlackBox, got %v", err)
+	}
+
+	unbounded := NewChain[int](NewFIFO[int](1, 1), NewFIFO[int](0, 1))
+	if unbounded.MaxSize() != 0 {
+		t.Errorf("Expected unbounded chain, got MaxSize %d", unbounded.MaxSize())
+	}
+}
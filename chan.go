@@ -0,0 +1,83 @@
+package blackbox
+
+import "context"
+
+// ToChan streams items out of the box into the returned channel until ctx is
+// done, after which the channel is closed. Items are removed with GetCtx, so
+// the stream waits for new items instead of ending when the box is empty.
+//
+// An item that was already taken out of the box when ctx is done is put back
+// (at the position Put would place it) before the channel is closed. If the
+// box rejects it, e.g. because it is full by then, the item is still sent to
+// a receiver that keeps reading, or put back once the box changes.
+func (c *concurrentBox[T]) ToChan(ctx context.Context) <-chan T {
+	ch := make(chan T)
+	go func() {
+		defer close(ch)
+		for {
+			item, err := c.GetCtx(ctx)
+			if err != nil {
+				return
+			}
+			select {
+			case ch <- item:
+			case <-ctx.Done():
+				c.putBack(ch, item)
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// putBack returns an item taken out by ToChan to the box, retrying after
+// every change of the box until it is accepted or ch is received from.
+func (c *concurrentBox[T]) putBack(ch chan<- T, item T) {
+	for {
+		c.mu.Lock()
+		if err := c.box.Put(item); err == nil {
+			c.broadcast()
+			c.mu.Unlock()
+			return
+		}
+		wait := c.waitCh()
+		c.mu.Unlock()
+
+		select {
+		case ch <- item:
+			return
+		case <-wait:
+		}
+	}
+}
+
+// FromChan puts every item received from ch into box until ch is closed,
+// which returns nil, or ctx is done, which returns ctx.Err().
+//
+// When box supports PutCtx (such as the concurrent wrapper), FromChan waits
+// for space in a full box; otherwise the first Put error is returned and the
+// item that failed is not inserted.
+func FromChan[T any](ctx context.Context, ch <-chan T, box BlackBox[T]) error {
+	blocking, canBlock := box.(interface {
+		PutCtx(ctx context.Context, item T) error
+	})
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case item, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			var err error
+			if canBlock {
+				err = blocking.PutCtx(ctx, item)
+			} else {
+				err = box.Put(item)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
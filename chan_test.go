@@ -0,0 +1,116 @@
+package blackbox
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestToChan(t *testing.T) {
+	box := NewConcurrent[int](NewFIFOFrom[int]([]int{1, 2}, 0))
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := box.ToChan(ctx)
+
+	if item := <-ch; item != 1 {
+		t.Fatalf("Expected 1, got %d", item)
+	}
+	if item := <-ch; item != 2 {
+		t.Fatalf("Expected 2, got %d", item)
+	}
+
+	// The stream waits for new items instead of closing on empty.
+	go box.Put(3)
+	select {
+	case item := <-ch:
+		if item != 3 {
+			t.Fatalf("Expected 3, got %d", item)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("ToChan did not deliver a late item")
+	}
+
+	cancel()
+	for range ch {
+	}
+	if !box.IsEmpty() {
+		t.Errorf("Expected box to stay empty, got size %d", box.Size())
+	}
+}
+
+func TestToChanPutsBackInFlightItem(t *testing.T) {
+	box := NewConcurrent[int](NewFIFO[int](0, 4))
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := box.ToChan(ctx)
+
+	box.Put(1)
+	// Nobody receives, so the item is in flight when ctx is cancelled.
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	for range ch {
+	}
+	if item, err := box.Get(); err != nil || item != 1 {
+		t.Fatalf("Expected in-flight item to be put back, got %d, %v", item, err)
+	}
+}
+
+func TestToChanKeepsInFlightItemOfFullBox(t *testing.T) {
+	box := NewConcurrent[int](NewFIFO[int](1, 1))
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := box.ToChan(ctx)
+
+	box.Put(1)
+	time.Sleep(10 * time.Millisecond)
+	box.Put(2)
+	// The box is full again while item 1 is in flight.
+	cancel()
+	time.Sleep(10 * time.Millisecond)
+
+	wait, stop := context.WithTimeout(context.Background(), 5*time.Second)
+	defer stop()
+	if item, err := box.GetCtx(wait); err != nil || item != 2 {
+		t.Fatalf("Expected item 2, got %d, %v", item, err)
+	}
+	if item, err := box.GetCtx(wait); err != nil || item != 1 {
+		t.Fatalf("Expected in-flight item 1 to be put back once there is room, got %d, %v", item, err)
+	}
+	for range ch {
+	}
+}
+
+func TestFromChan(t *testing.T) {
+	ch := make(chan int, 3)
+	ch <- 1
+	ch <- 2
+	ch <- 3
+	close(ch)
+
+	box := NewFIFO[int](0, 4)
+	if err := FromChan[int](context.Background(), ch, box); err != nil {
+		t.Fatalf("FromChan returned unexpected error: %v", err)
+	}
+	if got := box.Items(); !EqualInts(got, []int{1, 2, 3}) {
+		t.Fatalf("Expected [1 2 3], got %v", got)
+	}
+
+	// A plain bounded box fails fast.
+	ch = make(chan int, 2)
+	ch <- 1
+	ch <- 2
+	if err := FromChan[int](context.Background(), ch, NewLIFO[int](1, 1)); err != ErrBlackBoxFull {
+		t.Fatalf("Expected ErrBlackBoxFull, got %v", err)
+	}
+
+	// A concurrent box applies backpressure until ctx is done.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	ch = make(chan int, 2)
+	ch <- 1
+	ch <- 2
+	cbox := NewConcurrent[int](NewLIFO[int](1, 1))
+	if err := FromChan[int](ctx, ch, cbox); err != context.DeadlineExceeded {
+		t.Fatalf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if cbox.Size() != 1 {
+		t.Fatalf("Expected 1 item, got %d", cbox.Size())
+	}
+}
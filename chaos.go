@@ -0,0 +1,150 @@
+package blackbox
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// ChaosConfig configures the fault-injection decorator returned by NewChaos.
+// Every rate is a probability in the range [0, 1]; a zero rate disables that fault.
+type ChaosConfig struct {
+	// Seed seeds the fault RNG so a failing run can be replayed exactly.
+	Seed int64
+	// FullRate is the probability that Put fails with ErrBlackBoxFull without inserting.
+	FullRate float64
+	// EmptyRate is the probability that Get or Peek fails with ErrEmptyBlackBox.
+	EmptyRate float64
+	// LatencyRate is the probability that Put, Get or Peek is delayed before running.
+	LatencyRate float64
+	// MaxLatency is the upper bound of an injected delay.
+	MaxLatency time.Duration
+	// ReorderRate is the probability that Get returns the item after the next one,
+	// putting the skipped item back into the box. If the box rejects it, Get
+	// puts the other item back instead and returns the skipped one, so no item
+	// is lost; only if the box rejects both does Get return the error along
+	// with the item.
+	ReorderRate float64
+}
+
+// chaosBox is a fault-injecting wrapper around any BlackBox[T].
+// It is meant for resilience testing of consumers, never for production use.
+type chaosBox[T any] struct {
+	box BlackBox[T]
+	cfg ChaosConfig
+	rng *rand.Rand
+	mu  sync.Mutex // guards rng
+}
+
+// NewChaos wraps box and randomly injects ErrBlackBoxFull, ErrEmptyBlackBox,
+// latency and reordering according to cfg. Faults are drawn from an RNG seeded
+// with cfg.Seed, so the same sequence of calls always sees the same faults.
+func NewChaos[T any](box BlackBox[T], cfg ChaosConfig) BlackBox[T] {
+	return &chaosBox[T]{
+		box: box,
+		cfg: cfg,
+		rng: rand.New(rand.NewSource(cfg.Seed)),
+	}
+}
+
+// roll returns true with the given probability.
+func (c *chaosBox[T]) roll(rate float64) bool {
+	if rate <= 0 {
+		return false
+	}
+	c.mu.Lock()
+	hit := c.rng.Float64() < rate
+	c.mu.Unlock()
+	return hit
+}
+
+// delay sleeps for a random duration up to MaxLatency when the latency fault fires.
+func (c *chaosBox[T]) delay() {
+	if c.cfg.MaxLatency <= 0 || !c.roll(c.cfg.LatencyRate) {
+		return
+	}
+	c.mu.Lock()
+	d := time.Duration(c.rng.Int63n(int64(c.cfg.MaxLatency) + 1))
+	c.mu.Unlock()
+	time.Sleep(d)
+}
+
+func (c *chaosBox[T]) Put(item T) error {
+	c.delay()
+	if c.roll(c.cfg.FullRate) {
+		return ErrBlackBoxFull
+	}
+	return c.box.Put(item)
+}
+
+func (c *chaosBox[T]) Get() (T, error) {
+	c.delay()
+	if c.roll(c.cfg.EmptyRate) {
+		var zero T
+		return zero, ErrEmptyBlackBox
+	}
+	if c.box.Size() >= 2 && c.roll(c.cfg.ReorderRate) {
+		skipped, err := c.box.Get()
+		if err != nil {
+			return skipped, err
+		}
+		item, err := c.box.Get()
+		if err != nil {
+			return skipped, nil
+		}
+		perr := c.box.Put(skipped)
+		if perr == nil {
+			return item, nil
+		}
+		// No room for the skipped item: hand it out without reordering.
+		if c.box.Put(item) == nil {
+			return skipped, nil
+		}
+		return item, perr
+	}
+	return c.box.Get()
+}
+
+func (c *chaosBox[T]) Peek() (T, error) {
+	c.delay()
+	if c.roll(c.cfg.EmptyRate) {
+		var zero T
+		return zero, ErrEmptyBlackBox
+	}
+	return c.box.Peek()
+}
+
+func (c *chaosBox[T]) Size() int {
+	return c.box.Size()
+}
+
+func (c *chaosBox[T]) MaxSize() int {
+	return c.box.MaxSize()
+}
+
+func (c *chaosBox[T]) IsFull() bool {
+	return c.box.IsFull()
+}
+
+func (c *chaosBox[T]) IsEmpty() bool {
+	return c.box.IsEmpty()
+}
+
+func (c *chaosBox[T]) Clean() {
+	c.box.Clean()
+}
+
+func (c *chaosBox[T]) Items() []T {
+	return c.box.Items()
+}
+
+func (c *chaosBox[T]) mutatesOnRead() bool {
+	return mutatesOnRead(c.box)
+}
+
+func (c *chaosBox[T]) mutatesOnPeek() bool {
+	return mutatesOnPeek(c.box)
+}
+
+// Compile-time assertion that chaosBox implements BlackBox[T].
+var _ BlackBox[any] = (*chaosBox[any])(nil)
@@ -0,0 +1,137 @@
+package blackbox
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestChaosNoFaults(t *testing.T) {
+	box := NewChaos[int](NewFIFO[int](0, 4), ChaosConfig{})
+	for i := 1; i <= 5; i++ {
+		if err := box.Put(i); err != nil {
+			t.Fatalf("Failed to put item %d: %v", i, err)
+		}
+	}
+	for i := 1; i <= 5; i++ {
+		item, err := box.Get()
+		if err != nil {
+			t.Fatalf("Failed to get item: %v", err)
+		}
+		if item != i {
+			t.Errorf("Expected item %d, got %d", i, item)
+		}
+	}
+}
+
+func TestChaosInjectsFullAndEmpty(t *testing.T) {
+	inner := NewFIFO[int](0, 4)
+	box := NewChaos[int](inner, ChaosConfig{FullRate: 1, EmptyRate: 1})
+
+	if err := box.Put(1); err != ErrBlackBoxFull {
+		t.Errorf("Expected ErrBlackBoxFull, got %v", err)
+	}
+	if inner.Size() != 0 {
+		t.Errorf("Expected rejected Put to leave inner box empty, got size %d", inner.Size())
+	}
+
+	inner.Put(1)
+	if _, err := box.Get(); err != ErrEmptyBlackBox {
+		t.Errorf("Expected ErrEmptyBlackBox from Get, got %v", err)
+	}
+	if _, err := box.Peek(); err != ErrEmptyBlackBox {
+		t.Errorf("Expected ErrEmptyBlackBox from Peek, got %v", err)
+	}
+	if box.Size() != 1 {
+		t.Errorf("Expected injected empty to keep the item, got size %d", box.Size())
+	}
+}
+
+func TestChaosReorder(t *testing.T) {
+	box := NewChaos[int](NewFIFOFrom[int]([]int{1, 2, 3}, 0), ChaosConfig{ReorderRate: 1})
+
+	want := []int{2, 1, 3}
+	for _, w := range want {
+		item, err := box.Get()
+		if err != nil {
+			t.Fatalf("Failed to get item: %v", err)
+		}
+		if item != w {
+			t.Errorf("Expected item %d, got %d", w, item)
+		}
+	}
+	if !box.IsEmpty() {
+		t.Error("Box should be empty")
+	}
+}
+
+func TestChaosReorderPutBackError(t *testing.T) {
+	inner := &fullFor{BlackBox: NewFIFOFrom[int]([]int{1, 2}, 0), n: 1}
+	box := NewChaos[int](inner, ChaosConfig{ReorderRate: 1})
+
+	// The skipped item is rejected, so it is returned without reordering.
+	item, err := box.Get()
+	if item != 1 || err != nil {
+		t.Errorf("Expected item 1 without error, got %d and %v", item, err)
+	}
+	if item, _ := box.Get(); item != 2 || !box.IsEmpty() {
+		t.Errorf("Expected item 2 to be kept, got %d with size %d", item, box.Size())
+	}
+
+	inner = &fullFor{BlackBox: NewFIFOFrom[int]([]int{1, 2}, 0), n: 2}
+	box = NewChaos[int](inner, ChaosConfig{ReorderRate: 1})
+	item, err = box.Get()
+	if item != 2 || !errors.Is(err, ErrBlackBoxFull) {
+		t.Errorf("Expected item 2 with ErrBlackBoxFull, got %d and %v", item, err)
+	}
+}
+
+func TestChaosReorderFullBox(t *testing.T) {
+	box := NewChaos[int](NewFIFOFrom[int]([]int{1, 2, 3}, 3), ChaosConfig{ReorderRate: 1})
+	if !box.IsFull() {
+		t.Fatal("Expected a full box")
+	}
+
+	var got []int
+	for !box.IsEmpty() {
+		item, err := box.Get()
+		if err != nil {
+			t.Fatalf("Failed to get item: %v", err)
+		}
+		got = append(got, item)
+	}
+	if !EqualInts(got, []int{2, 1, 3}) {
+		t.Errorf("Expected every item once as [2 1 3], got %v", got)
+	}
+}
+
+func TestChaosSeedIsReproducible(t *testing.T) {
+	cfg := ChaosConfig{Seed: 7, FullRate: 0.5}
+	box1 := NewChaos[int](NewLIFO[int](0, 0), cfg)
+	box2 := NewChaos[int](NewLIFO[int](0, 0), cfg)
+
+	for i := 0; i < 100; i++ {
+		err1 := box1.Put(i)
+		err2 := box2.Put(i)
+		if err1 != err2 {
+			t.Fatalf("Expected identical faults for the same seed at call %d: %v vs %v", i, err1, err2)
+		}
+	}
+	if box1.Size() == 0 || box1.Size() == 100 {
+		t.Errorf("Expected roughly half of the Puts to fail, got size %d", box1.Size())
+	}
+}
+
+func TestChaosLatency(t *testing.T) {
+	box := NewChaos[int](NewFIFO[int](0, 0), ChaosConfig{LatencyRate: 1, MaxLatency: time.Millisecond})
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		box.Put(i)
+	}
+	if time.Since(start) > time.Second {
+		t.Errorf("Injected latency exceeded MaxLatency bounds: %v", time.Since(start))
+	}
+	if box.Size() != 5 {
+		t.Errorf("Expected size 5, got %d", box.Size())
+	}
+}
@@ -0,0 +1,206 @@
+package blackbox
+
+// defaultChunkSize is the chunk size of NewChunkedFIFO when none is given.
+const defaultChunkSize = 64
+
+// chunk is a fixed-size segment of a chunkedFIFO.
+type chunk[T any] struct {
+	items []T
+	next  *chunk[T]
+}
+
+// chunkedFIFO is a FIFO blackbox that stores items in a linked list of
+// fixed-size chunks. Growing links a new chunk instead of copying the whole
+// buffer like the ring of NewFIFO does, and emptied chunks are released.
+type chunkedFIFO[T any] struct {
+	// items are read from head[headIdx] and written to tail[tailIdx].
+	head      *chunk[T]
+	tail      *chunk[T]
+	headIdx   int
+	tailIdx   int
+	size      int
+	chunkSize int
+	maxSize   int
+	overflow  OverflowPolicy
+	onEvict   func(item T)
+	name      string // see WithName
+	// spare keeps one emptied chunk to avoid reallocating when the queue
+	// oscillates around a chunk boundary.
+	spare *chunk[T]
+}
+
+// NewChunkedFIFO creates a new FIFO blackbox with the specified maximum size
+// that stores items in linked chunks of chunkSize items (64 if chunkSize is
+// not positive). Put never copies existing items, so its worst-case latency
+// stays flat even for huge queues, at the cost of slightly slower access than
+// NewFIFO.
+// Returns a concrete instance of chunked fifo blackbox without interface.
+func NewChunkedFIFO[T any](maxSize, chunkSize int) *chunkedFIFO[T] {
+	if chunkSize <= 0 {
+		chunkSize = defaultChunkSize
+	}
+	return &chunkedFIFO[T]{chunkSize: chunkSize, maxSize: maxSize}
+}
+
+// NewChunkedFIFOFrom creates a new chunked FIFO blackbox from a slice of items
+// with the specified maximum size and chunk size.
+func NewChunkedFIFOFrom[T any](items []T, maxSize, chunkSize int) *chunkedFIFO[T] {
+	if maxSize > 0 && maxSize < len(items) {
+		maxSize = len(items)
+	}
+	b := NewChunkedFIFO[T](maxSize, chunkSize)
+	for _, item := range items {
+		b.pushBack(item)
+	}
+	return b
+}
+
+func (b *chunkedFIFO[T]) newChunk() *chunk[T] {
+	if c := b.spare; c != nil {
+		b.spare = nil
+		return c
+	}
+	return &chunk[T]{items: make([]T, b.chunkSize)}
+}
+
+func (b *chunkedFIFO[T]) pushBack(item T) {
+	if b.tail == nil {
+		b.head = b.newChunk()
+		b.tail = b.head
+		b.headIdx, b.tailIdx = 0, 0
+	} else if b.tailIdx == b.chunkSize {
+		c := b.newChunk()
+		b.tail.next = c
+		b.tail = c
+		b.tailIdx = 0
+	}
+	b.tail.items[b.tailIdx] = item
+	b.tailIdx++
+	b.size++
+}
+
+func (b *chunkedFIFO[T]) popFront() T {
+	var zero T
+	item := b.head.items[b.headIdx]
+	b.head.items[b.headIdx] = zero
+	b.headIdx++
+	b.size--
+	if b.size == 0 {
+		// Reuse the only chunk from the start.
+		b.headIdx, b.tailIdx = 0, 0
+	} else if b.headIdx == b.chunkSize {
+		done := b.head
+		b.head = done.next
+		b.headIdx = 0
+		done.next = nil
+		b.spare = done
+	}
+	return item
+}
+
+// evict reports an item removed by the box itself to the evict callback.
+func (b *chunkedFIFO[T]) evict(item T) {
+	if b.onEvict != nil {
+		b.onEvict(item)
+	}
+}
+
+func (b *chunkedFIFO[T]) Put(item T) error {
+	if b.maxSize > 0 && b.size >= b.maxSize {
+		switch b.overflow {
+		case OverflowDropOldest:
+			b.evict(b.popFront())
+		case OverflowDropNewest:
+			b.evict(item)
+			return nil
+		default:
+			return fullError(b.name, b.size, b.maxSize)
+		}
+	}
+	b.pushBack(item)
+	return nil
+}
+
+func (b *chunkedFIFO[T]) Get() (T, error) {
+	if b.size == 0 {
+		var zero T
+		return zero, emptyError(b.name)
+	}
+	return b.popFront(), nil
+}
+
+func (b *chunkedFIFO[T]) Peek() (T, error) {
+	if b.size == 0 {
+		var zero T
+		return zero, emptyError(b.name)
+	}
+	return b.head.items[b.headIdx], nil
+}
+
+func (b *chunkedFIFO[T]) Size() int {
+	return b.size
+}
+
+func (b *chunkedFIFO[T]) MaxSize() int {
+	return b.maxSize
+}
+
+func (b *chunkedFIFO[T]) IsFull() bool {
+	return b.maxSize > 0 && b.size >= b.maxSize
+}
+
+func (b *chunkedFIFO[T]) IsEmpty() bool {
+	return b.size == 0
+}
+
+// forEach calls fn for every item in FIFO order until fn returns false.
+func (b *chunkedFIFO[T]) forEach(fn func(item T) bool) {
+	idx := b.headIdx
+	for c, n := b.head, 0; n < b.size; n++ {
+		if idx == b.chunkSize {
+			c, idx = c.next, 0
+		}
+		if !fn(c.items[idx]) {
+			return
+		}
+		idx++
+	}
+}
+
+func (b *chunkedFIFO[T]) Clean() {
+	if b.onEvict != nil {
+		b.forEach(func(item T) bool {
+			b.onEvict(item)
+			return true
+		})
+	}
+	b.head, b.tail, b.spare = nil, nil, nil
+	b.headIdx, b.tailIdx, b.size = 0, 0, 0
+}
+
+func (b *chunkedFIFO[T]) Items() []T {
+	items := make([]T, 0, b.size)
+	b.forEach(func(item T) bool {
+		items = append(items, item)
+		return true
+	})
+	return items
+}
+
+// removeFunc removes every item for which pred returns true, keeping the
+// order of the others, and returns the removed items.
+func (b *chunkedFIFO[T]) removeFunc(pred func(item T) bool) []T {
+	var removed []T
+	for n := b.size; n > 0; n-- {
+		item := b.popFront()
+		if pred(item) {
+			removed = append(removed, item)
+		} else {
+			b.pushBack(item)
+		}
+	}
+	return removed
+}
+
+// Compile-time assertion that chunkedFIFO implements BlackBox[T].
+var _ BlackBox[any] = (*chunkedFIFO[any])(nil)
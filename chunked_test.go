@@ -0,0 +1,88 @@
+package blackbox
+
+import (
+	"testing"
+	"time"
+)
+
+func TestChunkedFIFO(t *testing.T) {
+	box := New[int](WithStrategy(StrategyFIFO), WithChunkSize(3))
+	if _, ok := box.(*chunkedFIFO[int]); !ok {
+		t.Fatalf("Expected a chunked FIFO, got %T", box)
+	}
+
+	next := 0
+	var want []int
+	for round := 0; round < 5; round++ {
+		for i := 0; i < 7; i++ {
+			box.Put(next)
+			want = append(want, next)
+			next++
+		}
+		if !EqualInts(box.Items(), want) || box.Size() != len(want) {
+			t.Fatalf("Expected %v, got %v", want, box.Items())
+		}
+		for i := 0; i < 5; i++ {
+			item, err := box.Get()
+			if err != nil || item != want[0] {
+				t.Fatalf("Expected %d, got %d (%v)", want[0], item, err)
+			}
+			want = want[1:]
+		}
+	}
+	if item, _ := box.Peek(); item != want[0] {
+		t.Errorf("Expected Peek to return %d, got %d", want[0], item)
+	}
+
+	for !box.IsEmpty() {
+		box.Get()
+	}
+	if _, err := box.Get(); err != ErrEmptyBlackBox {
+		t.Errorf("Expected ErrEmptyBlackBox, got %v", err)
+	}
+	box.Put(1)
+	if !EqualInts(box.Items(), []int{1}) {
+		t.Errorf("Expected [1] after emptying, got %v", box.Items())
+	}
+}
+
+func TestChunkedFIFOOverflow(t *testing.T) {
+	var evicted []int
+	box := NewFrom[int]([]int{1, 2, 3, 4}, WithStrategy(StrategyFIFO), WithChunkSize(2), WithMaxSize(4),
+		WithOverflowPolicy(OverflowDropOldest), WithEvictCallback(func(item int) { evicted = append(evicted, item) }))
+	box.Put(5)
+	if !EqualInts(box.Items(), []int{2, 3, 4, 5}) || !EqualInts(evicted, []int{1}) || !box.IsFull() {
+		t.Errorf("Expected [2 3 4 5] with 1 evicted, got %v and %v", box.Items(), evicted)
+	}
+	box.Clean()
+	if !box.IsEmpty() || !EqualInts(evicted, []int{1, 2, 3, 4, 5}) {
+		t.Errorf("Expected Clean to evict every item, got %v", evicted)
+	}
+
+	bounded := NewChunkedFIFO[int](1, 0)
+	bounded.Put(1)
+	if err := bounded.Put(2); err != ErrBlackBoxFull {
+		t.Errorf("Expected ErrBlackBoxFull, got %v", err)
+	}
+}
+
+func TestChunkedFIFOWithTTL(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	box := New[int](WithStrategy(StrategyFIFO), WithChunkSize(2), WithTTL(time.Minute), WithClock(clock))
+	for i := 1; i <= 5; i++ {
+		box.Put(i)
+		clock.Advance(15 * time.Second)
+	}
+	// 1 and 2 were put a minute or more ago.
+	if items := box.Items(); !EqualInts(items, []int{3, 4, 5}) {
+		t.Errorf("Expected [3 4 5], got %v", items)
+	}
+	box.Put(6)
+	if item, err := box.Get(); err != nil || item != 3 {
+		t.Errorf("Expected 3, got %d (%v)", item, err)
+	}
+	clock.Advance(time.Minute)
+	if !box.IsEmpty() {
+		t.Errorf("Expected all items to expire, got %v", box.Items())
+	}
+}
@@ -0,0 +1,98 @@
+package blackbox
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock provides the current time to time-based features, so tests can
+// replace the wall clock with a fake one.
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc adapts an ordinary function to the Clock interface.
+type ClockFunc func() time.Time
+
+// Now returns f().
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// WithClock makes the time-based features of the box (TTL, retention, dwell
+// statistics, age bias, audit timestamps, duplicate suppression) read the
+// time from clock instead of the wall clock, e.g. to test expiry
+// deterministically with a fake clock. NewMetered, NewAudit and NewSuppress
+// accept it too.
+func WithClock(clock Clock) Option {
+	return func(c *config) {
+		c.clock = clock
+	}
+}
+
+// now returns the configured clock, or the wall clock.
+func (c config) now() Clock {
+	if c.clock == nil {
+		return systemClock{}
+	}
+	return c.clock
+}
+
+// systemClock is the default Clock backed by time.Now.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// wakeTimer calls a wake function registered by a concurrent wrapper once the
+// items held by a box become due, see releaser.
+type wakeTimer struct {
+	mu    sync.Mutex
+	wake  func()
+	timer *time.Timer
+	at    time.Time
+}
+
+// set registers wake, replacing the previous one.
+func (w *wakeTimer) set(wake func()) {
+	w.mu.Lock()
+	w.wake = wake
+	w.mu.Unlock()
+}
+
+// arm makes sure wake is called at due or earlier. A due time that has
+// already passed is ignored: its item is released by the next call on the box.
+func (w *wakeTimer) arm(due, now time.Time) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.wake == nil || !now.Before(due) {
+		return
+	}
+	if w.timer != nil {
+		if !w.at.After(due) {
+			return
+		}
+		w.timer.Stop()
+	}
+	w.at = due
+	w.timer = time.AfterFunc(due.Sub(now), w.fire)
+}
+
+func (w *wakeTimer) fire() {
+	w.mu.Lock()
+	w.timer = nil
+	wake := w.wake
+	w.mu.Unlock()
+	wake()
+}
+
+// stop cancels the pending call, e.g. once the box is cleaned.
+func (w *wakeTimer) stop() {
+	w.mu.Lock()
+	if w.timer != nil {
+		w.timer.Stop()
+		w.timer = nil
+	}
+	w.mu.Unlock()
+}
@@ -0,0 +1,85 @@
+package blackbox
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestWithClock(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+
+	box := New[int](WithTTL(time.Minute), WithClock(clock))
+	box.Put(1)
+	clock.Advance(59 * time.Second)
+	if box.Size() != 1 {
+		t.Errorf("Expected 1 item before the TTL, got %d", box.Size())
+	}
+	clock.Advance(time.Second)
+	if box.Size() != 0 {
+		t.Errorf("Expected the item to expire, got %d", box.Size())
+	}
+
+	retained := New[int](WithRetention(time.Minute), WithClock(clock))
+	retained.Put(1)
+	clock.Advance(30 * time.Second)
+	retained.Put(2)
+	clock.Advance(30 * time.Second)
+	if items := retained.Items(); !EqualInts(items, []int{2}) {
+		t.Errorf("Expected [2], got %v", items)
+	}
+
+	tickets := New[int](WithTickets(), WithTTL(time.Minute), WithClock(clock))
+	tickets.Put(1)
+	clock.Advance(time.Minute)
+	if tickets.Size() != 0 {
+		t.Errorf("Expected the ticketed item to expire, got %d", tickets.Size())
+	}
+
+	suppressed := New[string](WithSuppressWindow(func(s string) string { return s }, time.Minute), WithClock(clock))
+	suppressed.Put("a")
+	suppressed.Get()
+	suppressed.Put("a")
+	if suppressed.Size() != 0 {
+		t.Errorf("Expected the repeat to be suppressed, got %d", suppressed.Size())
+	}
+	clock.Advance(time.Minute)
+	suppressed.Put("a")
+	if suppressed.Size() != 1 {
+		t.Errorf("Expected the item after the window, got %d", suppressed.Size())
+	}
+
+	var log AuditLog
+	audited := New[int](WithAudit(&log), WithClock(clock))
+	audited.Put(1)
+	audited.Get()
+	if records := log.Records(); len(records) != 1 || !records[0].Time.Equal(clock.Now()) {
+		t.Errorf("Expected a record at the fake time, got %+v", records)
+	}
+}
+
+func TestWithClockWrappers(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+
+	metered := NewMetered(New[int](), WithClock(clock))
+	for i := 0; i < 3; i++ {
+		metered.Put(i)
+		clock.Advance(time.Second)
+	}
+	history := metered.Stats().SizeHistory
+	if len(history) != 3 || !history[0].Time.Equal(time.Unix(0, 0)) || history[2].Size != 3 {
+		t.Errorf("Expected 3 samples at the fake times, got %+v", history)
+	}
+
+	var buf bytes.Buffer
+	audited := NewAudit(New[int](), &buf, WithClock(clock))
+	if audited.clock != clock {
+		t.Error("Expected NewAudit to use the given clock")
+	}
+	if NewSuppress(New[int](), nil, time.Second, WithClock(clock)).clock != clock {
+		t.Error("Expected NewSuppress to use the given clock")
+	}
+	if NewMetered(New[int]()).clock != (systemClock{}) {
+		t.Error("Expected the wall clock by default")
+	}
+}
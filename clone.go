@@ -0,0 +1,237 @@
+package blackbox
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"reflect"
+)
+
+// ErrNotClonable is returned by Clone when a box wraps a BlackBox[T] that
+// does not implement Cloner[T].
+var ErrNotClonable = errors.New("blackbox wraps a BlackBox[T] that cannot be cloned")
+
+// Cloner is implemented by blackboxes that can be duplicated, e.g. to run a
+// "what-if" simulation without mutating the original box.
+type Cloner[T any] interface {
+	// Clone returns a copy of the box with the same strategy, maximum size and
+	// options. Items are copied by assignment. A box wrapping a BlackBox[T]
+	// that cannot be cloned returns an error matching ErrNotClonable.
+	Clone() (BlackBox[T], error)
+	// CloneFunc is like Clone, but copies every item with copyFn, e.g. to deep
+	// copy items holding pointers. A nil copyFn copies by assignment.
+	CloneFunc(copyFn func(item T) T) (BlackBox[T], error)
+}
+
+// seededSource is a math/rand source that counts its draws, so that its state
+// can be reproduced by replaying them from the seed.
+type seededSource struct {
+	src   rand.Source64
+	seed  int64
+	draws uint64
+}
+
+func newSeededSource(seed int64) *seededSource {
+	return &seededSource{
+		src:  rand.NewSource(seed).(rand.Source64),
+		seed: seed,
+	}
+}
+
+func (s *seededSource) Seed(seed int64) {
+	s.src.Seed(seed)
+	s.seed = seed
+	s.draws = 0
+}
+
+func (s *seededSource) Int63() int64 {
+	s.draws++
+	return s.src.Int63()
+}
+
+func (s *seededSource) Uint64() uint64 {
+	s.draws++
+	return s.src.Uint64()
+}
+
+// clone returns a source in the same state by copying the state of the
+// underlying generator.
+func (s *seededSource) clone() *seededSource {
+	c := *s
+	v := reflect.ValueOf(s.src)
+	state := reflect.New(v.Elem().Type())
+	state.Elem().Set(v.Elem())
+	c.src = state.Interface().(rand.Source64)
+	return &c
+}
+
+// cloneSlice copies items with copyFn, keeping the capacity of items.
+func cloneSlice[T any](items []T, copyFn func(item T) T) []T {
+	c := make([]T, len(items), cap(items))
+	if copyFn == nil {
+		copy(c, items)
+		return c
+	}
+	for i, item := range items {
+		c[i] = copyFn(item)
+	}
+	return c
+}
+
+func (b *ring[T]) clone(copyFn func(item T) T) ring[T] {
+	c := *b
+	c.items = make([]T, len(b.items))
+	for i := 0; i < b.size; i++ {
+		idx := (b.head + i) % len(b.items)
+		if copyFn == nil {
+			c.items[idx] = b.items[idx]
+		} else {
+			c.items[idx] = copyFn(b.items[idx])
+		}
+	}
+	return c
+}
+
+func (b *fifoBox[T]) Clone() (BlackBox[T], error) {
+	return b.CloneFunc(nil)
+}
+
+func (b *fifoBox[T]) CloneFunc(copyFn func(item T) T) (BlackBox[T], error) {
+	c := *b
+	c.ring = b.ring.clone(copyFn)
+	return &c, nil
+}
+
+func (b *dequeBox[T]) Clone() (BlackBox[T], error) {
+	return b.CloneFunc(nil)
+}
+
+func (b *dequeBox[T]) CloneFunc(copyFn func(item T) T) (BlackBox[T], error) {
+	return &dequeBox[T]{ring: b.ring.clone(copyFn)}, nil
+}
+
+func (b *lifoBox[T]) Clone() (BlackBox[T], error) {
+	return b.CloneFunc(nil)
+}
+
+func (b *lifoBox[T]) CloneFunc(copyFn func(item T) T) (BlackBox[T], error) {
+	c := *b
+	c.items = cloneSlice(b.items, copyFn)
+	return &c, nil
+}
+
+// Clone returns a copy of the box. The RNG state is duplicated too when the
+// box was created by New, NewFrom or NewFromBlackBox with a seeded or default
+// RNG, so the clone draws the same items as the original would. Otherwise the
+// clone shares the RNG of the original.
+func (b *randomBox[T]) Clone() (BlackBox[T], error) {
+	return b.CloneFunc(nil)
+}
+
+// CloneFunc is like Clone, but copies every item with copyFn.
+func (b *randomBox[T]) CloneFunc(copyFn func(item T) T) (BlackBox[T], error) {
+	c := *b
+	c.items = cloneSlice(b.items, copyFn)
+	if b.putAt != nil {
+		c.putAt = cloneSlice(b.putAt, nil)
+	}
+	if b.itemWeights != nil {
+		c.itemWeights = cloneSlice(b.itemWeights, nil)
+	}
+	if b.src != nil {
+		c.src = b.src.clone()
+		c.rng = rand.New(c.src)
+	}
+	return &c, nil
+}
+
+func (b *sortedBox[T]) Clone() (BlackBox[T], error) {
+	return b.CloneFunc(nil)
+}
+
+func (b *sortedBox[T]) CloneFunc(copyFn func(item T) T) (BlackBox[T], error) {
+	c := NewSorted[T](b.less, b.maxSize)
+	for x := b.head.next[0]; x != nil; x = x.next[0] {
+		if copyFn == nil {
+			c.insert(x.item)
+		} else {
+			c.insert(copyFn(x.item))
+		}
+	}
+	return c, nil
+}
+
+// Clone returns a copy of the box; items keep their expiry.
+func (t *timedBox[T]) Clone() (BlackBox[T], error) {
+	return t.CloneFunc(nil)
+}
+
+// CloneFunc is like Clone, but copies every item with copyFn.
+func (t *timedBox[T]) CloneFunc(copyFn func(item T) T) (BlackBox[T], error) {
+	t.purge()
+	c := *t
+	var itemFn func(it timedItem[T]) timedItem[T]
+	if copyFn != nil {
+		itemFn = func(it timedItem[T]) timedItem[T] {
+			it.value = copyFn(it.value)
+			return it
+		}
+	}
+	box, err := cloneBox(t.box, itemFn)
+	if err != nil {
+		return nil, err
+	}
+	c.box = box
+	return &c, nil
+}
+
+// Clone returns a copy of the box and of the wrapped box.
+func (c *categoryBox[T, K]) Clone() (BlackBox[T], error) {
+	return c.CloneFunc(nil)
+}
+
+// CloneFunc is like Clone, but copies every item with copyFn.
+func (c *categoryBox[T, K]) CloneFunc(copyFn func(item T) T) (BlackBox[T], error) {
+	box, err := cloneBox(c.box, copyFn)
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[K]int, len(c.counts))
+	for k, n := range c.counts {
+		counts[k] = n
+	}
+	return &categoryBox[T, K]{
+		box:      box,
+		key:      c.key,
+		limit:    c.limit,
+		counts:   counts,
+		size:     c.size,
+		overflow: c.overflow,
+		onEvict:  c.onEvict,
+	}, nil
+}
+
+// Clone returns a new goroutine-safe box wrapping a copy of the wrapped box.
+func (c *concurrentBox[T]) Clone() (BlackBox[T], error) {
+	return c.CloneFunc(nil)
+}
+
+// CloneFunc is like Clone, but copies every item with copyFn.
+func (c *concurrentBox[T]) CloneFunc(copyFn func(item T) T) (BlackBox[T], error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	box, err := cloneBox(c.box, copyFn)
+	if err != nil {
+		return nil, err
+	}
+	return newConcurrent(box, c.readShared), nil
+}
+
+// cloneBox clones a wrapped box, which must implement Cloner[T].
+func cloneBox[T any](box BlackBox[T], copyFn func(item T) T) (BlackBox[T], error) {
+	cloner, ok := box.(Cloner[T])
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", ErrNotClonable, box)
+	}
+	return cloner.CloneFunc(copyFn)
+}
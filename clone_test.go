@@ -0,0 +1,86 @@
+package blackbox
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestClone(t *testing.T) {
+	data := []int{1, 2, 3}
+	boxes := map[string]BlackBox[int]{
+		"FIFO":       NewFIFOFrom[int](data, 5),
+		"LIFO":       NewLIFOFrom[int](data, 5),
+		"Deque":      NewDequeFrom[int](data, 5),
+		"Sorted":     NewSortedFrom[int](data, lessInt, 5),
+		"Timed":      NewFrom[int](data, WithStrategy(StrategyLIFO), WithMaxSize(5), WithTTL(time.Hour)),
+		"Category":   New[int](WithStrategy(StrategyFIFO), WithMaxSize(5), WithCategoryLimit(isEven, 2)),
+		"Concurrent": NewConcurrent[int](NewFIFOFrom[int](data, 5)),
+	}
+	for name, box := range boxes {
+		if box.IsEmpty() {
+			for _, item := range data {
+				box.Put(item)
+			}
+		}
+		clone, err := box.(Cloner[int]).Clone()
+		if err != nil {
+			t.Fatalf("%s: Clone failed: %v", name, err)
+		}
+		clone.Put(4)
+		if box.Size() != 3 || clone.Size() != 4 || clone.MaxSize() != 5 {
+			t.Errorf("%s: Expected independent clone, got sizes %d and %d (max %d)",
+				name, box.Size(), clone.Size(), clone.MaxSize())
+		}
+		want := Drain(box)
+		got := Drain(clone)
+		if !EqualInts(got[:3], want) && !EqualInts(got[1:], want) {
+			t.Errorf("%s: Expected clone to keep the retrieval order %v, got %v", name, want, got)
+		}
+	}
+
+	category := New[int](WithCategoryLimit(isEven, 1))
+	category.Put(2)
+	clone, _ := category.(Cloner[int]).Clone()
+	if err := clone.Put(4); err != ErrCategoryFull {
+		t.Errorf("Expected clone to keep category counts, got %v", err)
+	}
+}
+
+func TestCloneRandomState(t *testing.T) {
+	box := NewFrom[int]([]int{1, 2, 3, 4, 5, 6, 7, 8}, WithSeed(3))
+	box.Get()
+	box.Peek()
+
+	clone, _ := box.(Cloner[int]).Clone()
+	if want, got := Drain(box), Drain(clone); !EqualInts(got, want) {
+		t.Errorf("Expected clone to draw %v, got %v", want, got)
+	}
+}
+
+func TestCloneFunc(t *testing.T) {
+	type task struct{ tags []string }
+	box := NewFIFO[*task](0, 2)
+	box.Put(&task{tags: []string{"a"}})
+
+	clone, _ := box.CloneFunc(func(item *task) *task {
+		c := *item
+		c.tags = append([]string(nil), item.tags...)
+		return &c
+	})
+	cloned, _ := clone.Peek()
+	cloned.tags[0] = "b"
+	if original, _ := box.Peek(); original.tags[0] != "a" {
+		t.Errorf("Expected deep copy, original changed to %v", original.tags)
+	}
+}
+
+func TestCloneNotClonable(t *testing.T) {
+	if _, err := NewConcurrent[int](NewChaos[int](NewFIFO[int](0, 1), ChaosConfig{})).Clone(); !errors.Is(err, ErrNotClonable) {
+		t.Errorf("Expected ErrNotClonable, got %v", err)
+	}
+	shed := New[int](WithMaxSize(10), WithLoadShedding(0.5), WithCategoryLimit(isEven, 2))
+	if _, err := shed.(Cloner[int]).Clone(); !errors.Is(err, ErrNotClonable) {
+		t.Errorf("Expected ErrNotClonable, got %v", err)
+	}
+}
@@ -0,0 +1,232 @@
+// Command blackbox inspects, dumps, filters and converts box snapshots saved
+// by SaveSnapshot/SaveFile (JSON) or EncodeBinary (binary), e.g. to debug a
+// durable queue offline.
+//
+// Usage:
+//
+//	blackbox inspect [flags] file
+//	blackbox dump    [flags] file
+//	blackbox filter  [flags] -match regexp file
+//	blackbox convert [flags] [-strategy name] [-to json|binary] file
+//
+// A file of "-" reads from standard input. Gzip-compressed JSON snapshots are
+// detected. Flags:
+//
+//	-format json|binary  format of the input (json by default)
+//	-type   item type: json, string, int, float, bool or bytes (json by
+//	        default, which keeps JSON items as they are; binary input needs
+//	        the type the items were encoded with by DefaultItemCodec)
+//	-o      output file of filter and convert (standard output by default)
+//
+// dump prints one JSON-encoded item per line, in the order of Items. filter
+// keeps the items whose JSON encoding matches -match. convert changes the
+// strategy and/or the format of the snapshot.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+
+	"github.com/raditzlawliet/blackbox"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "blackbox:", err)
+		os.Exit(1)
+	}
+}
+
+var errUsage = errors.New("usage: blackbox inspect|dump|filter|convert [flags] file")
+
+type options struct {
+	command  string
+	file     string
+	format   string
+	itemType string
+	output   string
+	match    string
+	strategy string
+	to       string
+}
+
+func parseArgs(args []string) (options, error) {
+	var o options
+	if len(args) == 0 {
+		return o, errUsage
+	}
+	o.command = args[0]
+	fs := flag.NewFlagSet(o.command, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.StringVar(&o.format, "format", "json", "format of the input: json or binary")
+	fs.StringVar(&o.itemType, "type", "json", "item type: json, string, int, float, bool or bytes")
+	fs.StringVar(&o.output, "o", "", "output file")
+	fs.StringVar(&o.match, "match", "", "regexp matched against the JSON encoding of items")
+	fs.StringVar(&o.strategy, "strategy", "", "strategy of the converted snapshot")
+	fs.StringVar(&o.to, "to", "", "format of the converted snapshot: json or binary")
+	if err := fs.Parse(args[1:]); err != nil {
+		return o, err
+	}
+	if fs.NArg() != 1 {
+		return o, errUsage
+	}
+	o.file = fs.Arg(0)
+	if o.to == "" {
+		o.to = o.format
+	}
+	return o, nil
+}
+
+func run(args []string, stdin io.Reader, stdout io.Writer) error {
+	o, err := parseArgs(args)
+	if err != nil {
+		return err
+	}
+	switch o.itemType {
+	case "json":
+		return runTyped[json.RawMessage](o, stdin, stdout)
+	case "string":
+		return runTyped[string](o, stdin, stdout)
+	case "int":
+		return runTyped[int64](o, stdin, stdout)
+	case "float":
+		return runTyped[float64](o, stdin, stdout)
+	case "bool":
+		return runTyped[bool](o, stdin, stdout)
+	case "bytes":
+		return runTyped[[]byte](o, stdin, stdout)
+	}
+	return fmt.Errorf("unknown item type %q", o.itemType)
+}
+
+func runTyped[T any](o options, stdin io.Reader, stdout io.Writer) error {
+	in := stdin
+	if o.file != "-" {
+		f, err := os.Open(o.file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+	env, err := readEnvelope[T](in, o.format)
+	if err != nil {
+		return err
+	}
+
+	switch o.command {
+	case "inspect":
+		fmt.Fprintf(stdout, "strategy: %s\nmaxSize:  %d\nsize:     %d\n", env.Strategy, env.MaxSize, len(env.Items))
+		if env.Seed != nil {
+			fmt.Fprintf(stdout, "seed:     %d\n", *env.Seed)
+		}
+		return nil
+	case "dump":
+		enc := json.NewEncoder(stdout)
+		for _, item := range env.Items {
+			if err := enc.Encode(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "filter":
+		re, err := regexp.Compile(o.match)
+		if err != nil {
+			return err
+		}
+		kept := env.Items[:0]
+		for _, item := range env.Items {
+			data, err := json.Marshal(item)
+			if err != nil {
+				return err
+			}
+			if re.Match(data) {
+				kept = append(kept, item)
+			}
+		}
+		env.Items = kept
+		return writeOutput(o, stdout, env)
+	case "convert":
+		if o.strategy != "" {
+			if _, err := blackbox.ParseStrategy(o.strategy); err != nil && o.strategy != "deque" {
+				return err
+			}
+			env.Strategy = o.strategy
+		}
+		return writeOutput(o, stdout, env)
+	}
+	return errUsage
+}
+
+// readEnvelope reads a snapshot in the given format.
+func readEnvelope[T any](r io.Reader, format string) (blackbox.Envelope[T], error) {
+	var env blackbox.Envelope[T]
+	switch format {
+	case "json":
+		br := bufio.NewReader(r)
+		if magic, _ := br.Peek(2); bytes.Equal(magic, blackbox.GzipCompression.Magic) {
+			zr, err := gzip.NewReader(br)
+			if err != nil {
+				return env, err
+			}
+			defer zr.Close()
+			r = zr
+		} else {
+			r = br
+		}
+		err := json.NewDecoder(r).Decode(&env)
+		return env, err
+	case "binary":
+		box, err := blackbox.DecodeBinary[T](r, blackbox.DefaultItemCodec[T]())
+		if err != nil {
+			return env, err
+		}
+		// The envelope of a decoded box is only exposed through JSON.
+		data, err := json.Marshal(box)
+		if err != nil {
+			return env, err
+		}
+		err = json.Unmarshal(data, &env)
+		return env, err
+	}
+	return env, fmt.Errorf("unknown format %q", format)
+}
+
+// writeOutput writes env to the output file, or stdout, in the format of o.to.
+func writeOutput[T any](o options, stdout io.Writer, env blackbox.Envelope[T]) error {
+	var buf bytes.Buffer
+	switch o.to {
+	case "json":
+		if err := json.NewEncoder(&buf).Encode(env); err != nil {
+			return err
+		}
+	case "binary":
+		data, err := json.Marshal(env)
+		if err != nil {
+			return err
+		}
+		// Restoring the box validates the strategy, as LoadSnapshot would.
+		box, err := blackbox.NewFromJSON[T](data)
+		if err != nil {
+			return err
+		}
+		if err := blackbox.EncodeBinary(&buf, box, blackbox.DefaultItemCodec[T]()); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown format %q", o.to)
+	}
+	if o.output == "" {
+		_, err := stdout.Write(buf.Bytes())
+		return err
+	}
+	return os.WriteFile(o.output, buf.Bytes(), 0o644)
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/raditzlawliet/blackbox"
+)
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "tasks.json")
+	box := blackbox.New[string](blackbox.WithStrategy(blackbox.StrategyFIFO), blackbox.WithMaxSize(10))
+	for _, task := range []string{"send-email", "resize-image", "send-sms"} {
+		box.Put(task)
+	}
+	if err := blackbox.SaveFile(path, box); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := run([]string{"inspect", path}, nil, &out); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "strategy: fifo") || !strings.Contains(out.String(), "size:     3") {
+		t.Errorf("Unexpected inspect output %q", out.String())
+	}
+
+	out.Reset()
+	run([]string{"dump", path}, nil, &out)
+	if out.String() != "\"send-email\"\n\"resize-image\"\n\"send-sms\"\n" {
+		t.Errorf("Unexpected dump output %q", out.String())
+	}
+
+	filtered := filepath.Join(dir, "send.json")
+	if err := run([]string{"filter", "-match", "^\"send-", "-o", filtered, path}, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	f, _ := os.Open(filtered)
+	restored, err := blackbox.LoadSnapshot[string](f)
+	f.Close()
+	if err != nil || restored.Size() != 2 {
+		t.Fatalf("Expected 2 items to be kept, got %v (%v)", restored, err)
+	}
+
+	// Convert to a binary LIFO snapshot and read it back from stdin.
+	converted := filepath.Join(dir, "tasks.bin")
+	if err := run([]string{"convert", "-type", "string", "-strategy", "lifo", "-to", "binary", "-o", converted, path}, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(converted)
+	out.Reset()
+	if err := run([]string{"inspect", "-format", "binary", "-type", "string", "-"}, bytes.NewReader(data), &out); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "strategy: lifo") || !strings.Contains(out.String(), "maxSize:  10") {
+		t.Errorf("Unexpected inspect output %q", out.String())
+	}
+
+	gzipped := filepath.Join(dir, "tasks.json.gz")
+	blackbox.SaveFile(gzipped, box, blackbox.WithSnapshotCompression(blackbox.GzipCompression))
+	out.Reset()
+	if err := run([]string{"dump", gzipped}, nil, &out); err != nil || strings.Count(out.String(), "\n") != 3 {
+		t.Errorf("Expected 3 items from the gzip snapshot, got %q (%v)", out.String(), err)
+	}
+
+	for _, args := range [][]string{
+		{},
+		{"inspect"},
+		{"inspect", "-type", "complex", path},
+		{"convert", "-strategy", "unknown", path},
+		{"unknown", path},
+	} {
+		if err := run(args, nil, &out); err == nil {
+			t.Errorf("%v: Expected an error", args)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package blackbox
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"io"
+)
+
+// Compression compresses snapshots, see WithSnapshotCompression.
+type Compression struct {
+	// Magic is the prefix of compressed data, used by LoadSnapshot to detect
+	// compressed snapshots. When empty, every snapshot is decompressed.
+	Magic     []byte
+	NewWriter func(w io.Writer) (io.WriteCloser, error)
+	NewReader func(r io.Reader) (io.ReadCloser, error)
+}
+
+// GzipCompression compresses snapshots with gzip from the standard library.
+var GzipCompression = Compression{
+	Magic: []byte{0x1f, 0x8b},
+	NewWriter: func(w io.Writer) (io.WriteCloser, error) {
+		return gzip.NewWriter(w), nil
+	},
+	NewReader: func(r io.Reader) (io.ReadCloser, error) {
+		return gzip.NewReader(r)
+	},
+}
+
+// WithSnapshotCompression makes SaveSnapshot and SaveFile compress snapshots
+// with c, e.g. GzipCompression, since large queues serialize to highly
+// compressible JSON. LoadSnapshot and LoadFile detect gzip snapshots on their
+// own; pass the option to them for other compressions, such as zstd from a
+// third-party package. Boxes ignore this option.
+func WithSnapshotCompression(c Compression) Option {
+	return func(cfg *config) {
+		cfg.compression = &c
+	}
+}
+
+// compressWriter wraps w with the configured compression, if any.
+func compressWriter(w io.Writer, opts []Option) (io.WriteCloser, error) {
+	c := parseOptions(opts).compression
+	if c == nil {
+		return nopWriteCloser{w}, nil
+	}
+	return c.NewWriter(w)
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+// decompressReader wraps r with the decompressor of the configured
+// compression or gzip when r starts with its magic.
+func decompressReader(r io.Reader, opts []Option) (io.ReadCloser, error) {
+	br := bufio.NewReader(r)
+	for _, c := range []*Compression{parseOptions(opts).compression, &GzipCompression} {
+		if c == nil {
+			continue
+		}
+		if len(c.Magic) == 0 {
+			return c.NewReader(br)
+		}
+		if magic, _ := br.Peek(len(c.Magic)); bytes.Equal(magic, c.Magic) {
+			return c.NewReader(br)
+		}
+	}
+	return io.NopCloser(br), nil
+}
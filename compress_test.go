@@ -0,0 +1,61 @@
+package blackbox
+
+import (
+	"bytes"
+	"compress/flate"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSnapshotGzip(t *testing.T) {
+	box := New[string](WithStrategy(StrategyFIFO))
+	for i := 0; i < 1000; i++ {
+		box.Put(strings.Repeat("task", 10))
+	}
+
+	var plain, compressed bytes.Buffer
+	SaveSnapshot(&plain, box)
+	if err := SaveSnapshot(&compressed, box, WithSnapshotCompression(GzipCompression)); err != nil {
+		t.Fatalf("Failed to save snapshot: %v", err)
+	}
+	if compressed.Len()*10 > plain.Len() {
+		t.Errorf("Expected a much smaller snapshot, got %d of %d bytes", compressed.Len(), plain.Len())
+	}
+	// gzip is detected without the option.
+	restored, err := LoadSnapshot[string](&compressed)
+	if err != nil || restored.Size() != 1000 {
+		t.Fatalf("Expected 1000 items, got %v (%v)", restored, err)
+	}
+
+	path := filepath.Join(t.TempDir(), "queue.json.gz")
+	SaveFile(path, box, WithSnapshotCompression(GzipCompression))
+	if restored, err := LoadFile[string](path); err != nil || restored.Size() != 1000 {
+		t.Errorf("Expected 1000 items from the file, got %v", err)
+	}
+}
+
+func TestSnapshotCustomCompression(t *testing.T) {
+	// Raw deflate has no magic, so it must be passed to LoadSnapshot.
+	deflate := Compression{
+		NewWriter: func(w io.Writer) (io.WriteCloser, error) {
+			return flate.NewWriter(w, flate.BestSpeed)
+		},
+		NewReader: func(r io.Reader) (io.ReadCloser, error) {
+			return flate.NewReader(r), nil
+		},
+	}
+	var buf bytes.Buffer
+	if err := SaveSnapshot[int](&buf, NewFIFOFrom[int]([]int{1, 2}, 0), WithSnapshotCompression(deflate)); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+	if _, err := LoadSnapshot[int](bytes.NewReader(data)); err == nil {
+		t.Error("Expected an error without the compression option")
+	}
+	box, err := LoadSnapshot[int](bytes.NewReader(data), WithSnapshotCompression(deflate))
+	if err != nil || !EqualInts(box.Items(), []int{1, 2}) {
+		t.Errorf("Expected [1 2], got %v (%v)", box, err)
+	}
+}
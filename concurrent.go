@@ -1,82 +1,420 @@
 package blackbox
 
-import "sync"
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// lazyReader is implemented by boxes that change their content or state in
+// read-only methods such as Size or Peek, e.g. boxes that purge expired items
+// lazily, and by wrappers around them. Their size and items can change
+// without a Put or Get.
+type lazyReader interface {
+	mutatesOnRead() bool
+}
+
+// mutatesOnRead reports whether box changes its content in read-only methods.
+func mutatesOnRead(box any) bool {
+	l, ok := box.(lazyReader)
+	return ok && l.mutatesOnRead()
+}
+
+// peekMutator is implemented by boxes whose Peek changes their state but not
+// their content, e.g. the Random box whose Peek draws from its RNG, and by
+// wrappers around them. Their Peek needs the exclusive lock, but their size
+// and items only change on writes.
+type peekMutator interface {
+	mutatesOnPeek() bool
+}
+
+// mutatesOnPeek reports whether Peek changes the state of box, which is also
+// the case for boxes that change their content in read-only methods.
+func mutatesOnPeek(box any) bool {
+	p, ok := box.(peekMutator)
+	return ok && p.mutatesOnPeek() || mutatesOnRead(box)
+}
+
+// releaser is implemented by boxes that hold items back and make them
+// available on their own as time passes, such as the retry, lease and debounce wrappers.
+type releaser interface {
+	// setWake registers wake to be called once held items are due, so that
+	// a concurrent wrapper can release them and wake up blocked callers.
+	setWake(wake func())
+	// held returns the number of items that are not available yet.
+	held() int
+}
+
+// held returns the number of items box holds back, see releaser.
+func held(box any) int {
+	if r, ok := box.(releaser); ok {
+		return r.held()
+	}
+	return 0
+}
 
 // concurrentBox is a simple goroutine-safe wrapper around any BlackBox[T].
 // It serializes all method calls with a mutex.
 type concurrentBox[T any] struct {
+	// size and maxSize cache the sizes of box so that Size, IsEmpty and
+	// IsFull don't need the lock. They are only used when cached is set.
+	size    int64
+	maxSize int64
+	cached  bool
+	// snap is the *Snapshot[T] shared until the next change, nil if none.
+	snap unsafe.Pointer
+
 	box BlackBox[T]
-	mu  sync.Mutex
+	mu  sync.RWMutex
+	// readShared lets read-only methods share a read lock, see NewConcurrentRW.
+	readShared bool
+	// changed is closed and reset whenever the content changes, waking up
+	// blocked callers. It is nil while nobody is waiting.
+	changed chan struct{}
 }
 
 // NewConcurrent wraps any BlackBox[T] and returns a goroutine-safe BlackBox[T].
 // This is an opt-in wrapper; use the plain boxes directly for maximum
 // performance when you don't need concurrency.
-func NewConcurrent[T any](box BlackBox[T]) BlackBox[T] {
-	return &concurrentBox[T]{box: box}
+// Returns a concrete instance of concurrent blackbox, which also provides blocking operations.
+func NewConcurrent[T any](box BlackBox[T]) *concurrentBox[T] {
+	return newConcurrent(box, false)
+}
+
+// newConcurrent creates a concurrent box, sharing read locks if readShared is
+// set and box doesn't change its state on reads.
+func newConcurrent[T any](box BlackBox[T], readShared bool) *concurrentBox[T] {
+	c := &concurrentBox[T]{
+		box:        box,
+		readShared: readShared && !mutatesOnPeek(box),
+		cached:     !mutatesOnRead(box),
+	}
+	if r, ok := box.(releaser); ok {
+		r.setWake(c.wake)
+	}
+	c.refresh()
+	return c
+}
+
+// wake releases the items the wrapped box held back and wakes up every
+// blocked caller, see releaser.
+func (c *concurrentBox[T]) wake() {
+	c.mu.Lock()
+	// Reads of a releaser release its due items.
+	c.box.Size()
+	c.broadcast()
+	c.mu.Unlock()
+}
+
+// NewConcurrentRW is like NewConcurrent, but Peek, Size, MaxSize, IsFull,
+// IsEmpty and Items only take a read lock, so frequent readers such as a
+// dashboard polling Size don't block producers and consumers.
+// Boxes that purge lazily on reads, such as WithTTL boxes and the debounce,
+// lease and retry wrappers, keep using the exclusive lock.
+// Returns a concrete instance of concurrent blackbox, which also provides blocking operations.
+func NewConcurrentRW[T any](box BlackBox[T]) *concurrentBox[T] {
+	return newConcurrent(box, true)
+}
+
+// rlock locks c for a read-only method.
+func (c *concurrentBox[T]) rlock() {
+	if c.readShared {
+		c.mu.RLock()
+	} else {
+		c.mu.Lock()
+	}
+}
+
+// runlock undoes rlock.
+func (c *concurrentBox[T]) runlock() {
+	if c.readShared {
+		c.mu.RUnlock()
+	} else {
+		c.mu.Unlock()
+	}
+}
+
+// waitCh returns a channel that is closed on the next change of the box.
+// The caller must hold c.mu.
+func (c *concurrentBox[T]) waitCh() <-chan struct{} {
+	if c.changed == nil {
+		c.changed = make(chan struct{})
+	}
+	return c.changed
+}
+
+// refresh updates the cached sizes and drops the shared snapshot. The caller must hold c.mu.
+func (c *concurrentBox[T]) refresh() {
+	if c.cached {
+		atomic.StoreInt64(&c.size, int64(c.box.Size()))
+		atomic.StoreInt64(&c.maxSize, int64(c.box.MaxSize()))
+		atomic.StorePointer(&c.snap, nil)
+	}
+}
+
+// broadcast records a change of the content: it refreshes the cached state
+// and wakes up every blocked caller. The caller must hold c.mu.
+func (c *concurrentBox[T]) broadcast() {
+	c.refresh()
+	if c.changed != nil {
+		close(c.changed)
+		c.changed = nil
+	}
 }
 
 func (c *concurrentBox[T]) Put(item T) error {
 	c.mu.Lock()
 	err := c.box.Put(item)
+	if err == nil {
+		c.broadcast()
+	}
 	c.mu.Unlock()
 	return err
 }
 
+// PutCtx inserts an item like Put, but blocks while the box is full until
+// space is freed or ctx is done, in which case ctx.Err() is returned. This
+// gives bounded boxes proper producer backpressure.
+// Only changes made through this wrapper wake up a blocked PutCtx.
+func (c *concurrentBox[T]) PutCtx(ctx context.Context, item T) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		c.mu.Lock()
+		err := c.box.Put(item)
+		if !errors.Is(err, ErrBlackBoxFull) {
+			if err == nil {
+				c.broadcast()
+			}
+			c.mu.Unlock()
+			return err
+		}
+		wait := c.waitCh()
+		c.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+		case <-wait:
+		}
+	}
+}
+
 func (c *concurrentBox[T]) Get() (T, error) {
 	c.mu.Lock()
 	item, err := c.box.Get()
+	if err == nil {
+		c.broadcast()
+	}
 	c.mu.Unlock()
 	return item, err
 }
 
+// GetCtx removes and returns an item like Get, but blocks while the box is
+// empty until an item is put or ctx is done, in which case ctx.Err() is returned.
+// Only changes made through this wrapper, and items released by a wrapped
+// retry, lease or debounce box when they are due, wake up a blocked GetCtx.
+func (c *concurrentBox[T]) GetCtx(ctx context.Context) (T, error) {
+	for {
+		if err := ctx.Err(); err != nil {
+			var zero T
+			return zero, err
+		}
+		c.mu.Lock()
+		item, err := c.box.Get()
+		if !errors.Is(err, ErrEmptyBlackBox) {
+			if err == nil {
+				c.broadcast()
+			}
+			c.mu.Unlock()
+			return item, err
+		}
+		wait := c.waitCh()
+		c.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+		case <-wait:
+		}
+	}
+}
+
+// waitUntil blocks until cond, called with the lock held, returns true or
+// ctx is done, in which case ctx.Err() is returned.
+func (c *concurrentBox[T]) waitUntil(ctx context.Context, cond func() bool) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		c.mu.Lock()
+		if cond() {
+			c.mu.Unlock()
+			return nil
+		}
+		wait := c.waitCh()
+		c.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+		case <-wait:
+		}
+	}
+}
+
+// WaitNotEmpty blocks until the box holds at least one item or ctx is done,
+// in which case ctx.Err() is returned. Another consumer may still take the
+// item first, so use GetCtx to wait for an item and take it atomically.
+// Only changes made through this wrapper, and items released by a wrapped
+// retry, lease or debounce box when they are due, wake up a blocked WaitNotEmpty.
+func (c *concurrentBox[T]) WaitNotEmpty(ctx context.Context) error {
+	return c.waitUntil(ctx, func() bool { return !c.box.IsEmpty() })
+}
+
+// WaitNotFull blocks until the box has room for an item or ctx is done, in
+// which case ctx.Err() is returned. Another producer may still fill the box
+// first, so use PutCtx to wait for room and put atomically.
+// Only changes made through this wrapper wake up a blocked WaitNotFull.
+func (c *concurrentBox[T]) WaitNotFull(ctx context.Context) error {
+	return c.waitUntil(ctx, func() bool { return !c.box.IsFull() })
+}
+
 func (c *concurrentBox[T]) Peek() (T, error) {
-	c.mu.Lock()
+	c.rlock()
 	item, err := c.box.Peek()
-	c.mu.Unlock()
+	c.runlock()
 	return item, err
 }
 
+// Size returns the number of items. Unless the wrapped box purges lazily on
+// reads, it is read from an atomic counter without taking the lock; changes
+// made to the wrapped box directly are then not reflected.
 func (c *concurrentBox[T]) Size() int {
-	c.mu.Lock()
+	if c.cached {
+		return int(atomic.LoadInt64(&c.size))
+	}
+	c.rlock()
 	size := c.box.Size()
-	c.mu.Unlock()
+	c.runlock()
 	return size
 }
 
 func (c *concurrentBox[T]) MaxSize() int {
-	c.mu.Lock()
+	c.rlock()
 	size := c.box.MaxSize()
-	c.mu.Unlock()
+	c.runlock()
 	return size
 }
 
 func (c *concurrentBox[T]) IsFull() bool {
-	c.mu.Lock()
+	if c.cached {
+		maxSize := atomic.LoadInt64(&c.maxSize)
+		return maxSize > 0 && atomic.LoadInt64(&c.size) >= maxSize
+	}
+	c.rlock()
 	isFull := c.box.IsFull()
-	c.mu.Unlock()
+	c.runlock()
 	return isFull
 }
 
 func (c *concurrentBox[T]) IsEmpty() bool {
-	c.mu.Lock()
+	if c.cached {
+		return atomic.LoadInt64(&c.size) == 0
+	}
+	c.rlock()
 	isEmpty := c.box.IsEmpty()
-	c.mu.Unlock()
+	c.runlock()
 	return isEmpty
 }
 
 func (c *concurrentBox[T]) Clean() {
 	c.mu.Lock()
 	c.box.Clean()
+	c.broadcast()
 	c.mu.Unlock()
 }
 
 func (c *concurrentBox[T]) Items() []T {
-	c.mu.Lock()
+	c.rlock()
 	items := c.box.Items()
-	c.mu.Unlock()
+	c.runlock()
 	return items
 }
 
+// Snapshot is an immutable view of a box taken in one atomic read. It can be
+// read and iterated without holding any lock and is safe to share between
+// goroutines.
+type Snapshot[T any] struct {
+	items   []T
+	size    int
+	maxSize int
+}
+
+// Items returns a copy of the items in the order returned by Items of the box.
+func (s Snapshot[T]) Items() []T {
+	return cloneSlice(s.items, nil)
+}
+
+// Len returns the number of items in the snapshot.
+func (s Snapshot[T]) Len() int {
+	return len(s.items)
+}
+
+// At returns the i-th item of the snapshot.
+func (s Snapshot[T]) At(i int) T {
+	return s.items[i]
+}
+
+// Range calls fn for every item in order until fn returns false.
+func (s Snapshot[T]) Range(fn func(i int, item T) bool) {
+	for i, item := range s.items {
+		if !fn(i, item) {
+			return
+		}
+	}
+}
+
+// Size returns the size of the box when the snapshot was taken.
+func (s Snapshot[T]) Size() int {
+	return s.size
+}
+
+// MaxSize returns the maximum size of the box when the snapshot was taken.
+func (s Snapshot[T]) MaxSize() int {
+	return s.maxSize
+}
+
+// Snapshot returns an immutable view of the items along with the size and
+// maximum size of the box, read under one lock so that they are consistent
+// with each other, unlike separate calls to Items, Size and MaxSize.
+//
+// Snapshots are copy-on-write: the items are copied once and the copy is
+// shared by every snapshot until the box changes, so repeated snapshots of an
+// unchanged box are free and a report iterating a large snapshot never holds
+// the lock. Boxes that purge lazily on reads are copied on every call.
+func (c *concurrentBox[T]) Snapshot() Snapshot[T] {
+	if snap := (*Snapshot[T])(atomic.LoadPointer(&c.snap)); snap != nil {
+		return *snap
+	}
+	c.rlock()
+	defer c.runlock()
+	snap := &Snapshot[T]{
+		items:   c.box.Items(),
+		size:    c.box.Size(),
+		maxSize: c.box.MaxSize(),
+	}
+	if c.cached {
+		atomic.StorePointer(&c.snap, unsafe.Pointer(snap))
+	}
+	return *snap
+}
+
+// mutatesOnRead reports whether the wrapped box purges lazily on reads. Peek
+// is safe either way, as it runs with the lock held.
+func (c *concurrentBox[T]) mutatesOnRead() bool {
+	return mutatesOnRead(c.box)
+}
+
 // Compile-time assertion that concurrentBox implements BlackBox[T].
 var _ BlackBox[any] = (*concurrentBox[any])(nil)
@@ -1,7 +1,10 @@
 package blackbox
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"math/rand"
 	"sync"
 	"sync/atomic"
@@ -250,6 +253,204 @@ func TestConcurrentWrapper_AccessorsAndClean(t *testing.T) {
 	}
 }
 
+func TestConcurrentWrapper_GetCtxBlocksUntilPut(t *testing.T) {
+	box := NewConcurrent[int](NewFIFO[int](0, 4))
+
+	got := make(chan int)
+	go func() {
+		item, err := box.GetCtx(context.Background())
+		if err != nil {
+			t.Errorf("GetCtx returned unexpected error: %v", err)
+		}
+		got <- item
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	select {
+	case item := <-got:
+		t.Fatalf("GetCtx returned %d before anything was put", item)
+	default:
+	}
+
+	if err := box.Put(42); err != nil {
+		t.Fatalf("Put returned unexpected error: %v", err)
+	}
+	select {
+	case item := <-got:
+		if item != 42 {
+			t.Fatalf("GetCtx returned %d, want 42", item)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("GetCtx did not wake up after Put")
+	}
+}
+
+func TestConcurrentWrapper_GetCtxCancelled(t *testing.T) {
+	box := NewConcurrent[int](NewLIFO[int](0, 4))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := box.GetCtx(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+
+	// A cancelled context wins even when an item is available.
+	box.Put(1)
+	if _, err := box.GetCtx(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if item, err := box.GetCtx(context.Background()); err != nil || item != 1 {
+		t.Fatalf("GetCtx returned %d, %v; want 1, nil", item, err)
+	}
+}
+
+func TestConcurrentWrapper_PutCtxBackpressure(t *testing.T) {
+	box := NewConcurrent[int](NewFIFO[int](1, 1))
+	if err := box.PutCtx(context.Background(), 1); err != nil {
+		t.Fatalf("PutCtx returned unexpected error: %v", err)
+	}
+
+	done := make(chan error)
+	go func() {
+		done <- box.PutCtx(context.Background(), 2)
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	select {
+	case err := <-done:
+		t.Fatalf("PutCtx returned %v while the box was full", err)
+	default:
+	}
+
+	if item, _ := box.Get(); item != 1 {
+		t.Fatalf("Get returned %d, want 1", item)
+	}
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("PutCtx returned unexpected error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("PutCtx did not wake up after Get")
+	}
+	if item, _ := box.Peek(); item != 2 {
+		t.Fatalf("Peek returned %d, want 2", item)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := box.PutCtx(ctx, 3); err != context.Canceled {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := box.PutCtx(ctx, 3); err != context.DeadlineExceeded {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+
+	// Clean also frees space for blocked producers.
+	go func() {
+		done <- box.PutCtx(context.Background(), 4)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	box.Clean()
+	if err := <-done; err != nil {
+		t.Fatalf("PutCtx returned unexpected error: %v", err)
+	}
+}
+
+func TestConcurrentWrapper_WaitNotEmptyAndNotFull(t *testing.T) {
+	box := NewConcurrent[int](NewFIFO[int](1, 1))
+
+	notEmpty := make(chan error)
+	go func() {
+		notEmpty <- box.WaitNotEmpty(context.Background())
+	}()
+	time.Sleep(10 * time.Millisecond)
+	select {
+	case err := <-notEmpty:
+		t.Fatalf("WaitNotEmpty returned %v while the box was empty", err)
+	default:
+	}
+	box.Put(1)
+	select {
+	case err := <-notEmpty:
+		if err != nil {
+			t.Fatalf("WaitNotEmpty returned unexpected error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("WaitNotEmpty did not wake up after Put")
+	}
+	if box.Size() != 1 {
+		t.Fatalf("WaitNotEmpty must not take the item, got size %d", box.Size())
+	}
+
+	notFull := make(chan error)
+	go func() {
+		notFull <- box.WaitNotFull(context.Background())
+	}()
+	time.Sleep(10 * time.Millisecond)
+	select {
+	case err := <-notFull:
+		t.Fatalf("WaitNotFull returned %v while the box was full", err)
+	default:
+	}
+	box.Get()
+	select {
+	case err := <-notFull:
+		if err != nil {
+			t.Fatalf("WaitNotFull returned unexpected error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("WaitNotFull did not wake up after Get")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := box.WaitNotEmpty(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestConcurrentWrapper_Snapshot(t *testing.T) {
+	box := NewConcurrent[int](NewFIFO[int](4, 4))
+	box.Put(1)
+	box.Put(2)
+
+	snap := box.Snapshot()
+	if !EqualInts(snap.Items(), []int{1, 2}) || snap.Len() != 2 || snap.Size() != 2 || snap.MaxSize() != 4 {
+		t.Errorf("Expected snapshot of [1 2] with size 2 and max size 4, got %v", snap.Items())
+	}
+	if snap.At(1) != 2 {
+		t.Errorf("Expected At(1) to return 2, got %d", snap.At(1))
+	}
+
+	items := snap.Items()
+	items[0] = 9
+	if snap.At(0) != 1 {
+		t.Errorf("Expected the snapshot to be immutable, got %d", snap.At(0))
+	}
+
+	// Unchanged boxes share the same copy.
+	if again := box.Snapshot(); &again.items[0] != &snap.items[0] {
+		t.Error("Expected an unchanged box to share its snapshot")
+	}
+
+	// Iterating does not hold the lock, so producers are not blocked.
+	var seen []int
+	snap.Range(func(i int, item int) bool {
+		box.Put(item + 10)
+		seen = append(seen, item)
+		return true
+	})
+	if !EqualInts(seen, []int{1, 2}) || box.Size() != 4 {
+		t.Errorf("Expected to iterate [1 2] while putting, got %v and size %d", seen, box.Size())
+	}
+	if next := box.Snapshot(); !EqualInts(next.Items(), []int{1, 2, 11, 12}) || snap.Len() != 2 {
+		t.Errorf("Expected a new snapshot after the change, got %v", next.Items())
+	}
+}
+
 func benchmarkConcurrentPut(b *testing.B, box BlackBox[int]) {
 	cb := NewConcurrent(box)
 	b.ResetTimer()
@@ -356,3 +557,145 @@ func BenchmarkConcurrentConcreteRandom_Get(b *testing.B) {
 	box := NewRandom[int](0, b.N, rng)
 	benchmarkConcurrentGet(b, box)
 }
+
+func TestConcurrentRW(t *testing.T) {
+	box := NewConcurrentRW[int](NewFIFO[int](0, 16))
+
+	// Readers holding the read lock must not block each other.
+	box.mu.RLock()
+	done := make(chan struct{})
+	go func() {
+		box.Size()
+		box.IsEmpty()
+		box.Items()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected readers to share the read lock")
+	}
+	box.mu.RUnlock()
+
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(2)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				box.Put(g*100 + i)
+			}
+		}(g)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				box.Size()
+				box.Peek()
+			}
+		}()
+	}
+	wg.Wait()
+	if box.Size() != 400 {
+		t.Errorf("Expected 400 items, got %d", box.Size())
+	}
+	if clone, _ := box.Clone(); !clone.(*concurrentBox[int]).readShared {
+		t.Error("Expected the clone to keep the read-shared mode")
+	}
+}
+
+func TestConcurrentRWRandomPeek(t *testing.T) {
+	// Random Peek draws from the RNG, so it must take the exclusive lock.
+	box := NewConcurrentRW[int](New[int](WithSeed(1)))
+	if box.readShared {
+		t.Error("Expected a random box not to share read locks")
+	}
+	if !box.cached {
+		t.Error("Expected a random box to keep the cached size")
+	}
+	if audited := NewConcurrentRW[int](NewAudit(New[int](), io.Discard)); audited.readShared || !audited.cached {
+		t.Error("Expected a wrapped random box to use the exclusive lock and the cached size")
+	}
+	for i := 0; i < 100; i++ {
+		box.Put(i)
+	}
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				box.Peek()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestConcurrentAtomicSize(t *testing.T) {
+	box := NewConcurrent[int](NewFIFO[int](2, 2))
+	box.Put(1)
+
+	// Size, IsEmpty and IsFull must not need the lock.
+	box.mu.Lock()
+	if box.Size() != 1 || box.IsEmpty() || box.IsFull() {
+		t.Errorf("Expected size 1, got %d", box.Size())
+	}
+	box.mu.Unlock()
+
+	box.Put(2)
+	if !box.IsFull() {
+		t.Error("Expected a full box")
+	}
+	box.Drain()
+	if !box.IsEmpty() || box.Size() != 0 {
+		t.Errorf("Expected an empty box, got size %d", box.Size())
+	}
+
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	timed := New[int](WithTTL(time.Second)).(*timedBox[int])
+	timed.clock = clock
+	lazy := NewConcurrentRW[int](timed)
+	if lazy.cached || lazy.readShared {
+		t.Fatal("Expected a box purging on reads to use the exclusive lock")
+	}
+	lazy.Put(1)
+	clock.Advance(time.Second)
+	if !lazy.IsEmpty() {
+		t.Error("Expected the expired item to be purged on read")
+	}
+}
+
+// wrapsEmpty is a box whose Get wraps ErrEmptyBlackBox, like a box of
+// another package adding context to its errors.
+type wrapsEmpty struct {
+	BlackBox[int]
+}
+
+func (w wrapsEmpty) Get() (int, error) {
+	item, err := w.BlackBox.Get()
+	if err != nil {
+		err = fmt.Errorf("queue: %w", err)
+	}
+	return item, err
+}
+
+func TestWrappedEmptyError(t *testing.T) {
+	box := NewConcurrent[int](wrapsEmpty{NewFIFO[int](0, 0)})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := box.GetCtx(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected GetCtx to wait for an item, got %v", err)
+	}
+
+	first := true
+	sharded := NewSharded[int](2, func() BlackBox[int] {
+		if first {
+			first = false
+			return wrapsEmpty{NewFIFO[int](0, 0)}
+		}
+		return NewFIFOFrom[int]([]int{7}, 0)
+	})
+	if item, err := sharded.Get(); err != nil || item != 7 {
+		t.Errorf("Expected Get to skip the empty shard, got %d (%v)", item, err)
+	}
+}
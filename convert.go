@@ -0,0 +1,60 @@
+package blackbox
+
+import "fmt"
+
+// ItemError is the error of one item, see ConvertError.
+type ItemError struct {
+	// Index is the position of the item in Items of the source box.
+	Index int
+	Err   error
+}
+
+// ConvertError is returned by ConvertBox when some items failed to convert.
+type ConvertError struct {
+	Failed []ItemError
+	// Total is the number of items in the source box.
+	Total int
+}
+
+func (e *ConvertError) Error() string {
+	first := e.Failed[0]
+	return fmt.Sprintf("blackbox failed to convert %d of %d items, item %d: %v", len(e.Failed), e.Total, first.Index, first.Err)
+}
+
+// Unwrap returns the error of the first item that failed.
+func (e *ConvertError) Unwrap() error {
+	return e.Failed[0].Err
+}
+
+// ConvertBox returns a new box holding conv of every item of box, e.g. to
+// migrate a queue after a schema change. Items keep their order, so the new
+// box returns them in the same order when it uses the same strategy. opts
+// configure the new box as in NewFromBlackBox, whose MaxSize defaults to
+// box.MaxSize(). Items that fail to convert are left out and reported by a
+// *ConvertError; the others are still converted. box is left untouched.
+func ConvertBox[T, U any](box BlackBox[T], conv func(item T) (U, error), opts ...Option) (BlackBox[U], error) {
+	items := box.Items()
+	converted := make([]U, 0, len(items))
+	var failed []ItemError
+	for i, item := range items {
+		u, err := conv(item)
+		if err != nil {
+			failed = append(failed, ItemError{Index: i, Err: err})
+			continue
+		}
+		converted = append(converted, u)
+	}
+
+	cfg := parseOptions(opts)
+	if !cfg.useMaxSize {
+		cfg.maxSize = box.MaxSize()
+	}
+	if cfg.maxSize > 0 && cfg.maxSize < len(converted) {
+		cfg.maxSize = len(converted)
+	}
+	out := assemble(cfg, converted, true)
+	if failed != nil {
+		return out, &ConvertError{Failed: failed, Total: len(items)}
+	}
+	return out, nil
+}
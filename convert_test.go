@@ -0,0 +1,43 @@
+package blackbox
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestConvertBox(t *testing.T) {
+	src := NewFrom([]string{"1", "2", "x", "4", "y"}, WithStrategy(StrategyLIFO), WithMaxSize(8))
+	box, err := ConvertBox(src, strconv.Atoi, WithStrategy(StrategyLIFO))
+
+	var convErr *ConvertError
+	if !errors.As(err, &convErr) {
+		t.Fatalf("Expected a ConvertError, got %v", err)
+	}
+	if convErr.Total != 5 || len(convErr.Failed) != 2 || convErr.Failed[0].Index != 2 || convErr.Failed[1].Index != 4 {
+		t.Errorf("Expected items 2 and 4 to fail, got %+v", convErr)
+	}
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("Expected the error to match strconv.ErrSyntax, got %v", err)
+	}
+	if items := box.Items(); !EqualInts(items, []int{1, 2, 4}) {
+		t.Errorf("Expected [1 2 4], got %v", items)
+	}
+	if item, _ := box.Get(); item != 4 {
+		t.Errorf("Expected the LIFO order to be kept, got %d", item)
+	}
+	if box.MaxSize() != 8 {
+		t.Errorf("Expected MaxSize 8, got %d", box.MaxSize())
+	}
+	if src.Size() != 5 {
+		t.Errorf("Expected the source box to be untouched, got %d", src.Size())
+	}
+
+	ok, err := ConvertBox(NewFrom([]int{1, 2, 3}), func(i int) (string, error) { return strconv.Itoa(i), nil }, WithMaxSize(2))
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if ok.Size() != 3 || ok.MaxSize() != 3 {
+		t.Errorf("Expected 3 items and MaxSize 3, got %d and %d", ok.Size(), ok.MaxSize())
+	}
+}
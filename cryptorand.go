@@ -0,0 +1,24 @@
+package blackbox
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+)
+
+// cryptoSource is a math/rand source backed by crypto/rand.
+// It is stateless and safe for concurrent use; Seed is a no-op.
+type cryptoSource struct{}
+
+func (cryptoSource) Seed(int64) {}
+
+func (s cryptoSource) Int63() int64 {
+	return int64(s.Uint64() &^ (1 << 63))
+}
+
+func (cryptoSource) Uint64() uint64 {
+	var b [8]byte
+	if _, err := crand.Read(b[:]); err != nil {
+		panic("blackbox: crypto/rand failed: " + err.Error())
+	}
+	return binary.LittleEndian.Uint64(b[:])
+}
@@ -0,0 +1,201 @@
+package blackbox
+
+import "time"
+
+// DebounceConfig configures the coalescing decorator returned by NewDebounce.
+type DebounceConfig[T any, K comparable] struct {
+	// Key identifies items that belong to the same burst.
+	Key func(T) K
+	// Quiet is how long a key must go without a Put before its item is enqueued.
+	Quiet time.Duration
+	// Merge combines the pending item with a newly put one. When nil, the
+	// newest item replaces the pending one.
+	Merge func(pending, next T) T
+	// Clock is used to measure the quiet period. When nil, the wall clock is used.
+	Clock Clock
+}
+
+type pendingItem[T any, K comparable] struct {
+	key     K
+	item    T
+	lastPut time.Time
+}
+
+// debounceBox absorbs bursts of Puts with the same key before they reach the wrapped box.
+type debounceBox[T any, K comparable] struct {
+	box     BlackBox[T]
+	cfg     DebounceConfig[T, K]
+	pending []pendingItem[T, K]
+	index   map[K]int
+	// waker wakes up a concurrent wrapper once the next burst subsides.
+	waker wakeTimer
+}
+
+// NewDebounce wraps box so that repeated Puts of the same key within
+// cfg.Quiet are coalesced into a single item, enqueued once the key has
+// been quiet for cfg.Quiet.
+//
+// Pending items are flushed lazily: every call on the returned box first
+// moves the items whose burst has subsided into box, in the order their
+// bursts started. If box is full, the item stays pending and is retried on
+// the next call. Size, IsEmpty and Items only reflect flushed items; use
+// Pending to see how many bursts are still being absorbed. When wrapped with
+// NewConcurrent, a subsiding burst also wakes up callers blocked in GetCtx or
+// WaitNotEmpty, and Process waits for the pending items.
+func NewDebounce[T any, K comparable](box BlackBox[T], cfg DebounceConfig[T, K]) *debounceBox[T, K] {
+	if cfg.Clock == nil {
+		cfg.Clock = systemClock{}
+	}
+	return &debounceBox[T, K]{
+		box:   box,
+		cfg:   cfg,
+		index: make(map[K]int),
+	}
+}
+
+// flush moves items into the wrapped box. When force is false only items
+// whose quiet period has elapsed are moved. It returns the first Put error.
+func (d *debounceBox[T, K]) flush(force bool) error {
+	if len(d.pending) == 0 {
+		return nil
+	}
+	now := d.cfg.Clock.Now()
+	var firstErr error
+	kept := d.pending[:0]
+	for _, p := range d.pending {
+		if force || now.Sub(p.lastPut) >= d.cfg.Quiet {
+			err := d.box.Put(p.item)
+			if err == nil {
+				continue
+			}
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+		kept = append(kept, p)
+	}
+	if len(kept) == len(d.pending) {
+		d.arm(now)
+		return firstErr
+	}
+	var zero pendingItem[T, K]
+	for i := len(kept); i < len(d.pending); i++ {
+		d.pending[i] = zero
+	}
+	d.pending = kept
+	for i, p := range d.pending {
+		d.index[p.key] = i
+	}
+	for k, i := range d.index {
+		if i >= len(d.pending) || d.pending[i].key != k {
+			delete(d.index, k)
+		}
+	}
+	d.arm(now)
+	return firstErr
+}
+
+// arm sets the waker for the pending item whose quiet period ends first.
+func (d *debounceBox[T, K]) arm(now time.Time) {
+	var next time.Time
+	for _, p := range d.pending {
+		if due := p.lastPut.Add(d.cfg.Quiet); now.Before(due) && (next.IsZero() || due.Before(next)) {
+			next = due
+		}
+	}
+	if !next.IsZero() {
+		d.waker.arm(next, now)
+	}
+}
+
+func (d *debounceBox[T, K]) setWake(wake func()) {
+	d.waker.set(wake)
+	d.arm(d.cfg.Clock.Now())
+}
+
+func (d *debounceBox[T, K]) held() int {
+	return len(d.pending)
+}
+
+// Flush immediately enqueues every pending item, ignoring the quiet period.
+// It returns the first error from the wrapped box; failed items stay pending.
+func (d *debounceBox[T, K]) Flush() error {
+	return d.flush(true)
+}
+
+// Pending returns the number of items still waiting for their burst to subside.
+func (d *debounceBox[T, K]) Pending() int {
+	d.flush(false)
+	return len(d.pending)
+}
+
+// Put records item as the latest of its burst. It never fails; capacity of
+// the wrapped box is only checked when the item is flushed.
+func (d *debounceBox[T, K]) Put(item T) error {
+	d.flush(false)
+	key := d.cfg.Key(item)
+	now := d.cfg.Clock.Now()
+	if i, ok := d.index[key]; ok {
+		p := &d.pending[i]
+		if d.cfg.Merge != nil {
+			p.item = d.cfg.Merge(p.item, item)
+		} else {
+			p.item = item
+		}
+		p.lastPut = now
+	} else {
+		d.index[key] = len(d.pending)
+		d.pending = append(d.pending, pendingItem[T, K]{key: key, item: item, lastPut: now})
+	}
+	d.waker.arm(now.Add(d.cfg.Quiet), now)
+	return nil
+}
+
+func (d *debounceBox[T, K]) Get() (T, error) {
+	d.flush(false)
+	return d.box.Get()
+}
+
+func (d *debounceBox[T, K]) Peek() (T, error) {
+	d.flush(false)
+	return d.box.Peek()
+}
+
+func (d *debounceBox[T, K]) Size() int {
+	d.flush(false)
+	return d.box.Size()
+}
+
+func (d *debounceBox[T, K]) MaxSize() int {
+	return d.box.MaxSize()
+}
+
+func (d *debounceBox[T, K]) IsFull() bool {
+	d.flush(false)
+	return d.box.IsFull()
+}
+
+func (d *debounceBox[T, K]) IsEmpty() bool {
+	d.flush(false)
+	return d.box.IsEmpty()
+}
+
+// Clean removes all items, including pending ones.
+func (d *debounceBox[T, K]) Clean() {
+	d.pending = d.pending[:0]
+	d.index = make(map[K]int)
+	d.waker.stop()
+	d.box.Clean()
+}
+
+func (d *debounceBox[T, K]) Items() []T {
+	d.flush(false)
+	return d.box.Items()
+}
+
+func (d *debounceBox[T, K]) mutatesOnRead() bool {
+	return true
+}
+
+// Compile-time assertion that debounceBox implements BlackBox[T].
+var _ BlackBox[any] = (*debounceBox[any, string])(nil)
@@ -0,0 +1,145 @@
+package blackbox
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a manually advanced Clock for tests.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+type fileEvent struct {
+	path  string
+	count int
+}
+
+func TestDebounceCoalescesBursts(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	box := NewDebounce[fileEvent, string](NewFIFO[fileEvent](0, 4), DebounceConfig[fileEvent, string]{
+		Key:   func(e fileEvent) string { return e.path },
+		Quiet: time.Second,
+		Merge: func(pending, next fileEvent) fileEvent {
+			pending.count += next.count
+			return pending
+		},
+		Clock: clock,
+	})
+
+	for i := 0; i < 5; i++ {
+		box.Put(fileEvent{path: "a.txt", count: 1})
+		clock.Advance(500 * time.Millisecond)
+	}
+	box.Put(fileEvent{path: "b.txt", count: 1})
+
+	if box.Size() != 0 || !box.IsEmpty() {
+		t.Fatalf("Expected nothing to be enqueued during the burst, got size %d", box.Size())
+	}
+	if box.Pending() != 2 {
+		t.Fatalf("Expected 2 pending bursts, got %d", box.Pending())
+	}
+	if _, err := box.Get(); err != ErrEmptyBlackBox {
+		t.Errorf("Expected ErrEmptyBlackBox, got %v", err)
+	}
+
+	clock.Advance(500 * time.Millisecond)
+	if box.Size() != 1 {
+		t.Fatalf("Expected the quiet key to be enqueued, got size %d", box.Size())
+	}
+	event, err := box.Get()
+	if err != nil {
+		t.Fatalf("Failed to get item: %v", err)
+	}
+	if event.path != "a.txt" || event.count != 5 {
+		t.Errorf("Expected merged a.txt event with count 5, got %+v", event)
+	}
+
+	clock.Advance(time.Second)
+	if event, _ := box.Get(); event.path != "b.txt" {
+		t.Errorf("Expected b.txt event, got %+v", event)
+	}
+	if box.Pending() != 0 {
+		t.Errorf("Expected no pending bursts, got %d", box.Pending())
+	}
+}
+
+func TestDebounceReplaceFlushAndClean(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	box := NewDebounce[fileEvent, string](NewFIFO[fileEvent](1, 1), DebounceConfig[fileEvent, string]{
+		Key:   func(e fileEvent) string { return e.path },
+		Quiet: time.Minute,
+		Clock: clock,
+	})
+
+	box.Put(fileEvent{path: "a", count: 1})
+	box.Put(fileEvent{path: "a", count: 2})
+	box.Put(fileEvent{path: "b", count: 3})
+
+	// The wrapped box only holds one item, so b stays pending.
+	if err := box.Flush(); err != ErrBlackBoxFull {
+		t.Errorf("Expected ErrBlackBoxFull, got %v", err)
+	}
+	if box.Pending() != 1 {
+		t.Errorf("Expected b to stay pending, got %d", box.Pending())
+	}
+	if event, _ := box.Peek(); event.count != 2 {
+		t.Errorf("Expected newest a event to replace the pending one, got %+v", event)
+	}
+	if !box.IsFull() || box.MaxSize() != 1 {
+		t.Errorf("Expected wrapped box to be full")
+	}
+	if items := box.Items(); len(items) != 1 {
+		t.Errorf("Expected 1 flushed item, got %d", len(items))
+	}
+
+	box.Put(fileEvent{path: "b", count: 4})
+	box.Clean()
+	if box.Pending() != 0 || box.Size() != 0 {
+		t.Errorf("Expected Clean() to drop pending and flushed items")
+	}
+}
+
+func TestDebounceWakesBlockedGet(t *testing.T) {
+	debounce := NewDebounce[int, int](NewFIFO[int](0, 4), DebounceConfig[int, int]{
+		Key:   func(i int) int { return i },
+		Quiet: 10 * time.Millisecond,
+	})
+	box := NewConcurrent[int](debounce)
+	box.Put(1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	start := time.Now()
+	item, err := box.GetCtx(ctx)
+	if err != nil || item != 1 {
+		t.Fatalf("Expected the debounced item, got %d (%v)", item, err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected GetCtx to wake up when the quiet period ended, took %v", elapsed)
+	}
+
+	// Process waits for the pending items too.
+	box.Put(2)
+	var mu sync.Mutex
+	var processed []int
+	err = box.Process(ctx, 2, func(ctx context.Context, item int) error {
+		mu.Lock()
+		defer mu.Unlock()
+		processed = append(processed, item)
+		return nil
+	})
+	if err != nil || !EqualInts(processed, []int{2}) {
+		t.Errorf("Expected the pending item to be processed, got %v (%v)", processed, err)
+	}
+}
@@ -0,0 +1,165 @@
+package blackbox
+
+import "errors"
+
+// ErrDuplicate is returned by Put on a deduplicating blackbox when an item
+// with the same key is already in the box.
+var ErrDuplicate = errors.New("blackbox already holds the item")
+
+// dedupBox gives set semantics to any BlackBox[T]: at most one item per key.
+type dedupBox[T any, K comparable] struct {
+	box  BlackBox[T]
+	key  func(T) K
+	keys map[K]int
+	// size is the size of box after the last call; when it differs, items
+	// left the box on their own, e.g. evicted or expired, and keys is rebuilt.
+	size int
+}
+
+// NewDedup wraps box so that Put returns ErrDuplicate for an item whose key,
+// as returned by keyFn, is already in the box, e.g. to make registering the
+// same raffle participant twice impossible. The key is released when the item
+// leaves the box. Duplicates already in box are kept.
+// Returns a concrete instance of deduplicating blackbox.
+func NewDedup[T any](box BlackBox[T], keyFn func(T) string) *dedupBox[T, string] {
+	return newDedup(box, keyFn)
+}
+
+// NewUnique wraps box like NewDedup with the items themselves as keys, for
+// comparable items: PutUnique reports whether the item was added, and
+// membership is checked with an index instead of scanning the items.
+// Returns a concrete instance of deduplicating blackbox.
+func NewUnique[T comparable](box BlackBox[T]) *dedupBox[T, T] {
+	return newDedup(box, func(item T) T { return item })
+}
+
+func newDedup[T any, K comparable](box BlackBox[T], keyFn func(T) K) *dedupBox[T, K] {
+	d := &dedupBox[T, K]{box: box, key: keyFn}
+	d.sync()
+	return d
+}
+
+// WithDeduplication rejects items whose key, as returned by keyFn, is
+// already in the box with ErrDuplicate, see NewDedup.
+func WithDeduplication[T any](keyFn func(T) string) Option {
+	return func(c *config) {
+		c.decorators = append(c.decorators, func(box BlackBox[T]) BlackBox[T] {
+			return NewDedup(box, keyFn)
+		})
+	}
+}
+
+// sync rebuilds the keys from the items of the box.
+func (d *dedupBox[T, K]) sync() {
+	d.keys = make(map[K]int)
+	eachItem(d.box, func(item T) bool {
+		d.keys[d.key(item)]++
+		return true
+	})
+	d.size = d.box.Size()
+}
+
+// check rebuilds the keys if items left the box without going through Get.
+func (d *dedupBox[T, K]) check(size int) {
+	if size != d.size {
+		d.sync()
+	}
+}
+
+// Contains reports whether an item with the given key is in the box.
+func (d *dedupBox[T, K]) Contains(key K) bool {
+	d.check(d.box.Size())
+	return d.keys[key] > 0
+}
+
+// PutUnique inserts item unless an item with the same key is in the box.
+// added is false, with a nil error, for a duplicate.
+func (d *dedupBox[T, K]) PutUnique(item T) (added bool, err error) {
+	if err := d.Put(item); err != nil {
+		if err == ErrDuplicate {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (d *dedupBox[T, K]) Put(item T) error {
+	d.check(d.box.Size())
+	k := d.key(item)
+	if d.keys[k] > 0 {
+		return ErrDuplicate
+	}
+	if err := d.box.Put(item); err != nil {
+		return err
+	}
+	d.keys[k]++
+	d.size++
+	d.check(d.box.Size())
+	return nil
+}
+
+func (d *dedupBox[T, K]) Get() (T, error) {
+	d.check(d.box.Size())
+	item, err := d.box.Get()
+	if err != nil {
+		return item, err
+	}
+	k := d.key(item)
+	if d.keys[k] <= 1 {
+		delete(d.keys, k)
+	} else {
+		d.keys[k]--
+	}
+	d.size--
+	return item, nil
+}
+
+func (d *dedupBox[T, K]) Peek() (T, error) {
+	return d.box.Peek()
+}
+
+func (d *dedupBox[T, K]) Size() int {
+	return d.box.Size()
+}
+
+func (d *dedupBox[T, K]) MaxSize() int {
+	return d.box.MaxSize()
+}
+
+func (d *dedupBox[T, K]) IsFull() bool {
+	return d.box.IsFull()
+}
+
+func (d *dedupBox[T, K]) IsEmpty() bool {
+	return d.box.IsEmpty()
+}
+
+func (d *dedupBox[T, K]) Clean() {
+	d.box.Clean()
+	d.keys = make(map[K]int)
+	d.size = 0
+}
+
+func (d *dedupBox[T, K]) Items() []T {
+	return d.box.Items()
+}
+
+func (d *dedupBox[T, K]) forEach(fn func(item T) bool) {
+	eachItem(d.box, fn)
+}
+
+func (d *dedupBox[T, K]) mutatesOnRead() bool {
+	return mutatesOnRead(d.box)
+}
+
+func (d *dedupBox[T, K]) mutatesOnPeek() bool {
+	return mutatesOnPeek(d.box)
+}
+
+func (d *dedupBox[T, K]) drawPlan() drawPlan[T] {
+	return planOf(d.box)
+}
+
+// Compile-time assertion that dedupBox implements BlackBox[T].
+var _ BlackBox[any] = (*dedupBox[any, string])(nil)
@@ -0,0 +1,69 @@
+package blackbox
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestDeduplication(t *testing.T) {
+	box := New[string](WithDeduplication(func(name string) string { return name }))
+	if err := box.Put("alice"); err != nil {
+		t.Fatal(err)
+	}
+	if err := box.Put("alice"); err != ErrDuplicate {
+		t.Errorf("Expected ErrDuplicate, got %v", err)
+	}
+	box.Put("bob")
+	if box.Size() != 2 {
+		t.Errorf("Expected 2 participants, got %d", box.Size())
+	}
+
+	winner, _ := box.Get()
+	if err := box.Put(winner); err != nil {
+		t.Errorf("Expected %s to be accepted again after leaving the box, got %v", winner, err)
+	}
+	box.Clean()
+	if err := box.Put("alice"); err != nil {
+		t.Errorf("Expected Clean to release the keys, got %v", err)
+	}
+}
+
+func TestDedupEviction(t *testing.T) {
+	key := func(i int) string { return strconv.Itoa(i % 10) }
+	box := NewDedup[int](New[int](WithStrategy(StrategyRing), WithMaxSize(2)), key)
+	box.Put(1)
+	box.Put(2)
+	box.Put(3) // evicts 1
+	if box.Contains("1") {
+		t.Error("Expected the evicted item to release its key")
+	}
+	if err := box.Put(11); err != nil {
+		t.Errorf("Expected 11 to be accepted after 1 was evicted, got %v", err)
+	}
+	if err := box.Put(13); err != ErrDuplicate {
+		t.Errorf("Expected ErrDuplicate for 13, got %v", err)
+	}
+
+	dups := NewDedup[int](NewFIFOFrom([]int{1, 1}, 0), key)
+	dups.Get()
+	if err := dups.Put(1); err != ErrDuplicate {
+		t.Errorf("Expected the remaining 1 to keep its key, got %v", err)
+	}
+}
+
+func TestPutUnique(t *testing.T) {
+	box := NewUnique[int](NewFIFO[int](2, 0))
+	if added, err := box.PutUnique(1); !added || err != nil {
+		t.Errorf("Expected 1 to be added, got %v (%v)", added, err)
+	}
+	if added, err := box.PutUnique(1); added || err != nil {
+		t.Errorf("Expected duplicate 1 not to be added without error, got %v (%v)", added, err)
+	}
+	box.PutUnique(2)
+	if added, err := box.PutUnique(3); added || err == nil {
+		t.Errorf("Expected the full box error, got %v (%v)", added, err)
+	}
+	if !box.Contains(2) || box.Contains(3) {
+		t.Error("Expected the index to hold 1 and 2 only")
+	}
+}
@@ -0,0 +1,99 @@
+package blackbox
+
+// dequeBox is a double-ended queue built on the same ring buffer as fifoBox.
+// As a BlackBox[T] it behaves like a FIFO: Put appends to the back while Get
+// and Peek read from the front.
+type dequeBox[T any] struct {
+	ring[T]
+}
+
+// NewDeque creates a new double-ended blackbox with the specified maximum size and capacity.
+// Returns a concrete instance of deque blackbox without interface.
+func NewDeque[T any](maxSize, capacity int) *dequeBox[T] {
+	return &dequeBox[T]{
+		ring: newRing[T](maxSize, capacity),
+	}
+}
+
+// NewDequeFrom creates a new double-ended blackbox from a slice of items and the specified maximum size.
+// items are copied so it safe to use the original slice after the blackbox is created.
+// The first item of the slice is the front of the deque.
+func NewDequeFrom[T any](items []T, maxSize int) *dequeBox[T] {
+	newItems := make([]T, len(items))
+	copy(newItems, items)
+	return &dequeBox[T]{
+		ring: newRingFrom(newItems, maxSize),
+	}
+}
+
+// PutFront inserts an item at the front of the deque.
+func (b *dequeBox[T]) PutFront(item T) error {
+	if b.IsFull() {
+		return ErrBlackBoxFull
+	}
+	b.pushFront(item)
+	return nil
+}
+
+// PutBack inserts an item at the back of the deque.
+func (b *dequeBox[T]) PutBack(item T) error {
+	if b.IsFull() {
+		return ErrBlackBoxFull
+	}
+	b.pushBack(item)
+	return nil
+}
+
+// GetFront removes and returns the item at the front of the deque.
+func (b *dequeBox[T]) GetFront() (T, error) {
+	if b.size == 0 {
+		var zero T
+		return zero, ErrEmptyBlackBox
+	}
+	return b.popFront(), nil
+}
+
+// GetBack removes and returns the item at the back of the deque.
+func (b *dequeBox[T]) GetBack() (T, error) {
+	if b.size == 0 {
+		var zero T
+		return zero, ErrEmptyBlackBox
+	}
+	return b.popBack(), nil
+}
+
+// PeekFront returns the item at the front of the deque without removing it.
+func (b *dequeBox[T]) PeekFront() (T, error) {
+	if b.size == 0 {
+		var zero T
+		return zero, ErrEmptyBlackBox
+	}
+	return b.front(), nil
+}
+
+// PeekBack returns the item at the back of the deque without removing it.
+func (b *dequeBox[T]) PeekBack() (T, error) {
+	if b.size == 0 {
+		var zero T
+		return zero, ErrEmptyBlackBox
+	}
+	return b.back(), nil
+}
+
+// Put is an alias of PutBack.
+func (b *dequeBox[T]) Put(item T) error {
+	return b.PutBack(item)
+}
+
+// Get is an alias of GetFront.
+func (b *dequeBox[T]) Get() (T, error) {
+	return b.GetFront()
+}
+
+// Peek is an alias of PeekFront.
+func (b *dequeBox[T]) Peek() (T, error) {
+	return b.PeekFront()
+}
+
+// Compile-time assertion that dequeBox implements BlackBox[T].
+var _ BlackBox[any] = (*dequeBox[any])(nil)
@@ -0,0 +1,79 @@
+package blackbox
+
+import "testing"
+
+func TestDequeBothEnds(t *testing.T) {
+	box := NewDeque[int](0, 2)
+
+	box.PutBack(2)
+	box.PutFront(1)
+	box.PutBack(3)
+	box.PutFront(0) // forces growth while head has wrapped
+
+	if got := box.Items(); !EqualInts(got, []int{0, 1, 2, 3}) {
+		t.Fatalf("Expected items [0 1 2 3], got %v", got)
+	}
+
+	if item, _ := box.PeekFront(); item != 0 {
+		t.Errorf("Expected front 0, got %d", item)
+	}
+	if item, _ := box.PeekBack(); item != 3 {
+		t.Errorf("Expected back 3, got %d", item)
+	}
+
+	want := []struct {
+		back bool
+		item int
+	}{{true, 3}, {false, 0}, {true, 2}, {false, 1}}
+	for _, w := range want {
+		var item int
+		var err error
+		if w.back {
+			item, err = box.GetBack()
+		} else {
+			item, err = box.GetFront()
+		}
+		if err != nil {
+			t.Fatalf("Failed to get item: %v", err)
+		}
+		if item != w.item {
+			t.Errorf("Expected %d, got %d", w.item, item)
+		}
+	}
+
+	if !box.IsEmpty() {
+		t.Error("Deque should be empty")
+	}
+	for _, fn := range []func() (int, error){box.GetFront, box.GetBack, box.PeekFront, box.PeekBack} {
+		if _, err := fn(); err != ErrEmptyBlackBox {
+			t.Errorf("Expected ErrEmptyBlackBox, got %v", err)
+		}
+	}
+}
+
+func TestDequeAsBlackBox(t *testing.T) {
+	var box BlackBox[int] = NewDequeFrom[int]([]int{1, 2}, 3)
+	if err := box.Put(3); err != nil {
+		t.Fatalf("Failed to put item: %v", err)
+	}
+	if err := box.Put(4); err != ErrBlackBoxFull {
+		t.Errorf("Expected ErrBlackBoxFull, got %v", err)
+	}
+	if item, _ := box.Peek(); item != 1 {
+		t.Errorf("Expected peek 1, got %d", item)
+	}
+	for i := 1; i <= 3; i++ {
+		if item, _ := box.Get(); item != i {
+			t.Errorf("Expected %d, got %d", i, item)
+		}
+	}
+
+	deque := NewDequeFrom[int]([]int{1, 2}, 2)
+	if err := deque.PutFront(0); err != ErrBlackBoxFull {
+		t.Errorf("Expected ErrBlackBoxFull, got %v", err)
+	}
+	deque.Clean()
+	if deque.Size() != 0 || deque.MaxSize() != 2 {
+		t.Errorf("Expected empty deque with max size 2 after Clean()")
+	}
+}
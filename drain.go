@@ -0,0 +1,118 @@
+package blackbox
+
+import (
+	"context"
+	"time"
+)
+
+// Drainer is implemented by blackboxes that can remove all of their items at once.
+type Drainer[T any] interface {
+	// Drain removes and returns all items in retrieval order.
+	Drain() []T
+}
+
+// Drain removes and returns all items of box in the order Get would return
+// them. It uses the Drain method of the box when available and calls Get until
+// the box is empty otherwise. Drained items are not passed to the evict callback.
+func Drain[T any](box BlackBox[T]) []T {
+	if d, ok := box.(Drainer[T]); ok {
+		return d.Drain()
+	}
+	items := make([]T, 0, box.Size())
+	for !box.IsEmpty() {
+		item, err := box.Get()
+		if err != nil {
+			break
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// Drain removes and returns all items in FIFO order.
+func (b *fifoBox[T]) Drain() []T {
+	items := b.ring.Items()
+	b.ring.Clean()
+	return items
+}
+
+// Drain removes and returns all items from front to back.
+func (b *dequeBox[T]) Drain() []T {
+	items := b.ring.Items()
+	b.ring.Clean()
+	return items
+}
+
+// Drain removes and returns all items in LIFO order.
+func (b *lifoBox[T]) Drain() []T {
+	items := make([]T, len(b.items))
+	var zero T
+	for i, item := range b.items {
+		items[len(items)-1-i] = item
+		b.items[i] = zero
+	}
+	b.items = b.items[:0]
+	return items
+}
+
+// Drain removes and returns all items in the order successive Get calls would
+// draw them, honouring weights and age bias.
+func (b *randomBox[T]) Drain() []T {
+	items := make([]T, 0, len(b.items))
+	for len(b.items) > 0 {
+		items = append(items, b.remove(b.pick()))
+	}
+	return items
+}
+
+// Drain removes and returns all items in ascending order.
+func (b *sortedBox[T]) Drain() []T {
+	items := b.Items()
+	b.Clean()
+	return items
+}
+
+// Drain removes and returns all unexpired items in retrieval order.
+func (t *timedBox[T]) Drain() []T {
+	t.purge()
+	items := values(Drain(t.box))
+	t.nextExpiry = time.Time{}
+	return items
+}
+
+// Drain atomically removes and returns all items in retrieval order, so it
+// cannot race with producers like a loop over Get does.
+func (c *concurrentBox[T]) Drain() []T {
+	c.mu.Lock()
+	items := Drain(c.box)
+	if len(items) > 0 {
+		c.broadcast()
+	}
+	c.mu.Unlock()
+	return items
+}
+
+// DrainCtx is like Drain, but blocks while the box is empty until an item is
+// put or ctx is done, in which case ctx.Err() is returned.
+// Only changes made through this wrapper wake up a blocked DrainCtx.
+func (c *concurrentBox[T]) DrainCtx(ctx context.Context) ([]T, error) {
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		c.mu.Lock()
+		if !c.box.IsEmpty() {
+			items := Drain(c.box)
+			c.broadcast()
+			c.mu.Unlock()
+			return items, nil
+		}
+		wait := c.waitCh()
+		c.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+		case <-wait:
+		}
+	}
+}
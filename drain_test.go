@@ -0,0 +1,77 @@
+package blackbox
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestDrain(t *testing.T) {
+	data := []int{1, 2, 3, 4}
+	tests := []struct {
+		name string
+		box  BlackBox[int]
+		want []int
+	}{
+		{"FIFO", NewFIFOFrom[int](data, 0), []int{1, 2, 3, 4}},
+		{"LIFO", NewLIFOFrom[int](data, 0), []int{4, 3, 2, 1}},
+		{"Deque", NewDequeFrom[int](data, 0), []int{1, 2, 3, 4}},
+		{"Sorted", NewSortedFrom[int]([]int{3, 1, 4, 2}, lessInt, 0), []int{1, 2, 3, 4}},
+		{"TimedLIFO", NewFrom[int](data, WithStrategy(StrategyLIFO), WithTTL(time.Hour)), []int{4, 3, 2, 1}},
+		{"CategoryFIFO", New[int](WithStrategy(StrategyFIFO), WithCategoryLimit(func(i int) int { return i }, 1)), nil},
+	}
+	for _, tt := range tests {
+		if tt.want == nil {
+			for _, item := range data {
+				tt.box.Put(item)
+			}
+			tt.want = data
+		}
+		if items := Drain(tt.box); !EqualInts(items, tt.want) {
+			t.Errorf("%s: Expected %v, got %v", tt.name, tt.want, items)
+		}
+		if !tt.box.IsEmpty() {
+			t.Errorf("%s: Box should be empty after Drain", tt.name)
+		}
+		if items := Drain(tt.box); len(items) != 0 {
+			t.Errorf("%s: Expected nothing to drain, got %v", tt.name, items)
+		}
+	}
+}
+
+func TestDrainRandom(t *testing.T) {
+	var evicted int
+	box := NewFrom[int]([]int{1, 2, 3, 4}, WithSeed(1), WithEvictCallback(func(int) { evicted++ }))
+	items := Drain(box)
+	if len(items) != 4 || !ContainsInt(items, 1) || !ContainsInt(items, 4) {
+		t.Errorf("Expected all items, got %v", items)
+	}
+	if evicted != 0 {
+		t.Errorf("Expected drained items not to be evicted, got %d", evicted)
+	}
+}
+
+func TestConcurrentDrainCtx(t *testing.T) {
+	cbox := NewConcurrent[int](NewFIFO[int](0, 4))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := cbox.DrainCtx(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cbox.Put(1)
+	}()
+	items, err := cbox.DrainCtx(context.Background())
+	if err != nil || !EqualInts(items, []int{1}) {
+		t.Errorf("Expected [1], got %v, %v", items, err)
+	}
+
+	cbox.Put(2)
+	cbox.Put(3)
+	if items := cbox.Drain(); !EqualInts(items, []int{2, 3}) {
+		t.Errorf("Expected [2 3], got %v", items)
+	}
+}
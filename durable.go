@@ -0,0 +1,320 @@
+package blackbox
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// walMagic starts every write-ahead log, followed by walVersion.
+const (
+	walMagic   = "BBW"
+	walVersion = 1
+)
+
+// Write-ahead log record types.
+const (
+	walPut   byte = 'P'
+	walGet   byte = 'G'
+	walClean byte = 'C'
+)
+
+// durableBox records every change of the wrapped box in an append-only
+// write-ahead log, so its content can be rebuilt after a crash.
+type durableBox[T any] struct {
+	box   BlackBox[T]
+	codec ItemCodec[T]
+	path  string
+	file  *os.File
+	err   error
+}
+
+// NewDurable wraps box with a write-ahead log at walPath using
+// DefaultItemCodec, see NewDurableCodec.
+func NewDurable[T any](box BlackBox[T], walPath string) (*durableBox[T], error) {
+	return NewDurableCodec(box, walPath, DefaultItemCodec[T]())
+}
+
+// NewDurableCodec wraps box with a write-ahead log at walPath. Every Put, Get
+// and Clean is appended to the log, and an existing log is replayed into box
+// first, so box should be empty. Records reach the operating system before Put
+// returns, so they survive a crash of the process; call Sync to also survive
+// a power loss. A record cut short by a crash is discarded.
+//
+// Only Puts the box accepts are logged. Replaying is exact for the FIFO, LIFO
+// and Sorted strategies, where each logged Get removes the first item with the
+// same encoding in retrieval order. For the Random strategy, it removes an
+// item with the same encoding. Use
+// Compact to keep the log from growing forever.
+//
+// The returned box is not goroutine-safe; wrap it with NewConcurrent if needed.
+func NewDurableCodec[T any](box BlackBox[T], walPath string, codec ItemCodec[T]) (*durableBox[T], error) {
+	d := &durableBox[T]{box: box, codec: codec, path: walPath}
+	if err := d.replay(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// replay applies the existing log to the box and opens it for appending.
+func (d *durableBox[T]) replay() error {
+	f, err := os.OpenFile(d.path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+
+	r := bufio.NewReader(f)
+	header := make([]byte, len(walMagic)+1)
+	n, err := io.ReadFull(r, header)
+	switch {
+	case n == 0 && err == io.EOF:
+		if _, err := f.Write(append([]byte(walMagic), walVersion)); err != nil {
+			f.Close()
+			return err
+		}
+	case err != nil || string(header[:len(walMagic)]) != walMagic || header[len(walMagic)] != walVersion:
+		f.Close()
+		return ErrInvalidBinary
+	default:
+		offset, err := d.apply(r, int64(n))
+		if err != nil {
+			f.Close()
+			return err
+		}
+		// Drop a record cut short by a crash, so new records follow valid ones.
+		if err := f.Truncate(offset); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	if _, err := f.Seek(0, io.SeekEnd); err != nil {
+		f.Close()
+		return err
+	}
+	d.file = f
+	return nil
+}
+
+// apply replays the records read from r and returns the offset following the
+// last complete record.
+func (d *durableBox[T]) apply(r *bufio.Reader, offset int64) (int64, error) {
+	for {
+		op, err := r.ReadByte()
+		if err == io.EOF {
+			return offset, nil
+		}
+		if err != nil {
+			return offset, err
+		}
+		size := int64(1)
+		var data []byte
+		if op == walPut || op == walGet {
+			data, err = readBytes(r)
+			if err != nil {
+				return offset, nil
+			}
+			size += int64(len(uvarintBytes(uint64(len(data))))) + int64(len(data))
+		}
+		switch op {
+		case walPut:
+			item, err := d.codec.Decode(data)
+			if err != nil {
+				return offset, err
+			}
+			d.box.Put(item)
+		case walGet:
+			d.replayGet(data)
+		case walClean:
+			d.box.Clean()
+		default:
+			return offset, ErrInvalidBinary
+		}
+		offset += size
+	}
+}
+
+// replayGet removes the item that a logged Get returned: the first item with
+// the same encoding in retrieval order, so that duplicates are removed in the
+// order Get returned them.
+func (d *durableBox[T]) replayGet(data []byte) {
+	match := func(item T) bool {
+		encoded, err := d.codec.Encode(item)
+		return err == nil && bytes.Equal(encoded, data)
+	}
+	// Boxes that draw on reads, such as the Random strategy, number their
+	// items differently in eachItem and GetAt.
+	if ix, ok := d.box.(Indexer[T]); ok && !mutatesOnPeek(d.box) {
+		idx, i := -1, 0
+		eachItem(d.box, func(item T) bool {
+			if match(item) {
+				idx = i
+				return false
+			}
+			i++
+			return true
+		})
+		if idx >= 0 {
+			ix.GetAt(idx)
+		}
+		return
+	}
+	r, ok := d.box.(Remover[T])
+	if !ok {
+		d.box.Get()
+		return
+	}
+	removed := false
+	r.RemoveFunc(func(item T) bool {
+		if removed {
+			return false
+		}
+		removed = match(item)
+		return removed
+	})
+}
+
+// record appends a record to the log.
+func (d *durableBox[T]) record(op byte, item *T) error {
+	rec := []byte{op}
+	if item != nil {
+		data, err := d.codec.Encode(*item)
+		if err != nil {
+			return err
+		}
+		rec = append(rec, uvarintBytes(uint64(len(data)))...)
+		rec = append(rec, data...)
+	}
+	_, err := d.file.Write(rec)
+	return err
+}
+
+// Put inserts the item into the wrapped box and then logs it, so that
+// rejected items are not replayed. If logging fails, the item stays in the
+// box and the error is returned.
+func (d *durableBox[T]) Put(item T) error {
+	if err := d.box.Put(item); err != nil {
+		return err
+	}
+	return d.record(walPut, &item)
+}
+
+// Get removes an item from the wrapped box and logs it. If logging fails, the
+// removed item is returned along with the error, since it is already gone
+// from the box.
+func (d *durableBox[T]) Get() (T, error) {
+	item, err := d.box.Get()
+	if err != nil {
+		return item, err
+	}
+	return item, d.record(walGet, &item)
+}
+
+func (d *durableBox[T]) Peek() (T, error) {
+	return d.box.Peek()
+}
+
+func (d *durableBox[T]) Size() int {
+	return d.box.Size()
+}
+
+func (d *durableBox[T]) MaxSize() int {
+	return d.box.MaxSize()
+}
+
+func (d *durableBox[T]) IsFull() bool {
+	return d.box.IsFull()
+}
+
+func (d *durableBox[T]) IsEmpty() bool {
+	return d.box.IsEmpty()
+}
+
+// Clean removes all items from the wrapped box and logs it. The log is not
+// shrunk; see Compact. A failure to log is reported by Err.
+func (d *durableBox[T]) Clean() {
+	d.box.Clean()
+	if err := d.record(walClean, nil); err != nil && d.err == nil {
+		d.err = err
+	}
+}
+
+// Err returns the first error logging a Clean, if any.
+func (d *durableBox[T]) Err() error {
+	return d.err
+}
+
+func (d *durableBox[T]) Items() []T {
+	return d.box.Items()
+}
+
+func (d *durableBox[T]) mutatesOnRead() bool {
+	return mutatesOnRead(d.box)
+}
+
+func (d *durableBox[T]) mutatesOnPeek() bool {
+	return mutatesOnPeek(d.box)
+}
+
+func (d *durableBox[T]) drawPlan() drawPlan[T] {
+	return planOf(d.box)
+}
+
+// Sync commits the log to stable storage.
+func (d *durableBox[T]) Sync() error {
+	return d.file.Sync()
+}
+
+// Compact rewrites the log so that it only holds the items currently in the
+// box. The new log replaces the old one atomically.
+func (d *durableBox[T]) Compact() error {
+	f, err := os.CreateTemp(filepath.Dir(d.path), filepath.Base(d.path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+	old := d.file
+	d.file = f
+	err = d.writeCompacted()
+	if err == nil {
+		err = f.Sync()
+	}
+	if err == nil {
+		err = os.Rename(tmp, d.path)
+	}
+	if err != nil {
+		d.file = old
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	old.Close()
+	return nil
+}
+
+func (d *durableBox[T]) writeCompacted() error {
+	if _, err := d.file.Write(append([]byte(walMagic), walVersion)); err != nil {
+		return err
+	}
+	for _, item := range d.box.Items() {
+		item := item
+		if err := d.record(walPut, &item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close closes the log. The box must not be modified afterwards.
+func (d *durableBox[T]) Close() error {
+	if d.file == nil {
+		return os.ErrClosed
+	}
+	err := d.file.Close()
+	d.file = nil
+	return err
+}
+
+// Compile-time assertion that durableBox implements BlackBox[T].
+var _ BlackBox[any] = (*durableBox[any])(nil)
@@ -0,0 +1,173 @@
+package blackbox
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDurableReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.wal")
+
+	box, err := NewDurable[string](NewFIFO[string](0, 4), path)
+	if err != nil {
+		t.Fatalf("Failed to create durable box: %v", err)
+	}
+	box.Put("a")
+	box.Put("b")
+	box.Put("c")
+	if item, _ := box.Get(); item != "a" {
+		t.Fatalf("Expected a, got %s", item)
+	}
+	box.Close()
+
+	restored, err := NewDurable[string](NewFIFO[string](0, 4), path)
+	if err != nil {
+		t.Fatalf("Failed to replay: %v", err)
+	}
+	if items := restored.Items(); len(items) != 2 || items[0] != "b" || items[1] != "c" {
+		t.Errorf("Expected [b c], got %v", items)
+	}
+	restored.Clean()
+	restored.Put("d")
+	restored.Close()
+
+	restored, _ = NewDurable[string](NewFIFO[string](0, 4), path)
+	defer restored.Close()
+	if items := restored.Items(); len(items) != 1 || items[0] != "d" {
+		t.Errorf("Expected [d] after Clean, got %v", items)
+	}
+}
+
+func TestDurableRandomReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "draw.wal")
+	box, _ := NewDurable[int](New[int](WithSeed(1)), path)
+	for i := 1; i <= 5; i++ {
+		box.Put(i)
+	}
+	drawn, _ := box.Get()
+	want := box.Items()
+	box.Close()
+
+	restored, _ := NewDurable[int](New[int](WithSeed(2)), path)
+	defer restored.Close()
+	if ContainsInt(restored.Items(), drawn) || restored.Size() != len(want) {
+		t.Errorf("Expected %v without the drawn item %d, got %v", want, drawn, restored.Items())
+	}
+}
+
+func TestDurableTruncatedRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.wal")
+	box, _ := NewDurable[int](NewLIFO[int](0, 4), path)
+	box.Put(1)
+	box.Put(300)
+	box.Close()
+
+	// Simulate a crash in the middle of the last record.
+	info, _ := os.Stat(path)
+	os.Truncate(path, info.Size()-1)
+
+	restored, err := NewDurable[int](NewLIFO[int](0, 4), path)
+	if err != nil {
+		t.Fatalf("Failed to replay: %v", err)
+	}
+	restored.Put(2)
+	restored.Close()
+
+	restored, _ = NewDurable[int](NewLIFO[int](0, 4), path)
+	defer restored.Close()
+	if items := restored.Items(); !EqualInts(items, []int{1, 2}) {
+		t.Errorf("Expected [1 2], got %v", items)
+	}
+}
+
+func TestDurableCompact(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.wal")
+	box, _ := NewDurable[int](NewFIFO[int](0, 4), path)
+	for i := 0; i < 100; i++ {
+		box.Put(i)
+		box.Get()
+	}
+	box.Put(7)
+	before, _ := os.Stat(path)
+	if err := box.Compact(); err != nil {
+		t.Fatalf("Failed to compact: %v", err)
+	}
+	after, _ := os.Stat(path)
+	if after.Size() >= before.Size() {
+		t.Errorf("Expected compaction to shrink the log, got %d -> %d bytes", before.Size(), after.Size())
+	}
+	box.Put(8)
+	box.Close()
+	if err := box.Close(); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("Expected os.ErrClosed, got %v", err)
+	}
+
+	restored, _ := NewDurable[int](NewFIFO[int](0, 4), path)
+	defer restored.Close()
+	if items := restored.Items(); !EqualInts(items, []int{7, 8}) {
+		t.Errorf("Expected [7 8], got %v", items)
+	}
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("Expected temporary files to be cleaned up, got %d entries", len(entries))
+	}
+}
+
+func TestDurableInvalidLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.wal")
+	os.WriteFile(path, []byte("not a wal"), 0o644)
+	if _, err := NewDurable[int](NewFIFO[int](0, 4), path); !errors.Is(err, ErrInvalidBinary) {
+		t.Errorf("Expected ErrInvalidBinary, got %v", err)
+	}
+}
+
+func TestDurableLIFODuplicates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stack.wal")
+	box, _ := NewDurable[string](NewLIFO[string](0, 4), path)
+	box.Put("a")
+	box.Put("b")
+	box.Put("a")
+	box.Get()
+	want := box.Items()
+	box.Close()
+
+	restored, _ := NewDurable[string](NewLIFO[string](0, 4), path)
+	defer restored.Close()
+	if items := restored.Items(); len(items) != 2 || items[0] != want[0] || items[1] != want[1] {
+		t.Errorf("Expected %v, got %v", want, items)
+	}
+}
+
+func TestDurableRejectedPut(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.wal")
+	box, _ := NewDurable[int](NewFIFO[int](2, 2), path)
+	box.Put(1)
+	box.Put(2)
+	if err := box.Put(3); !errors.Is(err, ErrBlackBoxFull) {
+		t.Fatalf("Expected ErrBlackBoxFull, got %v", err)
+	}
+	box.Close()
+
+	// A larger box would accept the rejected item if it had been logged.
+	restored, _ := NewDurable[int](NewFIFO[int](0, 4), path)
+	defer restored.Close()
+	if items := restored.Items(); !EqualInts(items, []int{1, 2}) {
+		t.Errorf("Expected [1 2], got %v", items)
+	}
+}
+
+func TestDurableCleanErr(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.wal")
+	box, _ := NewDurable[int](NewFIFO[int](0, 4), path)
+	box.Put(1)
+	if box.Err() != nil {
+		t.Errorf("Expected no error, got %v", box.Err())
+	}
+	box.file.Close()
+	box.Clean()
+	if box.Err() == nil {
+		t.Error("Expected Err to report the failed Clean record")
+	}
+}
@@ -0,0 +1,75 @@
+package blackbox
+
+import (
+	"sort"
+	"time"
+)
+
+// DwellStats describes how long the items in a box have been waiting.
+type DwellStats struct {
+	Count int           `json:"count"`
+	Min   time.Duration `json:"min"`
+	Mean  time.Duration `json:"mean"`
+	P95   time.Duration `json:"p95"`
+	// Max is the age of the oldest item, e.g. to alert when work sits in the
+	// queue too long.
+	Max time.Duration `json:"max"`
+}
+
+// DwellReporter is implemented by blackboxes created with WithDwellTracking
+// or WithTTL, which record when each item was put.
+type DwellReporter interface {
+	// DwellStats returns the statistics of the time the items currently in
+	// the box have spent in it so far.
+	DwellStats() DwellStats
+}
+
+// WithDwellTracking records when each item was put, so that the box
+// implements DwellReporter. Items do not expire unless WithTTL is used too.
+func WithDwellTracking() Option {
+	return func(c *config) {
+		c.useTTL = true
+	}
+}
+
+// dwellStats computes the statistics of the given dwell times.
+func dwellStats(dwells []time.Duration) DwellStats {
+	stats := DwellStats{Count: len(dwells)}
+	if len(dwells) == 0 {
+		return stats
+	}
+	sort.Slice(dwells, func(i, j int) bool { return dwells[i] < dwells[j] })
+	var total time.Duration
+	for _, d := range dwells {
+		total += d
+	}
+	stats.Min = dwells[0]
+	stats.Max = dwells[len(dwells)-1]
+	stats.Mean = total / time.Duration(len(dwells))
+	// Nearest-rank percentile.
+	rank := (95*len(dwells) + 99) / 100
+	stats.P95 = dwells[rank-1]
+	return stats
+}
+
+func (t *timedBox[T]) DwellStats() DwellStats {
+	t.purge()
+	now := t.clock.Now()
+	var dwells []time.Duration
+	eachItem(t.box, func(it timedItem[T]) bool {
+		dwells = append(dwells, now.Sub(it.putAt))
+		return true
+	})
+	return dwellStats(dwells)
+}
+
+// DwellStats returns the dwell statistics of the wrapped box with the lock
+// held, or zero statistics if it does not implement DwellReporter.
+func (c *concurrentBox[T]) DwellStats() DwellStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if d, ok := c.box.(DwellReporter); ok {
+		return d.DwellStats()
+	}
+	return DwellStats{}
+}
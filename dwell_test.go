@@ -0,0 +1,39 @@
+package blackbox
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDwellStats(t *testing.T) {
+	box := New[int](WithStrategy(StrategyFIFO), WithDwellTracking())
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	box.(*timedBox[int]).clock = clock
+
+	if stats := box.(DwellReporter).DwellStats(); stats.Count != 0 || stats.Max != 0 {
+		t.Errorf("Expected empty stats, got %+v", stats)
+	}
+	for i := 0; i < 20; i++ {
+		box.Put(i)
+		clock.Advance(time.Second)
+	}
+	stats := NewConcurrent(box).DwellStats()
+	expected := DwellStats{Count: 20, Min: time.Second, Mean: 10500 * time.Millisecond, P95: 19 * time.Second, Max: 20 * time.Second}
+	if stats != expected {
+		t.Errorf("Expected %+v, got %+v", expected, stats)
+	}
+
+	box.Get()
+	if stats := box.(DwellReporter).DwellStats(); stats.Count != 19 || stats.Max != 19*time.Second {
+		t.Errorf("Expected the oldest item to leave, got %+v", stats)
+	}
+
+	// Items do not expire.
+	clock.Advance(24 * time.Hour)
+	if box.Size() != 19 {
+		t.Errorf("Expected 19 items, got %d", box.Size())
+	}
+	if NewConcurrent(New[int]()).DwellStats().Count != 0 {
+		t.Error("Expected zero stats without dwell tracking")
+	}
+}
@@ -0,0 +1,61 @@
+package blackbox
+
+import "fmt"
+
+// FullError is returned by Put when a bounded box named with WithName is
+// full. It carries the state of the box for logs and matches ErrBlackBoxFull
+// with errors.Is:
+//
+//	var full *blackbox.FullError
+//	if errors.As(err, &full) {
+//		log.Printf("queue %s full: %d/%d", full.Box, full.Size, full.MaxSize)
+//	}
+type FullError struct {
+	// Box is the name of the box, see WithName.
+	Box     string
+	Size    int
+	MaxSize int
+}
+
+func (e *FullError) Error() string {
+	return fmt.Sprintf("%v: %s holds %d of %d items", ErrBlackBoxFull, e.Box, e.Size, e.MaxSize)
+}
+
+// Unwrap returns ErrBlackBoxFull.
+func (e *FullError) Unwrap() error {
+	return ErrBlackBoxFull
+}
+
+// EmptyError is returned by Get and Peek when a box named with WithName is
+// empty. It matches ErrEmptyBlackBox with errors.Is.
+type EmptyError struct {
+	// Box is the name of the box, see WithName.
+	Box string
+}
+
+func (e *EmptyError) Error() string {
+	return fmt.Sprintf("%v: %s", ErrEmptyBlackBox, e.Box)
+}
+
+// Unwrap returns ErrEmptyBlackBox.
+func (e *EmptyError) Unwrap() error {
+	return ErrEmptyBlackBox
+}
+
+// fullError returns the error of a full box with the given name. Unnamed
+// boxes return ErrBlackBoxFull itself, so comparing with == keeps working.
+func fullError(name string, size, maxSize int) error {
+	if name == "" {
+		return ErrBlackBoxFull
+	}
+	return &FullError{Box: name, Size: size, MaxSize: maxSize}
+}
+
+// emptyError returns the error of an empty box with the given name. Unnamed
+// boxes return ErrEmptyBlackBox itself.
+func emptyError(name string) error {
+	if name == "" {
+		return ErrEmptyBlackBox
+	}
+	return &EmptyError{Box: name}
+}
@@ -0,0 +1,77 @@
+package blackbox
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFullError(t *testing.T) {
+	box := New[int](WithStrategy(StrategyFIFO), WithMaxSize(2), WithName("orders"))
+	box.Put(1)
+	box.Put(2)
+	err := box.Put(3)
+
+	if !errors.Is(err, ErrBlackBoxFull) {
+		t.Fatalf("Expected an error matching ErrBlackBoxFull, got %v", err)
+	}
+	var full *FullError
+	if !errors.As(err, &full) {
+		t.Fatalf("Expected *FullError, got %T", err)
+	}
+	if full.Box != "orders" || full.Size != 2 || full.MaxSize != 2 {
+		t.Errorf("Expected orders holding 2 of 2 items, got %+v", full)
+	}
+	if want := "blackbox is full: orders holds 2 of 2 items"; err.Error() != want {
+		t.Errorf("Expected %q, got %q", want, err.Error())
+	}
+}
+
+func TestEmptyError(t *testing.T) {
+	for _, strategy := range []Strategy{StrategyFIFO, StrategyLIFO, StrategyRandom, StrategyRing} {
+		box := New[int](WithStrategy(strategy), WithMaxSize(2), WithName("orders"))
+		_, err := box.Get()
+		var empty *EmptyError
+		if !errors.Is(err, ErrEmptyBlackBox) || !errors.As(err, &empty) || empty.Box != "orders" {
+			t.Errorf("%v: Expected *EmptyError of orders, got %v", strategy, err)
+		}
+		if _, err := box.Peek(); !errors.As(err, &empty) {
+			t.Errorf("%v: Expected *EmptyError from Peek, got %v", strategy, err)
+		}
+		if want := "blackbox is empty: orders"; err.Error() != want {
+			t.Errorf("Expected %q, got %q", want, err.Error())
+		}
+	}
+}
+
+func TestUnnamedErrors(t *testing.T) {
+	box := New[int](WithStrategy(StrategyFIFO), WithMaxSize(1))
+	if _, err := box.Get(); err != ErrEmptyBlackBox {
+		t.Errorf("Expected ErrEmptyBlackBox itself, got %#v", err)
+	}
+	box.Put(1)
+	if err := box.Put(2); err != ErrBlackBoxFull {
+		t.Errorf("Expected ErrBlackBoxFull itself, got %#v", err)
+	}
+}
+
+func TestNamedWrappers(t *testing.T) {
+	adaptive := New[int](WithAdaptiveMaxSize(1, 4), WithName("jobs"))
+	adaptive.Put(1)
+	var full *FullError
+	if err := adaptive.Put(2); !errors.As(err, &full) || full.Box != "jobs" || full.MaxSize != 1 {
+		t.Errorf("Expected a *FullError of jobs at the adaptive limit, got %v", err)
+	}
+
+	tickets := New[int](WithTickets(), WithName("jobs"))
+	var empty *EmptyError
+	if _, err := tickets.Get(); !errors.As(err, &empty) {
+		t.Errorf("Expected an *EmptyError through the ticket box, got %v", err)
+	}
+
+	lockFree := New[int](WithStrategy(StrategyFIFO), WithLockFree(), WithMaxSize(2), WithName("jobs"))
+	lockFree.Put(1)
+	lockFree.Put(2)
+	if err := lockFree.Put(3); !errors.As(err, &full) {
+		t.Errorf("Expected a *FullError from the lock-free box, got %v", err)
+	}
+}
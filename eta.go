@@ -0,0 +1,136 @@
+package blackbox
+
+import "time"
+
+// defaultETAWindow is the consumption window used when ETAConfig.Window is zero.
+const defaultETAWindow = time.Minute
+
+// ETAConfig configures the estimator returned by NewETA.
+type ETAConfig struct {
+	// Window is how far back successful Get calls are taken into account to
+	// measure the consumption rate. Defaults to one minute.
+	Window time.Duration
+	// Clock is used to time the Get calls. When nil, the wall clock is used.
+	Clock Clock
+}
+
+// etaBox records when items are taken out of a box to estimate waiting times.
+type etaBox[T any] struct {
+	box   BlackBox[T]
+	cfg   ETAConfig
+	start time.Time
+	// gets holds the times of the successful Get calls within the window,
+	// oldest first.
+	gets []time.Time
+}
+
+// NewETA wraps box to measure how fast its items are consumed, so that ETA
+// can tell a waiting user "you are #12, ~3 minutes", together with Position.
+// Only Get calls made through the returned box are counted.
+// Returns a concrete instance of ETA blackbox.
+func NewETA[T any](box BlackBox[T], cfg ETAConfig) *etaBox[T] {
+	if cfg.Window <= 0 {
+		cfg.Window = defaultETAWindow
+	}
+	if cfg.Clock == nil {
+		cfg.Clock = systemClock{}
+	}
+	return &etaBox[T]{box: box, cfg: cfg, start: cfg.Clock.Now()}
+}
+
+// prune forgets the Get calls that left the window.
+func (e *etaBox[T]) prune(now time.Time) {
+	from := now.Add(-e.cfg.Window)
+	n := 0
+	for n < len(e.gets) && !e.gets[n].After(from) {
+		n++
+	}
+	e.gets = e.gets[:copy(e.gets, e.gets[n:])]
+}
+
+// Rate returns the number of items taken per second over the window, or over
+// the time since the box was wrapped if that is shorter.
+func (e *etaBox[T]) Rate() float64 {
+	now := e.cfg.Clock.Now()
+	e.prune(now)
+	elapsed := now.Sub(e.start)
+	if elapsed > e.cfg.Window {
+		elapsed = e.cfg.Window
+	}
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(len(e.gets)) / elapsed.Seconds()
+}
+
+// ETA estimates how long it takes until the item at position, as returned by
+// Position, is taken out of the box at the current consumption rate. ok is
+// false when position is not positive or no item was taken within the window.
+func (e *etaBox[T]) ETA(position int) (eta time.Duration, ok bool) {
+	rate := e.Rate()
+	if position <= 0 || rate == 0 {
+		return 0, false
+	}
+	return time.Duration(float64(position) / rate * float64(time.Second)), true
+}
+
+func (e *etaBox[T]) Put(item T) error {
+	return e.box.Put(item)
+}
+
+func (e *etaBox[T]) Get() (T, error) {
+	item, err := e.box.Get()
+	if err == nil {
+		now := e.cfg.Clock.Now()
+		e.prune(now)
+		e.gets = append(e.gets, now)
+	}
+	return item, err
+}
+
+func (e *etaBox[T]) Peek() (T, error) {
+	return e.box.Peek()
+}
+
+func (e *etaBox[T]) Size() int {
+	return e.box.Size()
+}
+
+func (e *etaBox[T]) MaxSize() int {
+	return e.box.MaxSize()
+}
+
+func (e *etaBox[T]) IsFull() bool {
+	return e.box.IsFull()
+}
+
+func (e *etaBox[T]) IsEmpty() bool {
+	return e.box.IsEmpty()
+}
+
+func (e *etaBox[T]) Clean() {
+	e.box.Clean()
+}
+
+func (e *etaBox[T]) Items() []T {
+	return e.box.Items()
+}
+
+func (e *etaBox[T]) forEach(fn func(item T) bool) {
+	eachItem(e.box, fn)
+}
+
+func (e *etaBox[T]) mutatesOnRead() bool {
+	return mutatesOnRead(e.box)
+}
+
+func (e *etaBox[T]) mutatesOnPeek() bool {
+	return mutatesOnPeek(e.box)
+}
+
+func (e *etaBox[T]) drawPlan() drawPlan[T] {
+	return planOf(e.box)
+}
+
+// Compile-time assertion that etaBox implements BlackBox[T].
+var _ BlackBox[any] = (*etaBox[any])(nil)
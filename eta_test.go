@@ -0,0 +1,58 @@
+package blackbox
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPosition(t *testing.T) {
+	box := NewFIFOFrom[int]([]int{5, 6, 7}, 0)
+	if pos := Position[int](box, 7, eqInt); pos != 3 {
+		t.Errorf("Expected position 3, got %d", pos)
+	}
+	if pos := Position[int](box, 9, eqInt); pos != 0 {
+		t.Errorf("Expected position 0, got %d", pos)
+	}
+}
+
+func TestETA(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	box := NewETA[int](NewFIFO[int](0, 0), ETAConfig{Window: time.Minute, Clock: clock})
+	for i := 0; i < 20; i++ {
+		box.Put(i)
+	}
+	if _, ok := box.ETA(1); ok {
+		t.Error("Expected no estimate before any Get")
+	}
+
+	// One item every 10 seconds.
+	for i := 0; i < 3; i++ {
+		clock.Advance(10 * time.Second)
+		box.Get()
+	}
+	if rate := box.Rate(); !almostEqual(rate, 0.1) {
+		t.Errorf("Expected 0.1 items per second, got %v", rate)
+	}
+	pos := Position[int](box, 14, eqInt)
+	if pos != 12 {
+		t.Fatalf("Expected position 12, got %d", pos)
+	}
+	if eta, ok := box.ETA(pos); !ok || eta != 2*time.Minute {
+		t.Errorf("Expected ~2m, got %v (%v)", eta, ok)
+	}
+
+	// The window is full and the first Get leaves it.
+	clock.Advance(40 * time.Second)
+	box.Get()
+	clock.Advance(5 * time.Second)
+	if rate := box.Rate(); !almostEqual(rate, 3.0/60) {
+		t.Errorf("Expected 3 items per minute, got %v", rate)
+	}
+	clock.Advance(time.Hour)
+	if _, ok := box.ETA(pos); ok {
+		t.Error("Expected no estimate once the window is empty")
+	}
+	if _, ok := box.ETA(0); ok {
+		t.Error("Expected no estimate for position 0")
+	}
+}
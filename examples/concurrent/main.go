@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"time"
@@ -21,9 +22,6 @@ func main() {
 	var wgProducers sync.WaitGroup
 	var wgConsumers sync.WaitGroup
 
-	// Channel to signal that all producers finished producing.
-	producersDone := make(chan struct{})
-
 	// Start producers.
 	wgProducers.Add(producers)
 	for p := 0; p < producers; p++ {
@@ -44,60 +42,44 @@ func main() {
 		}(id)
 	}
 
+	// Context cancelled once all producers finished producing.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
 	// Start consumers.
-	totalItems := producers * itemsPerProducer
 	wgConsumers.Add(consumers)
 	for c := 0; c < consumers; c++ {
 		id := c + 1
 		go func(cid int) {
 			defer wgConsumers.Done()
-			consumed := 0
 			for {
-				item, err := cbox.Get()
-				if err == blackbox.ErrEmptyBlackBox {
-					// If producers are done and box is empty, we're finished.
-					select {
-					case <-producersDone:
-						if cbox.IsEmpty() {
-							// nothing more to consume
-							return
-						}
-						// else continue trying
-					default:
-						// producers still running, wait a bit and retry
-						time.Sleep(15 * time.Millisecond)
+				// Block until an item is available instead of polling.
+				item, err := cbox.GetCtx(ctx)
+				if err != nil {
+					// Producers are done: take what is left without blocking.
+					item, err = cbox.Get()
+					if err != nil {
+						return
 					}
-					continue
 				}
-				// Successfully got an item
 				fmt.Printf("consumer %d: got %d\n", cid, item)
-				consumed++
 				// Optional small delay to simulate work
 				time.Sleep(20 * time.Millisecond)
-
-				// Quick exit if we've consumed everything (best-effort)
-				if consumed >= totalItems {
-					return
-				}
 			}
 		}(id)
 	}
 
-	// Wait for producers to finish, then close the done channel.
+	// Wait for producers to finish, then release blocked consumers.
 	go func() {
 		wgProducers.Wait()
-		close(producersDone)
+		cancel()
 	}()
 
 	// Wait for consumers to finish.
 	wgConsumers.Wait()
 
 	// Drain any remaining items (should be none).
-	for !cbox.IsEmpty() {
-		it, err := cbox.Get()
-		if err != nil {
-			break
-		}
+	for _, it := range cbox.Drain() {
 		fmt.Printf("drain: got %v\n", it)
 	}
 
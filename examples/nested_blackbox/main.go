@@ -38,25 +38,15 @@ func main() {
 	// Now let's unpack everything!
 	fmt.Println("\n" + "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 	fmt.Println("Starting to unpack the mystery!")
-	fmt.Printf("Outer box contains: %d boxes\n\n", outerBlackBox.Size())
-
-	// Unpack outer box
-	unpackCount := 1
-	for !outerBlackBox.IsEmpty() {
-		fmt.Printf("Opening package #%d from outer box...\n", unpackCount)
-
-		middleBox, _ := outerBlackBox.Get()
-		fmt.Printf("   Found a middle box with %d items inside!\n", middleBox.Size())
-
-		// Unpack middle box
-		itemCount := 1
-		for !middleBox.IsEmpty() {
-			box, _ := middleBox.Get()
-			fmt.Printf("   📦 Item #%d: %s\n", itemCount, box.Name)
-			fmt.Printf("      Description: %s\n\n", box.Content)
-			itemCount++
-		}
-
-		unpackCount++
+	fmt.Printf("Outer box contains: %d boxes with %d items\n\n", outerBlackBox.Size(), blackbox.Count(outerBlackBox))
+
+	// Flatten opens the middle boxes one at a time as their items run out
+	items := blackbox.Flatten(outerBlackBox)
+	itemCount := 1
+	for !items.IsEmpty() {
+		item, _ := items.Get()
+		fmt.Printf("   📦 Item #%d: %s\n", itemCount, item.Name)
+		fmt.Printf("      Description: %s\n\n", item.Content)
+		itemCount++
 	}
 }
@@ -1,7 +1,9 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/raditzlawliet/blackbox"
@@ -59,31 +61,39 @@ func main() {
 		fmt.Printf("\nNext task to process: #%d - %s\n", nextTask.ID, nextTask.Name)
 	}
 
-	// Process tasks
-	fmt.Println("\nProcessing tasks (FIFO order)...")
+	// Process tasks with a pool of 2 workers
+	fmt.Println("\nProcessing tasks (FIFO order, 2 workers)...")
+	workers := blackbox.NewConcurrent[Task](taskQueue)
+	var mu sync.Mutex
 	processedCount := 0
+	backoff := blackbox.Backoff{Initial: 10 * time.Millisecond, Max: 200 * time.Millisecond, Jitter: 0.5}
 
-	for !taskQueue.IsEmpty() {
-		task, _ := taskQueue.Get()
-		fmt.Printf("  Processing Task #%d: %s\n", task.ID, task.Name)
-		fmt.Printf("    Description: %s\n", task.Description)
-
+	err := workers.Process(context.Background(), 2, func(ctx context.Context, task Task) error {
 		// Simulate task processing
 		time.Sleep(100 * time.Millisecond)
 
-		fmt.Printf("      Completed! (Remaining in queue: %d)\n\n", taskQueue.Size())
+		mu.Lock()
+		fmt.Printf("  Completed Task #%d: %s\n", task.ID, task.Name)
+		fmt.Printf("    Description: %s\n", task.Description)
 		processedCount++
-
-		// Add rejected tasks back when there's space
-		if len(rejectedTasks) > 0 && !taskQueue.IsFull() {
-			rejectedTask := rejectedTasks[0]
+		var retry *Task
+		if len(rejectedTasks) > 0 {
+			retry = &rejectedTasks[0]
 			rejectedTasks = rejectedTasks[1:]
+		}
+		mu.Unlock()
 
-			err := taskQueue.Put(rejectedTask)
-			if err == nil {
-				fmt.Printf("  Previously rejected Task #%d added to queue\n\n", rejectedTask.ID)
+		// Add rejected tasks back, waiting for space with exponential backoff
+		if retry != nil {
+			if err := blackbox.PutWithBackoff[Task](ctx, workers, *retry, backoff); err != nil {
+				return err
 			}
+			fmt.Printf("  Previously rejected Task #%d added to queue\n", retry.ID)
 		}
+		return nil
+	})
+	if err != nil {
+		fmt.Printf("Processing failed: %v\n", err)
 	}
 
 	fmt.Printf("Total tasks processed: %d\n", processedCount)
@@ -25,16 +25,8 @@ func (c Command) Undo() {
 }
 
 func main() {
-	// Create an undo stack with LIFO strategy
-	undoStack := blackbox.New[Command](
-		blackbox.WithStrategy(blackbox.StrategyLIFO),
-		blackbox.WithMaxSize(10), // Keep last 10 commands
-	)
-
-	// Create a redo stack
-	redoStack := blackbox.New[Command](
-		blackbox.WithStrategy(blackbox.StrategyLIFO),
-	)
+	// Keep the last 10 commands, forgetting the oldest one instead of failing
+	history := blackbox.NewUndoManager[Command](10)
 
 	// Define some commands
 	commands := []Command{
@@ -45,69 +37,52 @@ func main() {
 		{ID: 5, Action: "WRITE", Description: "Write 'More content'", Data: "More content"},
 	}
 
-	// Execute commands and add to undo stack
+	// Execute commands and record them
 	for _, cmd := range commands {
 		cmd.Execute()
-		undoStack.Put(cmd)
+		history.Do(cmd)
 	}
 
-	fmt.Printf("\nUndo stack size: %d commands\n", undoStack.Size())
-
-	// Peek at what command would be undone next
-	if !undoStack.IsEmpty() {
-		nextCmd, _ := undoStack.Peek()
-		fmt.Printf("Next command to undo: #%d - %s\n", nextCmd.ID, nextCmd.Action)
-	}
+	fmt.Printf("\nUndo history: %d commands\n", history.UndoSize())
 
 	// Undo last 3 commands
 	fmt.Println("\nUndoing last 3 commands...")
-	for i := 0; i < 3 && !undoStack.IsEmpty(); i++ {
-		cmd, _ := undoStack.Get()
+	for i := 0; i < 3 && history.CanUndo(); i++ {
+		cmd, _ := history.Undo()
 		cmd.Undo()
-		redoStack.Put(cmd) // Move to redo stack
-		fmt.Printf("Undo stack: %d | Redo stack: %d\n\n", undoStack.Size(), redoStack.Size())
+		fmt.Printf("Undo: %d | Redo: %d\n\n", history.UndoSize(), history.RedoSize())
 	}
 
-	// Show current state
-	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
-	fmt.Printf("Current state:\n")
-	fmt.Printf("- Undo stack: %d commands\n", undoStack.Size())
-	fmt.Printf("- Redo stack: %d commands\n", redoStack.Size())
-
 	// Redo 2 commands
-	fmt.Println("\nRedoing 2 commands...")
-	for i := 0; i < 2 && !redoStack.IsEmpty(); i++ {
-		cmd, _ := redoStack.Get()
+	fmt.Println("Redoing 2 commands...")
+	for i := 0; i < 2 && history.CanRedo(); i++ {
+		cmd, _ := history.Redo()
 		cmd.Execute()
-		undoStack.Put(cmd) // Move back to undo stack
-		fmt.Printf("Undo stack: %d | Redo stack: %d\n\n", undoStack.Size(), redoStack.Size())
+		fmt.Printf("Undo: %d | Redo: %d\n\n", history.UndoSize(), history.RedoSize())
 	}
 
-	// Execute a new command (this clears redo stack in real apps)
+	// Execute a new command, which invalidates the redo history
 	fmt.Println("Executing new command...")
 	newCmd := Command{ID: 6, Action: "SAVE", Description: "Save document", Data: "document.txt"}
 	newCmd.Execute()
-	undoStack.Put(newCmd)
-
-	// Clear redo stack (new action invalidates redo history)
-	redoStack.Clean()
-	fmt.Println("\n(Redo stack cleared - new action invalidates redo history)")
+	history.Do(newCmd)
+	fmt.Printf("Can redo: %v\n", history.CanRedo())
 
 	// Final state
 	fmt.Println("\n━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 	fmt.Printf("Final state:\n")
-	fmt.Printf("- Undo stack: %d commands\n", undoStack.Size())
-	fmt.Printf("- Redo stack: %d commands\n", redoStack.Size())
+	fmt.Printf("- Undo history: %d commands\n", history.UndoSize())
+	fmt.Printf("- Redo history: %d commands\n", history.RedoSize())
 
 	// Demonstrate undo all
 	fmt.Println("\nUndoing all remaining commands...")
 	count := 1
-	for !undoStack.IsEmpty() {
-		cmd, _ := undoStack.Get()
+	for history.CanUndo() {
+		cmd, _ := history.Undo()
 		fmt.Printf("%d. ", count)
 		cmd.Undo()
 		count++
 	}
 
-	fmt.Printf("Undo stack is empty: %v\n", undoStack.IsEmpty())
+	fmt.Printf("Can undo: %v\n", history.CanUndo())
 }
@@ -0,0 +1,138 @@
+package blackbox
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrSkipRow can be returned by the row mapper of ImportCSV to skip a row,
+// e.g. a header.
+var ErrSkipRow = errors.New("blackbox row skipped")
+
+// exportItems calls fn with the items of box in the order of Items, without
+// copying them when the box implements UnsafeItemser.
+func exportItems[T any](box BlackBox[T], fn func(item T) error) error {
+	var items []T
+	if u, ok := box.(UnsafeItemser[T]); ok {
+		items = u.UnsafeItems()
+	} else {
+		items = box.Items()
+	}
+	for _, item := range items {
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ExportJSONL writes the items of box to w as JSON Lines, one JSON value per
+// line in the order of Items, so that ImportJSONL into an empty box of the
+// same strategy restores it. Unlike snapshots, the lines carry no version and
+// are not migrated when the item type changes.
+func ExportJSONL[T any](w io.Writer, box BlackBox[T]) error {
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	if err := exportItems(box, func(item T) error { return enc.Encode(item) }); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// JSONItemCodec returns an ItemCodec encoding items as JSON, e.g. for
+// ImportJSONL.
+func JSONItemCodec[T any]() ItemCodec[T] {
+	return ItemCodec[T]{
+		Encode: func(item T) ([]byte, error) {
+			return json.Marshal(item)
+		},
+		Decode: func(data []byte) (T, error) {
+			var item T
+			err := json.Unmarshal(data, &item)
+			return item, err
+		},
+	}
+}
+
+// ImportJSONL puts the items read from the JSON Lines in r into box as they
+// are decoded with codec, so large data files are bulk-loaded without
+// materializing a slice first. Pass JSONItemCodec for plain JSON values, or a
+// codec of your own, e.g. to upgrade items of an older layout. Empty lines
+// are skipped. It returns the number of items put; on error, the items before
+// the failing line stay in box.
+func ImportJSONL[T any](r io.Reader, box BlackBox[T], codec ItemCodec[T]) (int, error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(nil, maxBinaryLen)
+	n, line := 0, 0
+	for sc.Scan() {
+		line++
+		data := sc.Bytes()
+		if len(data) == 0 {
+			continue
+		}
+		item, err := codec.Decode(data)
+		if err != nil {
+			return n, fmt.Errorf("blackbox: line %d: %w", line, err)
+		}
+		if err := box.Put(item); err != nil {
+			return n, fmt.Errorf("blackbox: line %d: %w", line, err)
+		}
+		n++
+	}
+	return n, sc.Err()
+}
+
+// ExportCSV writes the items of box to w as CSV records mapped by toRow, in
+// the order of Items. Like ExportJSONL, the records carry no version.
+func ExportCSV[T any](w io.Writer, box BlackBox[T], toRow func(item T) ([]string, error)) error {
+	cw := csv.NewWriter(w)
+	err := exportItems(box, func(item T) error {
+		row, err := toRow(item)
+		if err != nil {
+			return err
+		}
+		return cw.Write(row)
+	})
+	if err != nil {
+		return err
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// ImportCSV puts the items mapped by fromRow from the CSV records in r into
+// box as they are read. fromRow can return ErrSkipRow to skip a record, e.g.
+// the header. row is reused by the next record, so copy it to keep it. It
+// returns the number of items put; on error, the items before the failing
+// record stay in box.
+func ImportCSV[T any](r io.Reader, box BlackBox[T], fromRow func(row []string) (T, error)) (int, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.ReuseRecord = true
+	n := 0
+	for {
+		row, err := cr.Read()
+		if err == io.EOF {
+			return n, nil
+		}
+		if err != nil {
+			return n, err
+		}
+		item, err := fromRow(row)
+		if errors.Is(err, ErrSkipRow) {
+			continue
+		}
+		if err == nil {
+			err = box.Put(item)
+		}
+		if err != nil {
+			line, _ := cr.FieldPos(0)
+			return n, fmt.Errorf("blackbox: line %d: %w", line, err)
+		}
+		n++
+	}
+}
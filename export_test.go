@@ -0,0 +1,93 @@
+package blackbox
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestJSONL(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ExportJSONL[int](&buf, NewLIFOFrom[int]([]int{1, 2, 3}, 0)); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "1\n2\n3\n" {
+		t.Errorf("Unexpected JSONL %q", buf.String())
+	}
+	box := NewLIFO[int](0, 0)
+	if n, err := ImportJSONL[int](&buf, box, JSONItemCodec[int]()); err != nil || n != 3 {
+		t.Fatalf("Expected 3 items, got %d (%v)", n, err)
+	}
+	if items := Drain[int](box); !EqualInts(items, []int{3, 2, 1}) {
+		t.Errorf("Expected the LIFO box to be restored, got %v", items)
+	}
+
+	n, err := ImportJSONL[int](strings.NewReader("1\n\n2\n3\n"), NewFIFO[int](2, 0), JSONItemCodec[int]())
+	if n != 2 || !errors.Is(err, ErrBlackBoxFull) || !strings.Contains(err.Error(), "line 4") {
+		t.Errorf("Expected ErrBlackBoxFull on line 4 after 2 items, got %d (%v)", n, err)
+	}
+	if _, err := ImportJSONL[int](strings.NewReader("x\n"), NewFIFO[int](0, 0), JSONItemCodec[int]()); err == nil {
+		t.Error("Expected a decoding error")
+	}
+}
+
+func TestCSV(t *testing.T) {
+	type task struct {
+		id   int
+		name string
+	}
+	data := "id,name\n1,build\n2,\"deploy, then test\"\n"
+	box := NewFIFO[task](0, 0)
+	n, err := ImportCSV[task](strings.NewReader(data), box, func(row []string) (task, error) {
+		if row[0] == "id" {
+			return task{}, ErrSkipRow
+		}
+		id, err := strconv.Atoi(row[0])
+		return task{id, row[1]}, err
+	})
+	if err != nil || n != 2 {
+		t.Fatalf("Expected 2 items, got %d (%v)", n, err)
+	}
+
+	var buf bytes.Buffer
+	err = ExportCSV[task](&buf, box, func(t task) ([]string, error) {
+		return []string{strconv.Itoa(t.id), t.name}, nil
+	})
+	if err != nil || buf.String() != data[len("id,name\n"):] {
+		t.Errorf("Unexpected CSV %q (%v)", buf.String(), err)
+	}
+
+	_, err = ImportCSV[task](strings.NewReader("1,a\nx,b\n"), NewFIFO[task](0, 0), func(row []string) (task, error) {
+		id, err := strconv.Atoi(row[0])
+		return task{id, row[1]}, err
+	})
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Expected an error on line 2, got %v", err)
+	}
+}
+
+func TestImportCodecAndWrappedSkip(t *testing.T) {
+	upper := ItemCodec[string]{Decode: func(data []byte) (string, error) {
+		return strings.ToUpper(string(data)), nil
+	}}
+	box := NewFIFO[string](0, 0)
+	if n, err := ImportJSONL[string](strings.NewReader("a\nb\n"), box, upper); err != nil || n != 2 {
+		t.Fatalf("Expected 2 items, got %d (%v)", n, err)
+	}
+	if items := box.Items(); len(items) != 2 || items[0] != "A" || items[1] != "B" {
+		t.Errorf("Expected the codec to decode [A B], got %v", items)
+	}
+
+	n, err := ImportCSV[string](strings.NewReader("# header\nx\n"), box, func(row []string) (string, error) {
+		if strings.HasPrefix(row[0], "#") {
+			return "", fmt.Errorf("%w: comment", ErrSkipRow)
+		}
+		return row[0], nil
+	})
+	if err != nil || n != 1 {
+		t.Errorf("Expected a wrapped ErrSkipRow to skip the row, got %d (%v)", n, err)
+	}
+}
@@ -0,0 +1,130 @@
+package blackbox
+
+// fairFIFO keeps a FIFO queue per key and round-robins Get across the keys,
+// so that one key holding many items cannot starve the others.
+type fairFIFO[T any, K comparable] struct {
+	key     func(T) K
+	queues  map[K]*ring[T]
+	keys    ring[K] // keys with queued items, in the order they are served
+	size    int
+	maxSize int
+}
+
+// NewFairFIFO creates a new fair FIFO blackbox with the specified maximum
+// size. Items are grouped by keyFn, e.g. by tenant, and Get takes the oldest
+// item of each key in turn: a tenant enqueueing 10k tasks only gets every
+// other turn against a second tenant. Within a key, items keep FIFO order.
+// Returns a concrete instance of fair FIFO blackbox without interface.
+func NewFairFIFO[T any, K comparable](keyFn func(T) K, maxSize int) *fairFIFO[T, K] {
+	return &fairFIFO[T, K]{
+		key:     keyFn,
+		queues:  make(map[K]*ring[T]),
+		keys:    newRing[K](0, 0),
+		maxSize: maxSize,
+	}
+}
+
+// KeySize returns the number of items of the given key in the box.
+func (b *fairFIFO[T, K]) KeySize(key K) int {
+	if q, ok := b.queues[key]; ok {
+		return q.size
+	}
+	return 0
+}
+
+func (b *fairFIFO[T, K]) Put(item T) error {
+	if b.maxSize > 0 && b.size >= b.maxSize {
+		return ErrBlackBoxFull
+	}
+	k := b.key(item)
+	q, ok := b.queues[k]
+	if !ok {
+		r := newRing[T](0, 0)
+		q = &r
+		b.queues[k] = q
+		b.keys.pushBack(k)
+	}
+	q.pushBack(item)
+	b.size++
+	return nil
+}
+
+// Get returns the oldest item of the key whose turn it is, then moves that
+// key to the end of the line.
+func (b *fairFIFO[T, K]) Get() (T, error) {
+	if b.size == 0 {
+		var zero T
+		return zero, ErrEmptyBlackBox
+	}
+	k := b.keys.popFront()
+	q := b.queues[k]
+	item := q.popFront()
+	if q.size > 0 {
+		b.keys.pushBack(k)
+	} else {
+		delete(b.queues, k)
+	}
+	b.size--
+	return item, nil
+}
+
+func (b *fairFIFO[T, K]) Peek() (T, error) {
+	if b.size == 0 {
+		var zero T
+		return zero, ErrEmptyBlackBox
+	}
+	return b.queues[b.keys.front()].front(), nil
+}
+
+func (b *fairFIFO[T, K]) Size() int {
+	return b.size
+}
+
+func (b *fairFIFO[T, K]) MaxSize() int {
+	return b.maxSize
+}
+
+func (b *fairFIFO[T, K]) IsFull() bool {
+	return b.maxSize > 0 && b.size >= b.maxSize
+}
+
+func (b *fairFIFO[T, K]) IsEmpty() bool {
+	return b.size == 0
+}
+
+func (b *fairFIFO[T, K]) Clean() {
+	b.queues = make(map[K]*ring[T])
+	b.keys.Clean()
+	b.size = 0
+}
+
+// Items returns the items in the order successive Get calls would return them.
+func (b *fairFIFO[T, K]) Items() []T {
+	items := make([]T, 0, b.size)
+	b.forEach(func(item T) bool {
+		items = append(items, item)
+		return true
+	})
+	return items
+}
+
+// forEach visits the items in retrieval order: the i-th item of every key in
+// turn, for i = 0, 1, ...
+func (b *fairFIFO[T, K]) forEach(fn func(item T) bool) {
+	keys := b.keys.Items()
+	for i, left := 0, b.size; left > 0; i++ {
+		for _, k := range keys {
+			q := b.queues[k]
+			if i >= q.size {
+				continue
+			}
+			if !fn(q.items[(q.head+i)%len(q.items)]) {
+				return
+			}
+			left--
+		}
+	}
+}
+
+// Compile-time assertion that fairFIFO implements BlackBox[T].
+var _ BlackBox[any] = (*fairFIFO[any, string])(nil)
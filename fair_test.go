@@ -0,0 +1,60 @@
+package blackbox
+
+import (
+	"errors"
+	"testing"
+)
+
+type tenantTask struct {
+	tenant string
+	id     int
+}
+
+func TestFairFIFORoundRobin(t *testing.T) {
+	box := NewFairFIFO(func(task tenantTask) string { return task.tenant }, 0)
+	for i := 1; i <= 100; i++ {
+		box.Put(tenantTask{"chatty", i})
+	}
+	box.Put(tenantTask{"quiet", 1})
+	box.Put(tenantTask{"other", 1})
+	box.Put(tenantTask{"quiet", 2})
+
+	want := []tenantTask{{"chatty", 1}, {"quiet", 1}, {"other", 1}, {"chatty", 2}, {"quiet", 2}, {"chatty", 3}, {"chatty", 4}}
+	if items := box.Items(); len(items) != 103 || items[6] != want[6] {
+		t.Errorf("Expected Items in retrieval order, got %v", items[:7])
+	}
+	if item, _ := box.Peek(); item != want[0] {
+		t.Errorf("Expected Peek to return %v, got %v", want[0], item)
+	}
+	for _, w := range want {
+		if item, err := box.Get(); err != nil || item != w {
+			t.Errorf("Expected %v, got %v (%v)", w, item, err)
+		}
+	}
+	if box.KeySize("quiet") != 0 || box.KeySize("chatty") != 96 || box.Size() != 96 {
+		t.Errorf("Expected 96 chatty items, got %d of %d", box.KeySize("chatty"), box.Size())
+	}
+
+	// A key that comes back goes to the end of the line.
+	box.Put(tenantTask{"quiet", 3})
+	box.Get()
+	if item, _ := box.Get(); item != (tenantTask{"quiet", 3}) {
+		t.Errorf("Expected quiet to be served after chatty, got %v", item)
+	}
+}
+
+func TestFairFIFOMaxSize(t *testing.T) {
+	box := NewFairFIFO(func(i int) int { return i % 2 }, 2)
+	box.Put(1)
+	box.Put(2)
+	if err := box.Put(3); !errors.Is(err, ErrBlackBoxFull) {
+		t.Errorf("Expected ErrBlackBoxFull, got %v", err)
+	}
+	box.Clean()
+	if !box.IsEmpty() || box.KeySize(1) != 0 {
+		t.Error("Expected an empty box after Clean")
+	}
+	if _, err := box.Get(); err != ErrEmptyBlackBox {
+		t.Errorf("Expected ErrEmptyBlackBox, got %v", err)
+	}
+}
@@ -1,161 +1,103 @@
 package blackbox
 
 type fifoBox[T any] struct {
-	items   []T
-	head    int
-	tail    int
-	size    int
-	maxSize int
+	ring[T]
+	overflow OverflowPolicy
+	onEvict  func(item T)
+	name     string // see WithName
+	// overwrite makes Put evict the oldest item once full whatever the
+	// overflow policy, see StrategyRing.
+	overwrite bool
 }
 
 // NewFIFO creates a new FIFO blackbox with the specified maximum size and capacity.
 // Returns a concrete instance of lifo blackbox without interface.
 func NewFIFO[T any](maxSize, capacity int) *fifoBox[T] {
 	return &fifoBox[T]{
-		items:   make([]T, capacity),
-		head:    0,
-		tail:    0,
-		size:    0,
-		maxSize: maxSize,
+		ring: newRing[T](maxSize, capacity),
 	}
 }
 
+// NewRingBuffer creates a new ring buffer blackbox: a FIFO blackbox of fixed
+// size where Put always succeeds by overwriting the oldest item once full,
+// e.g. to keep the last N events for telemetry. Like New with StrategyRing.
+// A size below 1 is treated as 1.
+// Returns a concrete instance of fifo blackbox without interface.
+func NewRingBuffer[T any](size int) *fifoBox[T] {
+	if size < 1 {
+		size = 1
+	}
+	b := NewFIFO[T](size, size)
+	b.overwrite = true
+	return b
+}
+
 // NewFIFOFrom creates a new FIFO blackbox from a slice of items and the specified maximum size.
 // items are copied so it safe to use the original slice after the blackbox is created.
 func NewFIFOFrom[T any](items []T, maxSize int) *fifoBox[T] {
-	if maxSize > 0 && maxSize < len(items) {
-		maxSize = len(items)
-	}
 	newItems := make([]T, len(items))
 	copy(newItems, items)
 	return &fifoBox[T]{
-		items:   newItems,
-		head:    0,
-		tail:    len(newItems),
-		size:    len(newItems),
-		maxSize: maxSize,
+		ring: newRingFrom(newItems, maxSize),
 	}
 }
 
 // NewFIFOFromBlackBox creates a new FIFO blackbox from a blackbox.
 // items are copied so it safe to use the original blackbox after the blackbox is created.
 func NewFIFOFromBlackBox[T any](box BlackBox[T], maxSize int) *fifoBox[T] {
-	if maxSize > 0 && maxSize < box.Size() {
-		maxSize = box.Size()
-	}
 	return &fifoBox[T]{
-		items:   box.Items(),
-		head:    0,
-		tail:    box.Size(),
-		size:    box.Size(),
-		maxSize: maxSize,
+		ring: newRingFrom(box.Items(), maxSize),
 	}
 }
 
-func (b *fifoBox[T]) grow() {
-	// Initialize newCapacity
-	var newCapacity int
-	if len(b.items) == 0 {
-		newCapacity = defaultInitialCapacity
-	} else {
-		newCapacity = len(b.items) * growthFactor
+// evict reports an item removed by the box itself to the evict callback.
+func (b *fifoBox[T]) evict(item T) {
+	if b.onEvict != nil {
+		b.onEvict(item)
 	}
-
-	if b.maxSize > 0 && newCapacity > b.maxSize {
-		newCapacity = b.maxSize
-	}
-
-	newItems := make([]T, newCapacity)
-
-	if b.size > 0 {
-		if b.head < b.tail {
-			copy(newItems, b.items[b.head:b.tail])
-		} else {
-			n := copy(newItems, b.items[b.head:])
-			copy(newItems[n:], b.items[:b.tail])
-		}
-	}
-
-	b.head = 0
-	b.tail = b.size
-	b.items = newItems
 }
 
 func (b *fifoBox[T]) Put(item T) error {
 	if b.maxSize > 0 && b.size >= b.maxSize {
-		return ErrBlackBoxFull
-	}
-
-	if b.size >= len(b.items) {
-		b.grow()
+		policy := b.overflow
+		if b.overwrite {
+			policy = OverflowDropOldest
+		}
+		switch policy {
+		case OverflowDropOldest:
+			b.evict(b.popFront())
+		case OverflowDropNewest:
+			b.evict(item)
+			return nil
+		default:
+			return fullError(b.name, b.size, b.maxSize)
+		}
 	}
-
-	b.items[b.tail] = item
-	b.tail = (b.tail + 1) % len(b.items)
-	b.size++
+	b.pushBack(item)
 	return nil
 }
 
 func (b *fifoBox[T]) Get() (T, error) {
 	if b.size == 0 {
 		var zero T
-		return zero, ErrEmptyBlackBox
+		return zero, emptyError(b.name)
 	}
-
-	item := b.items[b.head]
-	var zero T
-	b.items[b.head] = zero
-	b.head = (b.head + 1) % len(b.items)
-	b.size--
-	return item, nil
+	return b.popFront(), nil
 }
 
 func (b *fifoBox[T]) Peek() (T, error) {
 	if b.size == 0 {
 		var zero T
-		return zero, ErrEmptyBlackBox
+		return zero, emptyError(b.name)
 	}
-	return b.items[b.head], nil
-}
-
-func (b *fifoBox[T]) Size() int {
-	return b.size
-}
-
-func (b *fifoBox[T]) MaxSize() int {
-	return b.maxSize
-}
-
-func (b *fifoBox[T]) IsFull() bool {
-	return b.maxSize > 0 && b.size >= b.maxSize
-}
-
-func (b *fifoBox[T]) IsEmpty() bool {
-	return b.size == 0
+	return b.front(), nil
 }
 
 func (b *fifoBox[T]) Clean() {
-	var zero T
-	for i := 0; i < b.size; i++ {
-		idx := (b.head + i) % len(b.items)
-		b.items[idx] = zero
-	}
-	b.head = 0
-	b.tail = 0
-	b.size = 0
-}
-
-func (b *fifoBox[T]) Items() []T {
-	if b.size == 0 {
-		return make([]T, 0)
-	}
-
-	items := make([]T, b.size)
-	if b.head < b.tail {
-		copy(items, b.items[b.head:b.tail])
-	} else {
-		n := copy(items, b.items[b.head:])
-		copy(items[n:], b.items[:b.tail])
+	if b.onEvict != nil {
+		for i := 0; i < b.size; i++ {
+			b.onEvict(b.items[(b.head+i)%len(b.items)])
+		}
 	}
-	return items
+	b.ring.Clean()
 }
@@ -102,3 +102,26 @@ func TestFIFOGrowRespectsMaxSizeAndCopiesWrapAround(t *testing.T) {
 		t.Fatalf("wrapped copy mismatch: want %v got %v", want, got)
 	}
 }
+
+func TestFIFOFromWrapsTail(t *testing.T) {
+	b := NewFIFOFrom[int]([]int{1, 2, 3}, 0)
+	b.Get()
+	b.Get()
+	if err := b.Put(4); err != nil {
+		t.Fatalf("Failed to put item: %v", err)
+	}
+	want := []int{3, 4}
+	if got := b.Items(); !EqualInts(got, want) {
+		t.Fatalf("items mismatch: want %v got %v", want, got)
+	}
+
+	b2 := NewFIFOFromBlackBox[int](NewLIFOFrom[int]([]int{1, 2, 3}, 0), 0)
+	b2.Get()
+	if err := b2.Put(4); err != nil {
+		t.Fatalf("Failed to put item: %v", err)
+	}
+	want = []int{2, 3, 4}
+	if got := b2.Items(); !EqualInts(got, want) {
+		t.Fatalf("items mismatch: want %v got %v", want, got)
+	}
+}
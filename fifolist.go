@@ -0,0 +1,155 @@
+package blackbox
+
+import "sync"
+
+// listNode is an element of the linked list of listFIFO.
+type listNode[T any] struct {
+	item T
+	next *listNode[T]
+}
+
+// listFIFO is a FIFO blackbox backed by a singly linked list. Memory follows
+// the number of items: nodes of removed items go back to a sync.Pool that the
+// garbage collector may drain, instead of a ring buffer that grows to the
+// peak size and never shrinks.
+type listFIFO[T any] struct {
+	head     *listNode[T]
+	tail     *listNode[T]
+	size     int
+	maxSize  int
+	overflow OverflowPolicy
+	onEvict  func(item T)
+	pool     sync.Pool
+}
+
+// NewFIFOList creates a new FIFO blackbox with the specified maximum size,
+// backed by a linked list with node pooling. Prefer it over NewFIFO for
+// workloads whose size varies wildly, where holding on to the peak capacity
+// is the wrong tradeoff.
+// Returns a concrete instance of list fifo blackbox without interface.
+func NewFIFOList[T any](maxSize int) *listFIFO[T] {
+	return &listFIFO[T]{maxSize: maxSize}
+}
+
+// NewFIFOListFrom creates a new list FIFO blackbox from a slice of items with
+// the specified maximum size.
+func NewFIFOListFrom[T any](items []T, maxSize int) *listFIFO[T] {
+	if maxSize > 0 && maxSize < len(items) {
+		maxSize = len(items)
+	}
+	b := NewFIFOList[T](maxSize)
+	for _, item := range items {
+		b.pushBack(item)
+	}
+	return b
+}
+
+func (b *listFIFO[T]) pushBack(item T) {
+	node, _ := b.pool.Get().(*listNode[T])
+	if node == nil {
+		node = &listNode[T]{}
+	}
+	node.item = item
+	if b.tail == nil {
+		b.head = node
+	} else {
+		b.tail.next = node
+	}
+	b.tail = node
+	b.size++
+}
+
+func (b *listFIFO[T]) popFront() T {
+	node := b.head
+	item := node.item
+	b.head = node.next
+	if b.head == nil {
+		b.tail = nil
+	}
+	b.size--
+	*node = listNode[T]{}
+	b.pool.Put(node)
+	return item
+}
+
+// evict reports an item removed by the box itself to the evict callback.
+func (b *listFIFO[T]) evict(item T) {
+	if b.onEvict != nil {
+		b.onEvict(item)
+	}
+}
+
+func (b *listFIFO[T]) Put(item T) error {
+	if b.maxSize > 0 && b.size >= b.maxSize {
+		switch b.overflow {
+		case OverflowDropOldest:
+			b.evict(b.popFront())
+		case OverflowDropNewest:
+			b.evict(item)
+			return nil
+		default:
+			return ErrBlackBoxFull
+		}
+	}
+	b.pushBack(item)
+	return nil
+}
+
+func (b *listFIFO[T]) Get() (T, error) {
+	if b.size == 0 {
+		var zero T
+		return zero, ErrEmptyBlackBox
+	}
+	return b.popFront(), nil
+}
+
+func (b *listFIFO[T]) Peek() (T, error) {
+	if b.size == 0 {
+		var zero T
+		return zero, ErrEmptyBlackBox
+	}
+	return b.head.item, nil
+}
+
+func (b *listFIFO[T]) Size() int {
+	return b.size
+}
+
+func (b *listFIFO[T]) MaxSize() int {
+	return b.maxSize
+}
+
+func (b *listFIFO[T]) IsFull() bool {
+	return b.maxSize > 0 && b.size >= b.maxSize
+}
+
+func (b *listFIFO[T]) IsEmpty() bool {
+	return b.size == 0
+}
+
+// forEach calls fn for every item in FIFO order until fn returns false.
+func (b *listFIFO[T]) forEach(fn func(item T) bool) {
+	for node := b.head; node != nil; node = node.next {
+		if !fn(node.item) {
+			return
+		}
+	}
+}
+
+func (b *listFIFO[T]) Clean() {
+	for b.size > 0 {
+		b.evict(b.popFront())
+	}
+}
+
+func (b *listFIFO[T]) Items() []T {
+	items := make([]T, 0, b.size)
+	b.forEach(func(item T) bool {
+		items = append(items, item)
+		return true
+	})
+	return items
+}
+
+// Compile-time assertion that listFIFO implements BlackBox[T].
+var _ BlackBox[any] = (*listFIFO[any])(nil)
@@ -0,0 +1,52 @@
+package blackbox
+
+import "testing"
+
+func TestFIFOList(t *testing.T) {
+	box := NewFIFOList[int](3)
+	for i := 1; i <= 3; i++ {
+		if err := box.Put(i); err != nil {
+			t.Fatalf("Failed to put item %d: %v", i, err)
+		}
+	}
+	if err := box.Put(4); err != ErrBlackBoxFull {
+		t.Errorf("Expected ErrBlackBoxFull, got %v", err)
+	}
+	if !box.IsFull() || !EqualInts(box.Items(), []int{1, 2, 3}) {
+		t.Errorf("Expected a full box with [1 2 3], got %v", box.Items())
+	}
+	if item, _ := box.Peek(); item != 1 {
+		t.Errorf("Expected Peek to return 1, got %d", item)
+	}
+	for i := 1; i <= 3; i++ {
+		if item, err := box.Get(); err != nil || item != i {
+			t.Errorf("Expected %d, got %d (%v)", i, item, err)
+		}
+	}
+	if _, err := box.Get(); err != ErrEmptyBlackBox {
+		t.Errorf("Expected ErrEmptyBlackBox, got %v", err)
+	}
+
+	// Pooled nodes are reused without leaking old links.
+	box.Put(5)
+	box.Put(6)
+	if !EqualInts(box.Items(), []int{5, 6}) || box.Size() != 2 {
+		t.Errorf("Expected [5 6], got %v", box.Items())
+	}
+}
+
+func TestFIFOListOverflow(t *testing.T) {
+	var evicted []int
+	box := NewFIFOListFrom[int]([]int{1, 2}, 2)
+	box.overflow = OverflowDropOldest
+	box.onEvict = func(item int) { evicted = append(evicted, item) }
+
+	box.Put(3)
+	if !EqualInts(box.Items(), []int{2, 3}) || !EqualInts(evicted, []int{1}) {
+		t.Errorf("Expected [2 3] with 1 evicted, got %v and %v", box.Items(), evicted)
+	}
+	box.Clean()
+	if !box.IsEmpty() || !EqualInts(evicted, []int{1, 2, 3}) {
+		t.Errorf("Expected Clean to evict every item, got %v", evicted)
+	}
+}
@@ -0,0 +1,37 @@
+package blackbox
+
+// Filter returns a new FIFO box holding the items of box for which pred
+// returns true, in retrieval order. box is left untouched; the result is a
+// snapshot that does not follow later changes to box.
+func Filter[T any](box BlackBox[T], pred func(item T) bool) BlackBox[T] {
+	var items []T
+	eachItem(box, func(item T) bool {
+		if pred(item) {
+			items = append(items, item)
+		}
+		return true
+	})
+	return &fifoBox[T]{ring: newRingFrom(items, 0)}
+}
+
+// Map returns a new FIFO box holding fn of every item of box, in retrieval
+// order. Unlike MapView, fn is applied once to a snapshot of the items.
+func Map[T, U any](box BlackBox[T], fn func(item T) U) BlackBox[U] {
+	items := make([]U, 0, box.Size())
+	eachItem(box, func(item T) bool {
+		items = append(items, fn(item))
+		return true
+	})
+	return &fifoBox[U]{ring: newRingFrom(items, 0)}
+}
+
+// Reduce folds the items of box into a single value, starting from init and
+// visiting the items in retrieval order, e.g. to sum the amounts of a queue.
+func Reduce[T, U any](box BlackBox[T], init U, fn func(acc U, item T) U) U {
+	acc := init
+	eachItem(box, func(item T) bool {
+		acc = fn(acc, item)
+		return true
+	})
+	return acc
+}
@@ -0,0 +1,31 @@
+package blackbox
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestFilterMapReduce(t *testing.T) {
+	box := NewLIFOFrom([]int{1, 2, 3, 4, 5}, 0)
+
+	even := Filter[int](box, func(i int) bool { return i%2 == 0 })
+	if !EqualInts(even.Items(), []int{4, 2}) {
+		t.Errorf("Expected [4 2] in retrieval order, got %v", even.Items())
+	}
+	if box.Size() != 5 {
+		t.Errorf("Expected the box to be untouched, got size %d", box.Size())
+	}
+
+	labels := Map[int, string](box, strconv.Itoa)
+	if item, _ := labels.Get(); item != "5" || labels.Size() != 4 {
+		t.Errorf("Expected to get \"5\" first, got %q", item)
+	}
+
+	sum := Reduce[int, int](box, 0, func(acc, i int) int { return acc + i })
+	if sum != 15 {
+		t.Errorf("Expected sum 15, got %d", sum)
+	}
+	if Filter[int](NewFIFO[int](0, 0), func(int) bool { return true }).Size() != 0 {
+		t.Error("Expected an empty box")
+	}
+}
@@ -0,0 +1,157 @@
+package blackbox
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+// fuzzOps are the operations driven by the fuzz targets, one per input byte.
+const (
+	opPut = iota
+	opGet
+	opPeek
+	opClean
+	opPutFront
+	opGetBack
+	fuzzOps
+)
+
+// fuzzModel replays ops on box and on a slice holding the expected items
+// from front (next out for FIFO) to back, checking both agree and that the
+// box invariants hold after every op.
+func fuzzModel(t *testing.T, box BlackBox[int], maxSize int, ring, lifo bool, ops []byte) {
+	var model []int
+	for i, op := range ops {
+		item := i + 1
+		switch int(op) % fuzzOps {
+		case opPut:
+			err := box.Put(item)
+			switch {
+			case maxSize == 0 || len(model) < maxSize:
+				model = append(model, item)
+			case ring:
+				model = append(model[1:], item)
+			default:
+				if !errors.Is(err, ErrBlackBoxFull) {
+					t.Fatalf("op %d: Expected ErrBlackBoxFull, got %v", i, err)
+				}
+			}
+		case opGet, opPeek:
+			get := box.Peek
+			if int(op)%fuzzOps == opGet {
+				get = box.Get
+			}
+			got, err := get()
+			if len(model) == 0 {
+				if err != ErrEmptyBlackBox {
+					t.Fatalf("op %d: Expected ErrEmptyBlackBox, got %v", i, err)
+				}
+				break
+			}
+			next := 0
+			if lifo {
+				next = len(model) - 1
+			}
+			if got != model[next] {
+				t.Fatalf("op %d: Expected %d, got %d", i, model[next], got)
+			}
+			if int(op)%fuzzOps == opGet {
+				model = append(model[:next], model[next+1:]...)
+			}
+		case opClean:
+			box.Clean()
+			model = model[:0]
+		case opPutFront:
+			if d, ok := box.(*dequeBox[int]); ok {
+				if err := d.PutFront(item); err == nil {
+					model = append([]int{item}, model...)
+				}
+			}
+		case opGetBack:
+			if d, ok := box.(*dequeBox[int]); ok {
+				if got, err := d.GetBack(); err == nil {
+					if got != model[len(model)-1] {
+						t.Fatalf("op %d: Expected %d from the back, got %d", i, model[len(model)-1], got)
+					}
+					model = model[:len(model)-1]
+				}
+			}
+		}
+		if err := checkInvariants(box); err != nil {
+			t.Fatalf("op %d: %v", i, err)
+		}
+		if box.Size() != len(model) || !EqualInts(box.Items(), model) {
+			t.Fatalf("op %d: Expected %v, got %v", i, model, box.Items())
+		}
+	}
+}
+
+func addFuzzSeeds(f *testing.F) {
+	f.Add(uint8(0), uint8(0), []byte{0, 0, 0, 1, 0, 0, 1, 1, 2, 3})
+	f.Add(uint8(3), uint8(2), []byte{0, 0, 1, 0, 0, 0, 0, 1, 1, 1})
+	f.Add(uint8(8), uint8(4), []byte{0, 0, 0, 1, 1, 0, 0, 0, 0, 0, 0, 4, 5, 4, 2})
+}
+
+func FuzzFIFO(f *testing.F) {
+	addFuzzSeeds(f)
+	f.Fuzz(func(t *testing.T, maxSize, capacity uint8, ops []byte) {
+		box := NewFIFO[int](int(maxSize%16), int(capacity%16))
+		fuzzModel(t, box, int(maxSize%16), false, false, ops)
+	})
+}
+
+func FuzzRingBuffer(f *testing.F) {
+	addFuzzSeeds(f)
+	f.Fuzz(func(t *testing.T, size, _ uint8, ops []byte) {
+		n := int(size%16) + 1
+		fuzzModel(t, NewRingBuffer[int](n), n, true, false, ops)
+	})
+}
+
+func FuzzDeque(f *testing.F) {
+	addFuzzSeeds(f)
+	f.Fuzz(func(t *testing.T, maxSize, capacity uint8, ops []byte) {
+		box := NewDeque[int](int(maxSize%16), int(capacity%16))
+		fuzzModel(t, box, int(maxSize%16), false, false, ops)
+	})
+}
+
+func FuzzLIFO(f *testing.F) {
+	addFuzzSeeds(f)
+	f.Fuzz(func(t *testing.T, maxSize, capacity uint8, ops []byte) {
+		box := NewLIFO[int](int(maxSize%16), int(capacity%16))
+		fuzzModel(t, box, int(maxSize%16), false, true, ops)
+	})
+}
+
+func FuzzRandom(f *testing.F) {
+	addFuzzSeeds(f)
+	f.Fuzz(func(t *testing.T, maxSize, capacity uint8, ops []byte) {
+		box := NewRandom[int](int(maxSize%16), int(capacity%16), rand.New(rand.NewSource(int64(len(ops)))))
+		size := 0
+		for i, op := range ops {
+			switch int(op) % 4 {
+			case 0:
+				if box.Put(i+1) == nil {
+					size++
+				}
+			case 1:
+				if _, err := box.Get(); err == nil {
+					size--
+				}
+			case 2:
+				box.PeekN(int(op) % 4)
+			case 3:
+				box.Clean()
+				size = 0
+			}
+			if err := checkInvariants[int](box); err != nil {
+				t.Fatalf("op %d: %v", i, err)
+			}
+			if box.Size() != size {
+				t.Fatalf("op %d: Expected %d items, got %d", i, size, box.Size())
+			}
+		}
+	})
+}
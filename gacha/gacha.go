@@ -0,0 +1,167 @@
+// Package gacha implements gacha draws on top of blackbox Random boxes:
+// rarity tiers with drop rates, a pity counter guaranteeing a drop of the
+// rarest tier within a number of draws, and rate-up banners featuring some
+// items of a tier.
+//
+// Draws are made with replacement: the tiers and their items are weighted
+// blackbox Random boxes that are only peeked, so they never run out.
+package gacha
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/raditzlawliet/blackbox"
+)
+
+var ErrInvalidBanner = errors.New("gacha banner is invalid")
+
+// Tier is a rarity tier of a banner.
+type Tier[T any] struct {
+	Name string
+	// Rate is the probability weight of the tier, e.g. 0.006 for a 0.6% drop
+	// rate. Rates are relative to the sum of the rates of all tiers.
+	Rate float64
+	// Items are drawn uniformly within the tier.
+	Items []T
+	// RateUp are the featured items of the tier; a draw of the tier yields one
+	// of them with probability RateUpShare, e.g. 0.5 for a 50/50 banner.
+	RateUp      []T
+	RateUpShare float64
+}
+
+// BannerConfig configures the banner returned by NewBanner.
+type BannerConfig[T any] struct {
+	// Tiers are listed from the most common to the rarest.
+	Tiers []Tier[T]
+	// Pity guarantees a drop of the rarest tier within that many draws.
+	// Zero disables pity.
+	Pity int
+	// Rand is used for the draws. When nil, a time-seeded RNG is used.
+	Rand *rand.Rand
+}
+
+// Result is the outcome of a draw.
+type Result[T any] struct {
+	Item T
+	Tier string
+	// Pity reports whether the rarest tier was forced by the pity counter.
+	Pity bool
+	// RateUp reports whether Item is a featured item of its tier.
+	RateUp bool
+}
+
+type tierBox[T any] struct {
+	tier Tier[T]
+	// items holds the indexes of the items, featured items first.
+	items blackbox.Weighted[int]
+}
+
+// Banner draws items from rarity tiers. It is not goroutine-safe; use one
+// banner per player, or a lock, since the pity counter is per player anyway.
+type Banner[T any] struct {
+	tiers []tierBox[T]
+	// rarity draws the index of a tier.
+	rarity blackbox.Weighted[int]
+	pity   int
+	// sincePity is the number of draws since the last drop of the rarest tier.
+	sincePity int
+}
+
+// NewBanner creates a banner from cfg. It returns an error wrapping
+// ErrInvalidBanner when a tier has no items, a rate is not a positive finite
+// number, a rate-up share is outside [0, 1] or pity is negative.
+func NewBanner[T any](cfg BannerConfig[T]) (*Banner[T], error) {
+	if len(cfg.Tiers) == 0 {
+		return nil, fmt.Errorf("%w: no tiers", ErrInvalidBanner)
+	}
+	if cfg.Pity < 0 {
+		return nil, fmt.Errorf("%w: negative pity %d", ErrInvalidBanner, cfg.Pity)
+	}
+	rng := cfg.Rand
+	if rng == nil {
+		rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	b := &Banner[T]{
+		rarity: blackbox.NewRandom[int](0, len(cfg.Tiers), rng),
+		pity:   cfg.Pity,
+	}
+	for i, tier := range cfg.Tiers {
+		if !(tier.Rate > 0) || math.IsInf(tier.Rate, 1) {
+			return nil, fmt.Errorf("%w: tier %q has rate %v", ErrInvalidBanner, tier.Name, tier.Rate)
+		}
+		if len(tier.Items)+len(tier.RateUp) == 0 {
+			return nil, fmt.Errorf("%w: tier %q has no items", ErrInvalidBanner, tier.Name)
+		}
+		if !(tier.RateUpShare >= 0 && tier.RateUpShare <= 1) {
+			return nil, fmt.Errorf("%w: tier %q has rate-up share %v", ErrInvalidBanner, tier.Name, tier.RateUpShare)
+		}
+		b.rarity.PutWeighted(i, tier.Rate)
+
+		share := tier.RateUpShare
+		switch {
+		case len(tier.RateUp) == 0:
+			share = 0
+		case len(tier.Items) == 0:
+			share = 1
+		}
+		items := blackbox.NewRandom[int](0, len(tier.RateUp)+len(tier.Items), rng)
+		for j := range tier.RateUp {
+			items.PutWeighted(j, share/float64(len(tier.RateUp)))
+		}
+		for j := range tier.Items {
+			items.PutWeighted(len(tier.RateUp)+j, (1-share)/float64(len(tier.Items)))
+		}
+		b.tiers = append(b.tiers, tierBox[T]{tier: tier, items: items})
+	}
+	return b, nil
+}
+
+// Draw draws one item. The rarest tier is forced when the previous Pity-1
+// draws all missed it.
+func (b *Banner[T]) Draw() Result[T] {
+	rarest := len(b.tiers) - 1
+	var r Result[T]
+	idx, _ := b.rarity.Peek()
+	if b.pity > 0 && b.sincePity >= b.pity-1 && idx != rarest {
+		idx, r.Pity = rarest, true
+	}
+	if idx == rarest {
+		b.sincePity = 0
+	} else {
+		b.sincePity++
+	}
+
+	t := b.tiers[idx]
+	j, _ := t.items.Peek()
+	r.Tier = t.tier.Name
+	if j < len(t.tier.RateUp) {
+		r.Item, r.RateUp = t.tier.RateUp[j], true
+	} else {
+		r.Item = t.tier.Items[j-len(t.tier.RateUp)]
+	}
+	return r
+}
+
+// DrawN draws n items, e.g. a 10-pull.
+func (b *Banner[T]) DrawN(n int) []Result[T] {
+	results := make([]Result[T], n)
+	for i := range results {
+		results[i] = b.Draw()
+	}
+	return results
+}
+
+// PityCount returns the number of draws since the last drop of the rarest
+// tier, e.g. to show "guaranteed in 12 draws" or to save it with the player.
+func (b *Banner[T]) PityCount() int {
+	return b.sincePity
+}
+
+// SetPityCount restores the pity counter saved for a player.
+func (b *Banner[T]) SetPityCount(n int) {
+	b.sincePity = n
+}
@@ -0,0 +1,81 @@
+package gacha
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func testBanner(t *testing.T, pity int) *Banner[string] {
+	t.Helper()
+	b, err := NewBanner(BannerConfig[string]{
+		Tiers: []Tier[string]{
+			{Name: "common", Rate: 0.9, Items: []string{"sword", "shield"}},
+			{Name: "rare", Rate: 0.09, Items: []string{"bow"}},
+			{Name: "legendary", Rate: 0.01, Items: []string{"dragon"}, RateUp: []string{"phoenix"}, RateUpShare: 0.5},
+		},
+		Pity: pity,
+		Rand: rand.New(rand.NewSource(1)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestBannerRates(t *testing.T) {
+	b := testBanner(t, 0)
+	counts := map[string]int{}
+	rateUp := 0
+	const n = 100000
+	for _, r := range b.DrawN(n) {
+		counts[r.Tier]++
+		if r.RateUp {
+			rateUp++
+			if r.Item != "phoenix" {
+				t.Fatalf("Expected phoenix to be the rate-up item, got %s", r.Item)
+			}
+		}
+	}
+	if c := counts["common"]; c < 0.89*n || c > 0.91*n {
+		t.Errorf("Expected about 90%% commons, got %d", c)
+	}
+	if c := counts["legendary"]; c < 0.007*n || c > 0.013*n {
+		t.Errorf("Expected about 1%% legendaries, got %d", c)
+	}
+	if share := float64(rateUp) / float64(counts["legendary"]); share < 0.4 || share > 0.6 {
+		t.Errorf("Expected about half of the legendaries to be rate-up, got %v", share)
+	}
+}
+
+func TestBannerPity(t *testing.T) {
+	b := testBanner(t, 10)
+	for i := 0; i < 1000; i++ {
+		r := b.Draw()
+		if b.PityCount() >= 10 {
+			t.Fatalf("Expected a legendary within 10 draws, got %d draws without", b.PityCount())
+		}
+		if r.Pity && r.Tier != "legendary" {
+			t.Fatalf("Expected pity to force a legendary, got %s", r.Tier)
+		}
+	}
+
+	b.SetPityCount(9)
+	if r := b.Draw(); r.Tier != "legendary" || b.PityCount() != 0 {
+		t.Errorf("Expected the restored counter to force a legendary, got %+v", r)
+	}
+}
+
+func TestNewBannerInvalid(t *testing.T) {
+	for name, cfg := range map[string]BannerConfig[int]{
+		"no tiers":      {},
+		"no items":      {Tiers: []Tier[int]{{Name: "a", Rate: 1}}},
+		"zero rate":     {Tiers: []Tier[int]{{Name: "a", Items: []int{1}}}},
+		"bad share":     {Tiers: []Tier[int]{{Name: "a", Rate: 1, Items: []int{1}, RateUpShare: 2}}},
+		"negative pity": {Tiers: []Tier[int]{{Name: "a", Rate: 1, Items: []int{1}}}, Pity: -1},
+	} {
+		if _, err := NewBanner(cfg); !errors.Is(err, ErrInvalidBanner) {
+			t.Errorf("%s: Expected ErrInvalidBanner, got %v", name, err)
+		}
+	}
+}
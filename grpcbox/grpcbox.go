@@ -0,0 +1,253 @@
+// Package grpcbox serves a blackbox over the network with gRPC, so that small
+// sidecar services can share one queue with the same Put/Get/Peek API.
+//
+// Like redisbox, the package has no dependencies. blackbox.proto defines the
+// service; Server implements its methods on encoded items and NewRemote
+// creates a BlackBox[T] calling them through the Service interface, so the
+// code generated by protoc-gen-go-grpc only needs thin adapters that convert
+// messages and errors. For example, on the server:
+//
+//	type server struct {
+//		blackboxpb.UnimplementedBlackBoxServer
+//		s *grpcbox.Server[Task]
+//	}
+//
+//	func (s server) Get(ctx context.Context, _ *blackboxpb.GetRequest) (*blackboxpb.Item, error) {
+//		data, err := s.s.Get(ctx)
+//		if errors.Is(err, blackbox.ErrEmptyBlackBox) {
+//			return nil, status.Error(codes.NotFound, err.Error())
+//		}
+//		return &blackboxpb.Item{Data: data}, err
+//	}
+//
+// The client adapter maps NOT_FOUND back to blackbox.ErrEmptyBlackBox and
+// RESOURCE_EXHAUSTED to blackbox.ErrBlackBoxFull.
+package grpcbox
+
+import (
+	"context"
+	"errors"
+
+	"github.com/raditzlawliet/blackbox"
+)
+
+// ErrReceiveUnsupported is returned by Server.Receive when the served box
+// cannot wait for items, see NewServer.
+var ErrReceiveUnsupported = errors.New("grpcbox: box cannot wait for items")
+
+// Service is the BlackBox gRPC service on encoded items. Server implements
+// it, and so does the client adapter around the generated client.
+type Service interface {
+	Put(ctx context.Context, data []byte) error
+	Get(ctx context.Context) ([]byte, error)
+	Peek(ctx context.Context) ([]byte, error)
+	Size(ctx context.Context) (size, maxSize int, err error)
+	Clean(ctx context.Context) error
+	Items(ctx context.Context) ([][]byte, error)
+	// Receive calls fn with every item taken out of the box until ctx is
+	// done or fn returns an error.
+	Receive(ctx context.Context, fn func(data []byte) error) error
+}
+
+// ctxGetter is implemented by boxes whose Get can wait for an item, such as
+// the boxes of blackbox.NewConcurrent.
+type ctxGetter[T any] interface {
+	GetCtx(ctx context.Context) (T, error)
+}
+
+// Server serves a box through the Service methods.
+type Server[T any] struct {
+	box   blackbox.BlackBox[T]
+	codec blackbox.ItemCodec[T]
+}
+
+// NewServer serves box, encoding its items with codec. The methods are called
+// from the goroutines of the gRPC server, so box must be goroutine-safe, e.g.
+// from blackbox.NewConcurrent, which also lets Receive wait for items.
+func NewServer[T any](box blackbox.BlackBox[T], codec blackbox.ItemCodec[T]) *Server[T] {
+	return &Server[T]{box: box, codec: codec}
+}
+
+func (s *Server[T]) Put(ctx context.Context, data []byte) error {
+	item, err := s.codec.Decode(data)
+	if err != nil {
+		return err
+	}
+	return s.box.Put(item)
+}
+
+func (s *Server[T]) Get(ctx context.Context) ([]byte, error) {
+	item, err := s.box.Get()
+	if err != nil {
+		return nil, err
+	}
+	return s.codec.Encode(item)
+}
+
+func (s *Server[T]) Peek(ctx context.Context) ([]byte, error) {
+	item, err := s.box.Peek()
+	if err != nil {
+		return nil, err
+	}
+	return s.codec.Encode(item)
+}
+
+func (s *Server[T]) Size(ctx context.Context) (size, maxSize int, err error) {
+	return s.box.Size(), s.box.MaxSize(), nil
+}
+
+func (s *Server[T]) Clean(ctx context.Context) error {
+	s.box.Clean()
+	return nil
+}
+
+func (s *Server[T]) Items(ctx context.Context) ([][]byte, error) {
+	items := s.box.Items()
+	encoded := make([][]byte, len(items))
+	for i, item := range items {
+		data, err := s.codec.Encode(item)
+		if err != nil {
+			return nil, err
+		}
+		encoded[i] = data
+	}
+	return encoded, nil
+}
+
+// Receive takes items out of the box as they arrive and passes them to fn,
+// until ctx is done or fn fails. It returns ErrReceiveUnsupported unless the
+// box has a GetCtx method.
+func (s *Server[T]) Receive(ctx context.Context, fn func(data []byte) error) error {
+	g, ok := s.box.(ctxGetter[T])
+	if !ok {
+		return ErrReceiveUnsupported
+	}
+	for {
+		item, err := g.GetCtx(ctx)
+		if err != nil {
+			return err
+		}
+		data, err := s.codec.Encode(item)
+		if err != nil {
+			return err
+		}
+		if err := fn(data); err != nil {
+			return err
+		}
+	}
+}
+
+// remoteBox is a blackbox served by a Server.
+type remoteBox[T any] struct {
+	service Service
+	codec   blackbox.ItemCodec[T]
+	err     error
+}
+
+// NewRemote creates a blackbox calling service, usually the adapter around
+// the generated gRPC client, and decoding the items with codec. Methods that
+// cannot return an error record it for Err.
+// Returns a concrete instance of remote blackbox without interface.
+func NewRemote[T any](service Service, codec blackbox.ItemCodec[T]) *remoteBox[T] {
+	return &remoteBox[T]{service: service, codec: codec}
+}
+
+// Err returns the last error hit by a method that cannot return one (Size,
+// MaxSize, IsFull, IsEmpty, Clean and Items).
+func (b *remoteBox[T]) Err() error {
+	return b.err
+}
+
+func (b *remoteBox[T]) decode(data []byte, err error) (T, error) {
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return b.codec.Decode(data)
+}
+
+func (b *remoteBox[T]) Put(item T) error {
+	data, err := b.codec.Encode(item)
+	if err != nil {
+		return err
+	}
+	return b.service.Put(context.Background(), data)
+}
+
+func (b *remoteBox[T]) Get() (T, error) {
+	return b.decode(b.service.Get(context.Background()))
+}
+
+func (b *remoteBox[T]) Peek() (T, error) {
+	return b.decode(b.service.Peek(context.Background()))
+}
+
+// Receive calls fn with every item taken out of the remote box until ctx is
+// done or fn returns an error, which is then returned.
+func (b *remoteBox[T]) Receive(ctx context.Context, fn func(item T) error) error {
+	return b.service.Receive(ctx, func(data []byte) error {
+		item, err := b.codec.Decode(data)
+		if err != nil {
+			return err
+		}
+		return fn(item)
+	})
+}
+
+func (b *remoteBox[T]) sizes() (size, maxSize int) {
+	size, maxSize, err := b.service.Size(context.Background())
+	if err != nil {
+		b.err = err
+	}
+	return size, maxSize
+}
+
+func (b *remoteBox[T]) Size() int {
+	size, _ := b.sizes()
+	return size
+}
+
+func (b *remoteBox[T]) MaxSize() int {
+	_, maxSize := b.sizes()
+	return maxSize
+}
+
+func (b *remoteBox[T]) IsFull() bool {
+	size, maxSize := b.sizes()
+	return maxSize > 0 && size >= maxSize
+}
+
+func (b *remoteBox[T]) IsEmpty() bool {
+	return b.Size() == 0
+}
+
+func (b *remoteBox[T]) Clean() {
+	if err := b.service.Clean(context.Background()); err != nil {
+		b.err = err
+	}
+}
+
+func (b *remoteBox[T]) Items() []T {
+	encoded, err := b.service.Items(context.Background())
+	if err != nil {
+		b.err = err
+		return nil
+	}
+	items := make([]T, 0, len(encoded))
+	for _, data := range encoded {
+		item, err := b.codec.Decode(data)
+		if err != nil {
+			b.err = err
+			continue
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// Compile-time assertions that Server implements Service and remoteBox
+// implements BlackBox[T].
+var (
+	_ Service                = (*Server[any])(nil)
+	_ blackbox.BlackBox[any] = (*remoteBox[any])(nil)
+)
@@ -0,0 +1,74 @@
+package grpcbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/raditzlawliet/blackbox"
+)
+
+func TestRemote(t *testing.T) {
+	codec := blackbox.DefaultItemCodec[string]()
+	served := blackbox.NewConcurrent[string](blackbox.New[string](
+		blackbox.WithStrategy(blackbox.StrategyFIFO), blackbox.WithMaxSize(2)))
+	// The server is called directly instead of through a gRPC connection.
+	box := NewRemote[string](NewServer[string](served, codec), codec)
+
+	if _, err := box.Get(); !errors.Is(err, blackbox.ErrEmptyBlackBox) {
+		t.Errorf("Expected ErrEmptyBlackBox, got %v", err)
+	}
+	box.Put("a")
+	box.Put("b")
+	if err := box.Put("c"); !errors.Is(err, blackbox.ErrBlackBoxFull) {
+		t.Errorf("Expected ErrBlackBoxFull, got %v", err)
+	}
+	if box.Size() != 2 || box.MaxSize() != 2 || !box.IsFull() {
+		t.Errorf("Expected a full box of 2 items, got %d of %d", box.Size(), box.MaxSize())
+	}
+	if items := box.Items(); len(items) != 2 || items[0] != "a" {
+		t.Errorf("Expected [a b], got %v", items)
+	}
+	if item, _ := box.Peek(); item != "a" {
+		t.Errorf("Expected a, got %q", item)
+	}
+	if item, _ := box.Get(); item != "a" {
+		t.Errorf("Expected a, got %q", item)
+	}
+	box.Clean()
+	if !box.IsEmpty() || box.Err() != nil {
+		t.Errorf("Expected an empty box, got %v (%v)", served.Items(), box.Err())
+	}
+}
+
+func TestReceive(t *testing.T) {
+	codec := blackbox.DefaultItemCodec[int]()
+	served := blackbox.NewConcurrent[int](blackbox.NewFIFO[int](0, 0))
+	box := NewRemote[int](NewServer[int](served, codec), codec)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	go func() {
+		for i := 1; i <= 3; i++ {
+			served.Put(i)
+		}
+	}()
+	var got []int
+	stop := errors.New("stop")
+	err := box.Receive(ctx, func(item int) error {
+		got = append(got, item)
+		if len(got) == 3 {
+			return stop
+		}
+		return nil
+	})
+	if err != stop || len(got) != 3 || got[0] != 1 || got[2] != 3 {
+		t.Errorf("Expected to receive [1 2 3], got %v (%v)", got, err)
+	}
+
+	unsupported := NewServer[int](blackbox.NewFIFO[int](0, 0), codec)
+	if err := unsupported.Receive(ctx, nil); err != ErrReceiveUnsupported {
+		t.Errorf("Expected ErrReceiveUnsupported, got %v", err)
+	}
+}
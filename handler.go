@@ -0,0 +1,137 @@
+package blackbox
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Named is a box registered with Handler under a name. Create it with Name.
+type Named struct {
+	name     string
+	strategy func() string
+	size     func() int
+	maxSize  func() int
+	peek     func(n int) any
+	drain    func() any
+}
+
+// Name registers box under name for Handler.
+func Name[T any](name string, box BlackBox[T]) Named {
+	return Named{
+		name: name,
+		strategy: func() string {
+			if e, ok := box.(enveloper[T]); ok {
+				if env, err := e.envelope(); err == nil {
+					return env.Strategy
+				}
+			}
+			return "unknown"
+		},
+		size:    box.Size,
+		maxSize: box.MaxSize,
+		peek:    func(n int) any { return PeekN(box, n) },
+		drain:   func() any { return Drain(box) },
+	}
+}
+
+// BoxInfo describes a box in the responses of Handler.
+type BoxInfo struct {
+	Name     string `json:"name"`
+	Strategy string `json:"strategy"`
+	Size     int    `json:"size"`
+	MaxSize  int    `json:"maxSize"`
+}
+
+func (n Named) info() BoxInfo {
+	return BoxInfo{Name: n.name, Strategy: n.strategy(), Size: n.size(), MaxSize: n.maxSize()}
+}
+
+// Handler returns an http.Handler for quick operational inspection of boxes
+// without writing custom endpoints:
+//
+//	GET  /             lists the boxes as BoxInfo
+//	GET  /{name}       describes one box
+//	GET  /{name}/peek  returns the next items without removing them, see PeekN
+//	                   (?n=10, 1 by default)
+//	POST /{name}/drain removes and returns all items, see Drain
+//
+// Responses are JSON. The handler calls the boxes from the goroutines of the
+// HTTP server, so use goroutine-safe boxes, e.g. from NewConcurrent. Mount it
+// under a prefix with http.StripPrefix and protect it like any admin endpoint.
+func Handler(boxes ...Named) http.Handler {
+	byName := make(map[string]Named, len(boxes))
+	for _, b := range boxes {
+		byName[b.name] = b
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.Trim(r.URL.Path, "/")
+		if path == "" {
+			if !allowMethod(w, r, http.MethodGet) {
+				return
+			}
+			infos := make([]BoxInfo, len(boxes))
+			for i, b := range boxes {
+				infos[i] = b.info()
+			}
+			writeJSON(w, http.StatusOK, infos)
+			return
+		}
+
+		name, action := path, ""
+		if i := strings.LastIndex(path, "/"); i >= 0 {
+			name, action = path[:i], path[i+1:]
+		}
+		b, ok := byName[name]
+		if !ok {
+			// The name itself may contain a slash.
+			if b, ok = byName[path]; !ok {
+				writeJSON(w, http.StatusNotFound, map[string]string{"error": "unknown box"})
+				return
+			}
+			action = ""
+		}
+		switch action {
+		case "":
+			if allowMethod(w, r, http.MethodGet) {
+				writeJSON(w, http.StatusOK, b.info())
+			}
+		case "peek":
+			if !allowMethod(w, r, http.MethodGet) {
+				return
+			}
+			n := 1
+			if s := r.URL.Query().Get("n"); s != "" {
+				var err error
+				if n, err = strconv.Atoi(s); err != nil || n < 0 {
+					writeJSON(w, http.StatusBadRequest, map[string]string{"error": "n must be a non-negative integer"})
+					return
+				}
+			}
+			writeJSON(w, http.StatusOK, b.peek(n))
+		case "drain":
+			if allowMethod(w, r, http.MethodPost) {
+				writeJSON(w, http.StatusOK, b.drain())
+			}
+		default:
+			writeJSON(w, http.StatusNotFound, map[string]string{"error": "unknown action"})
+		}
+	})
+}
+
+// allowMethod reports whether r uses method, and replies with 405 otherwise.
+func allowMethod(w http.ResponseWriter, r *http.Request, method string) bool {
+	if r.Method == method {
+		return true
+	}
+	w.Header().Set("Allow", method)
+	writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+	return false
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,61 @@
+package blackbox
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler(t *testing.T) {
+	tasks := NewConcurrent[int](New[int](WithStrategy(StrategyFIFO), WithMaxSize(10)))
+	for i := 1; i <= 3; i++ {
+		tasks.Put(i)
+	}
+	names := NewConcurrent[string](NewLIFO[string](0, 0))
+	h := Handler(Name[int]("tasks", tasks), Name[string]("names", names))
+
+	do := func(method, target string, v any) int {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(method, target, nil))
+		if v != nil {
+			if err := json.Unmarshal(rec.Body.Bytes(), v); err != nil {
+				t.Fatalf("%s %s: %v", method, target, err)
+			}
+		}
+		return rec.Code
+	}
+
+	var infos []BoxInfo
+	if code := do(http.MethodGet, "/", &infos); code != http.StatusOK || len(infos) != 2 {
+		t.Fatalf("Expected 2 boxes, got %d %v", code, infos)
+	}
+	if infos[0] != (BoxInfo{Name: "tasks", Strategy: "fifo", Size: 3, MaxSize: 10}) {
+		t.Errorf("Unexpected info %+v", infos[0])
+	}
+	var info BoxInfo
+	if do(http.MethodGet, "/names", &info); info.Strategy != "lifo" {
+		t.Errorf("Unexpected info %+v", info)
+	}
+
+	var items []int
+	if do(http.MethodGet, "/tasks/peek?n=2", &items); !EqualInts(items, []int{1, 2}) {
+		t.Errorf("Expected [1 2], got %v", items)
+	}
+	if code := do(http.MethodGet, "/tasks/drain", nil); code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected 405, got %d", code)
+	}
+	if do(http.MethodPost, "/tasks/drain", &items); !EqualInts(items, []int{1, 2, 3}) || !tasks.IsEmpty() {
+		t.Errorf("Expected [1 2 3] to be drained, got %v", items)
+	}
+
+	for target, want := range map[string]int{
+		"/missing":         http.StatusNotFound,
+		"/tasks/unknown":   http.StatusNotFound,
+		"/tasks/peek?n=-1": http.StatusBadRequest,
+	} {
+		if code := do(http.MethodGet, target, nil); code != want {
+			t.Errorf("%s: Expected %d, got %d", target, want, code)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package blackbox
+
+// Hooks are callbacks invoked by a box created with WithHooks or NewHooks,
+// e.g. for metrics, audit logs or cache invalidation. Nil hooks are skipped.
+type Hooks[T any] struct {
+	// OnPut is called after an item was put successfully.
+	OnPut func(item T)
+	// OnGet is called after an item was removed successfully by Get.
+	OnGet func(item T)
+	// OnError is called when Put, Get or Peek returns an error, including
+	// ErrBlackBoxFull and ErrEmptyBlackBox. op is the name of the method.
+	OnError func(op string, err error)
+}
+
+// hookBox calls Hooks around the operations of any BlackBox[T].
+type hookBox[T any] struct {
+	box   BlackBox[T]
+	hooks Hooks[T]
+}
+
+// NewHooks wraps box so that hooks are called around its operations.
+// Returns a concrete instance of hooked blackbox.
+func NewHooks[T any](box BlackBox[T], hooks Hooks[T]) *hookBox[T] {
+	return &hookBox[T]{box: box, hooks: hooks}
+}
+
+// WithHooks attaches hooks to the box, see NewHooks.
+func WithHooks[T any](hooks Hooks[T]) Option {
+	return func(c *config) {
+		c.decorators = append(c.decorators, func(box BlackBox[T]) BlackBox[T] {
+			return NewHooks(box, hooks)
+		})
+	}
+}
+
+func (h *hookBox[T]) fail(op string, err error) {
+	if h.hooks.OnError != nil {
+		h.hooks.OnError(op, err)
+	}
+}
+
+func (h *hookBox[T]) Put(item T) error {
+	if err := h.box.Put(item); err != nil {
+		h.fail("Put", err)
+		return err
+	}
+	if h.hooks.OnPut != nil {
+		h.hooks.OnPut(item)
+	}
+	return nil
+}
+
+func (h *hookBox[T]) Get() (T, error) {
+	item, err := h.box.Get()
+	if err != nil {
+		h.fail("Get", err)
+		return item, err
+	}
+	if h.hooks.OnGet != nil {
+		h.hooks.OnGet(item)
+	}
+	return item, nil
+}
+
+func (h *hookBox[T]) Peek() (T, error) {
+	item, err := h.box.Peek()
+	if err != nil {
+		h.fail("Peek", err)
+	}
+	return item, err
+}
+
+func (h *hookBox[T]) Size() int {
+	return h.box.Size()
+}
+
+func (h *hookBox[T]) MaxSize() int {
+	return h.box.MaxSize()
+}
+
+func (h *hookBox[T]) IsFull() bool {
+	return h.box.IsFull()
+}
+
+func (h *hookBox[T]) IsEmpty() bool {
+	return h.box.IsEmpty()
+}
+
+func (h *hookBox[T]) Clean() {
+	h.box.Clean()
+}
+
+func (h *hookBox[T]) Items() []T {
+	return h.box.Items()
+}
+
+func (h *hookBox[T]) mutatesOnRead() bool {
+	return mutatesOnRead(h.box)
+}
+
+func (h *hookBox[T]) mutatesOnPeek() bool {
+	return mutatesOnPeek(h.box)
+}
+
+func (h *hookBox[T]) drawPlan() drawPlan[T] {
+	return planOf(h.box)
+}
+
+// Compile-time assertion that hookBox implements BlackBox[T].
+var _ BlackBox[any] = (*hookBox[any])(nil)
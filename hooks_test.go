@@ -0,0 +1,43 @@
+package blackbox
+
+import "testing"
+
+func TestHooks(t *testing.T) {
+	var puts, gets []int
+	var errs []string
+	box := New[int](
+		WithStrategy(StrategyFIFO),
+		WithMaxSize(1),
+		WithHooks(Hooks[int]{
+			OnPut:   func(item int) { puts = append(puts, item) },
+			OnGet:   func(item int) { gets = append(gets, item) },
+			OnError: func(op string, err error) { errs = append(errs, op+": "+err.Error()) },
+		}),
+	)
+
+	box.Put(1)
+	box.Put(2)
+	box.Get()
+	box.Get()
+	box.Peek()
+
+	if !EqualInts(puts, []int{1}) || !EqualInts(gets, []int{1}) {
+		t.Errorf("Expected put and get hooks for 1, got %v and %v", puts, gets)
+	}
+	want := []string{"Put: " + ErrBlackBoxFull.Error(), "Get: " + ErrEmptyBlackBox.Error(), "Peek: " + ErrEmptyBlackBox.Error()}
+	if len(errs) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, errs)
+	}
+	for i := range want {
+		if errs[i] != want[i] {
+			t.Errorf("Expected %s, got %s", want[i], errs[i])
+		}
+	}
+
+	// Nil hooks are skipped.
+	plain := NewHooks[int](NewLIFO[int](0, 1), Hooks[int]{})
+	plain.Put(1)
+	if item, _ := plain.Get(); item != 1 {
+		t.Errorf("Expected 1, got %d", item)
+	}
+}
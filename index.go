@@ -0,0 +1,186 @@
+package blackbox
+
+import "errors"
+
+// ErrIndexOutOfRange is returned by PeekAt and GetAt for an index outside [0, Size()).
+var ErrIndexOutOfRange = errors.New("blackbox index out of range")
+
+// Indexer is implemented by blackboxes that give access to an item by its
+// position in retrieval order, where 0 is the item the next Get returns, e.g.
+// to pull a specific customer out of line or cancel the 3rd pending task.
+// Removed items are not passed to the evict callback.
+type Indexer[T any] interface {
+	// PeekAt returns the i-th item without removing it.
+	PeekAt(i int) (T, error)
+	// GetAt removes and returns the i-th item; the order of the other items
+	// is kept.
+	GetAt(i int) (T, error)
+}
+
+// removeAt removes the i-th item visited by the removeFunc of r.
+func removeAt[T any](r itemRemover[T], i int) T {
+	n := 0
+	removed := r.removeFunc(func(T) bool {
+		n++
+		return n == i+1
+	})
+	return removed[0]
+}
+
+func (b *ring[T]) PeekAt(i int) (T, error) {
+	if i < 0 || i >= b.size {
+		var zero T
+		return zero, ErrIndexOutOfRange
+	}
+	return b.items[(b.head+i)%len(b.items)], nil
+}
+
+func (b *ring[T]) GetAt(i int) (T, error) {
+	if i < 0 || i >= b.size {
+		var zero T
+		return zero, ErrIndexOutOfRange
+	}
+	return removeAt[T](b, i), nil
+}
+
+func (b *lifoBox[T]) PeekAt(i int) (T, error) {
+	if i < 0 || i >= len(b.items) {
+		var zero T
+		return zero, ErrIndexOutOfRange
+	}
+	return b.items[len(b.items)-1-i], nil
+}
+
+func (b *lifoBox[T]) GetAt(i int) (T, error) {
+	if i < 0 || i >= len(b.items) {
+		var zero T
+		return zero, ErrIndexOutOfRange
+	}
+	return removeAt[T](b, len(b.items)-1-i), nil
+}
+
+// PeekAt draws the next i+1 items like PeekN, so that GetAt and Get agree
+// with it.
+func (b *randomBox[T]) PeekAt(i int) (T, error) {
+	if i < 0 || i >= len(b.items) {
+		var zero T
+		return zero, ErrIndexOutOfRange
+	}
+	b.PeekN(i + 1)
+	return b.items[len(b.items)-1-i], nil
+}
+
+// GetAt draws the next i+1 items like PeekN and removes the last of them.
+func (b *randomBox[T]) GetAt(i int) (T, error) {
+	if i < 0 || i >= len(b.items) {
+		var zero T
+		return zero, ErrIndexOutOfRange
+	}
+	b.PeekN(i + 1)
+	return removeAt[T](b, len(b.items)-1-i), nil
+}
+
+func (b *sortedBox[T]) PeekAt(i int) (T, error) {
+	if i < 0 || i >= b.size {
+		var zero T
+		return zero, ErrIndexOutOfRange
+	}
+	x := b.head.next[0]
+	for ; i > 0; i-- {
+		x = x.next[0]
+	}
+	return x.item, nil
+}
+
+func (b *sortedBox[T]) GetAt(i int) (T, error) {
+	if i < 0 || i >= b.size {
+		var zero T
+		return zero, ErrIndexOutOfRange
+	}
+	return removeAt[T](b, i), nil
+}
+
+// PeekAt counts unexpired items only. The wrapped box must implement
+// Indexer, otherwise ErrIndexOutOfRange is returned.
+func (t *timedBox[T]) PeekAt(i int) (T, error) {
+	t.purge()
+	x, ok := t.box.(Indexer[timedItem[T]])
+	if !ok {
+		var zero T
+		return zero, ErrIndexOutOfRange
+	}
+	it, err := x.PeekAt(i)
+	return it.value, err
+}
+
+// GetAt counts unexpired items only. The wrapped box must implement
+// Indexer, otherwise ErrIndexOutOfRange is returned.
+func (t *timedBox[T]) GetAt(i int) (T, error) {
+	t.purge()
+	x, ok := t.box.(Indexer[timedItem[T]])
+	if !ok {
+		var zero T
+		return zero, ErrIndexOutOfRange
+	}
+	it, err := x.GetAt(i)
+	return it.value, err
+}
+
+// PeekAt takes the write lock, as a Random box draws the next items.
+// The wrapped box must implement Indexer[T], otherwise ErrIndexOutOfRange is
+// returned.
+func (c *concurrentBox[T]) PeekAt(i int) (T, error) {
+	x, ok := c.box.(Indexer[T])
+	if !ok {
+		var zero T
+		return zero, ErrIndexOutOfRange
+	}
+	c.mu.Lock()
+	item, err := x.PeekAt(i)
+	c.refresh()
+	c.mu.Unlock()
+	return item, err
+}
+
+// GetAt removes the i-th item with the lock held. The wrapped box must
+// implement Indexer[T], otherwise ErrIndexOutOfRange is returned.
+func (c *concurrentBox[T]) GetAt(i int) (T, error) {
+	x, ok := c.box.(Indexer[T])
+	if !ok {
+		var zero T
+		return zero, ErrIndexOutOfRange
+	}
+	c.mu.Lock()
+	item, err := x.GetAt(i)
+	if err == nil {
+		c.broadcast()
+	}
+	c.mu.Unlock()
+	return item, err
+}
+
+// PeekAt returns the i-th item of the wrapped box. The wrapped box must
+// implement Indexer[T], otherwise ErrIndexOutOfRange is returned.
+func (c *categoryBox[T, K]) PeekAt(i int) (T, error) {
+	x, ok := c.box.(Indexer[T])
+	if !ok {
+		var zero T
+		return zero, ErrIndexOutOfRange
+	}
+	return x.PeekAt(i)
+}
+
+// GetAt removes the i-th item of the wrapped box. The wrapped box must
+// implement Indexer[T], otherwise ErrIndexOutOfRange is returned.
+func (c *categoryBox[T, K]) GetAt(i int) (T, error) {
+	x, ok := c.box.(Indexer[T])
+	if !ok {
+		var zero T
+		return zero, ErrIndexOutOfRange
+	}
+	item, err := x.GetAt(i)
+	if err == nil {
+		c.release(item)
+	}
+	return item, err
+}
@@ -0,0 +1,90 @@
+package blackbox
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestIndexer(t *testing.T) {
+	data := []int{1, 2, 3, 4}
+	tests := []struct {
+		name  string
+		box   BlackBox[int]
+		third int
+		rest  []int
+	}{
+		{"FIFO", NewFIFOFrom[int](data, 0), 3, []int{1, 2, 4}},
+		{"Deque", NewDequeFrom[int](data, 0), 3, []int{1, 2, 4}},
+		{"LIFO", NewLIFOFrom[int](data, 0), 2, []int{4, 3, 1}},
+		{"Sorted", NewSortedFrom[int]([]int{4, 2, 3, 1}, lessInt, 0), 3, []int{1, 2, 4}},
+		{"Timed", New[int](WithStrategy(StrategyFIFO), WithTTL(time.Hour)), 3, []int{1, 2, 4}},
+		{"ConcurrentLIFO", NewConcurrent[int](NewLIFOFrom[int](data, 0)), 2, []int{4, 3, 1}},
+		{"CategoryFIFO", NewCategoryLimit[int, int](NewFIFO[int](0, 0), func(i int) int { return i % 2 }, 2), 3, []int{1, 2, 4}},
+	}
+	for _, tt := range tests {
+		if tt.box.Size() == 0 {
+			for _, item := range data {
+				tt.box.Put(item)
+			}
+		}
+		x, ok := tt.box.(Indexer[int])
+		if !ok {
+			t.Errorf("%s: Expected the box to implement Indexer", tt.name)
+			continue
+		}
+		if item, err := x.PeekAt(2); err != nil || item != tt.third {
+			t.Errorf("%s: Expected PeekAt(2) to return %d, got %d (%v)", tt.name, tt.third, item, err)
+		}
+		if item, err := x.GetAt(2); err != nil || item != tt.third {
+			t.Errorf("%s: Expected GetAt(2) to return %d, got %d (%v)", tt.name, tt.third, item, err)
+		}
+		if rest := Drain(tt.box); !EqualInts(rest, tt.rest) {
+			t.Errorf("%s: Expected %v to remain, got %v", tt.name, tt.rest, rest)
+		}
+		for _, i := range []int{-1, 0} {
+			if _, err := x.GetAt(i); !errors.Is(err, ErrIndexOutOfRange) {
+				t.Errorf("%s: Expected ErrIndexOutOfRange for %d, got %v", tt.name, i, err)
+			}
+			if _, err := x.PeekAt(i); !errors.Is(err, ErrIndexOutOfRange) {
+				t.Errorf("%s: Expected ErrIndexOutOfRange for %d, got %v", tt.name, i, err)
+			}
+		}
+	}
+}
+
+func TestFIFOGetAtWrapAround(t *testing.T) {
+	box := NewFIFO[int](0, 4)
+	for i := 1; i <= 4; i++ {
+		box.Put(i)
+	}
+	box.Get()
+	box.Get()
+	box.Put(5)
+	box.Put(6) // wraps around
+
+	if item, _ := box.GetAt(1); item != 4 {
+		t.Errorf("Expected 4, got %d", item)
+	}
+	box.Put(7)
+	if items := box.Items(); !EqualInts(items, []int{3, 5, 6, 7}) {
+		t.Errorf("Expected [3 5 6 7], got %v", items)
+	}
+}
+
+func TestRandomGetAtAgreesWithPeekN(t *testing.T) {
+	box := NewRandomFrom[int]([]int{1, 2, 3, 4, 5}, 0, rand.New(rand.NewSource(1)))
+
+	next := box.PeekN(4)
+	if item, _ := box.PeekAt(1); item != next[1] {
+		t.Errorf("Expected PeekAt(1) to return %d, got %d", next[1], item)
+	}
+	if item, _ := box.GetAt(1); item != next[1] {
+		t.Errorf("Expected GetAt(1) to return %d, got %d", next[1], item)
+	}
+	want := []int{next[0], next[2], next[3]}
+	if items := box.PeekN(3); !EqualInts(items, want) {
+		t.Errorf("Expected the remaining order %v, got %v", want, items)
+	}
+}
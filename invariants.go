@@ -0,0 +1,99 @@
+package blackbox
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// invariantChecker is implemented by boxes that can verify their internal
+// state, see CheckInvariants.
+type invariantChecker interface {
+	checkInvariants() error
+}
+
+// checkInvariants verifies that head, tail and size agree and that the slots
+// outside the queued items are zeroed, so removed items can be collected.
+func (b *ring[T]) checkInvariants() error {
+	n := len(b.items)
+	if b.size < 0 || b.size > n {
+		return fmt.Errorf("ring size %d out of capacity %d", b.size, n)
+	}
+	if b.maxSize > 0 && b.size > b.maxSize {
+		return fmt.Errorf("ring size %d exceeds max size %d", b.size, b.maxSize)
+	}
+	if n == 0 {
+		if b.head != 0 || b.tail != 0 {
+			return fmt.Errorf("empty ring has head %d and tail %d", b.head, b.tail)
+		}
+		return nil
+	}
+	if b.head < 0 || b.head >= n || b.tail < 0 || b.tail >= n {
+		return fmt.Errorf("ring head %d or tail %d out of capacity %d", b.head, b.tail, n)
+	}
+	if (b.head+b.size)%n != b.tail {
+		return fmt.Errorf("ring head %d and size %d do not end at tail %d", b.head, b.size, b.tail)
+	}
+	for i := b.size; i < n; i++ {
+		if idx := (b.head + i) % n; !isZero(b.items[idx]) {
+			return fmt.Errorf("ring slot %d outside the items is not zeroed", idx)
+		}
+	}
+	return nil
+}
+
+func (b *lifoBox[T]) checkInvariants() error {
+	if b.maxSize > 0 && len(b.items) > b.maxSize {
+		return fmt.Errorf("lifo size %d exceeds max size %d", len(b.items), b.maxSize)
+	}
+	spare := b.items[len(b.items):cap(b.items)]
+	for i := range spare {
+		if !isZero(spare[i]) {
+			return fmt.Errorf("lifo slot %d outside the items is not zeroed", len(b.items)+i)
+		}
+	}
+	return nil
+}
+
+func (b *randomBox[T]) checkInvariants() error {
+	n := len(b.items)
+	if b.maxSize > 0 && n > b.maxSize {
+		return fmt.Errorf("random size %d exceeds max size %d", n, b.maxSize)
+	}
+	if b.drawn < 0 || b.drawn > n {
+		return fmt.Errorf("random drawn count %d out of size %d", b.drawn, n)
+	}
+	if b.bias != nil && len(b.putAt) != n {
+		return fmt.Errorf("random has %d put times for %d items", len(b.putAt), n)
+	}
+	if b.itemWeights != nil && len(b.itemWeights) != n {
+		return fmt.Errorf("random has %d weights for %d items", len(b.itemWeights), n)
+	}
+	spare := b.items[n:cap(b.items)]
+	for i := range spare {
+		if !isZero(spare[i]) {
+			return fmt.Errorf("random slot %d outside the items is not zeroed", n+i)
+		}
+	}
+	return nil
+}
+
+func (c *concurrentBox[T]) checkInvariants() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return checkInvariants(c.box)
+}
+
+// checkInvariants verifies the internal state of box, if it can.
+func checkInvariants[T any](box BlackBox[T]) error {
+	if c, ok := box.(invariantChecker); ok {
+		if err := c.checkInvariants(); err != nil {
+			return fmt.Errorf("blackbox invariant violated: %v", err)
+		}
+	}
+	return nil
+}
+
+// isZero reports whether item is the zero value of its type.
+func isZero[T any](item T) bool {
+	return reflect.ValueOf(&item).Elem().IsZero()
+}
@@ -0,0 +1,14 @@
+//go:build blackbox_invariants
+
+package blackbox
+
+// CheckInvariants verifies the internal state of the FIFO, ring, deque, LIFO
+// and Random boxes, also behind NewConcurrent: ring head, tail and size agree,
+// sizes stay within MaxSize and removed items no longer hold memory. Other
+// boxes always pass. It is meant for tests and fuzzing, and only built with
+// the blackbox_invariants build tag:
+//
+//	go test -tags blackbox_invariants ./...
+func CheckInvariants[T any](box BlackBox[T]) error {
+	return checkInvariants(box)
+}
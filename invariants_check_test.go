@@ -0,0 +1,25 @@
+//go:build blackbox_invariants
+
+package blackbox
+
+import "testing"
+
+func TestCheckInvariants(t *testing.T) {
+	box := NewFIFO[int](4, 2)
+	for i := 0; i < 6; i++ {
+		box.Put(i)
+		box.Get()
+		box.Put(i)
+	}
+	if err := CheckInvariants[int](NewConcurrent[int](box)); err != nil {
+		t.Errorf("Expected no violation, got %v", err)
+	}
+
+	box.tail = (box.tail + 1) % len(box.items)
+	if err := CheckInvariants[int](box); err == nil {
+		t.Error("Expected a violation for a corrupted tail")
+	}
+	if err := CheckInvariants(New[int](WithTTL(0))); err != nil {
+		t.Errorf("Expected other boxes to pass, got %v", err)
+	}
+}
@@ -0,0 +1,108 @@
+package blackbox
+
+// UnsafeItemser is implemented by blackboxes backed by a slice that can hand
+// it out without the defensive copy of Items, for hot paths where that copy
+// dominates allocation profiles.
+type UnsafeItemser[T any] interface {
+	// UnsafeItems returns the internal slice holding the items, in the order
+	// of Items. The slice must not be modified, and it is invalidated by the
+	// next change of the box; copy it to keep it.
+	UnsafeItems() []T
+}
+
+// UnsafeItems returns the items in retrieval order. A wrapped-around buffer
+// is first rotated in place, without allocating, so that the items are
+// contiguous.
+func (b *ring[T]) UnsafeItems() []T {
+	if b.head+b.size > len(b.items) {
+		reverse(b.items[:b.head])
+		reverse(b.items[b.head:])
+		reverse(b.items)
+		b.head = 0
+		b.tail = b.size % len(b.items)
+	}
+	return b.items[b.head : b.head+b.size : b.head+b.size]
+}
+
+// reverse reverses items in place.
+func reverse[T any](items []T) {
+	for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+		items[i], items[j] = items[j], items[i]
+	}
+}
+
+// UnsafeItems returns the items from the bottom to the top of the stack.
+func (b *lifoBox[T]) UnsafeItems() []T {
+	return b.items[:len(b.items):len(b.items)]
+}
+
+// UnsafeItems returns the items in storage order.
+func (b *randomBox[T]) UnsafeItems() []T {
+	return b.items[:len(b.items):len(b.items)]
+}
+
+// itemsAppender is implemented by the boxes of this package that can copy
+// their items into a caller-provided slice.
+type itemsAppender[T any] interface {
+	ItemsInto(dst []T) []T
+}
+
+// ItemsInto appends the items of box to dst, in the order of Items, and
+// returns the extended slice. Passing the previous result with length 0 lets
+// periodic snapshots of large boxes reuse one buffer instead of allocating a
+// fresh slice every time. Boxes without an ItemsInto method fall back to Items.
+func ItemsInto[T any](box BlackBox[T], dst []T) []T {
+	if a, ok := box.(itemsAppender[T]); ok {
+		return a.ItemsInto(dst)
+	}
+	return append(dst, box.Items()...)
+}
+
+func (b *ring[T]) ItemsInto(dst []T) []T {
+	if b.size == 0 {
+		return dst
+	}
+	if b.head < b.tail {
+		return append(dst, b.items[b.head:b.tail]...)
+	}
+	dst = append(dst, b.items[b.head:]...)
+	return append(dst, b.items[:b.tail]...)
+}
+
+func (b *lifoBox[T]) ItemsInto(dst []T) []T {
+	return append(dst, b.items...)
+}
+
+func (b *randomBox[T]) ItemsInto(dst []T) []T {
+	return append(dst, b.items...)
+}
+
+func (b *sortedBox[T]) ItemsInto(dst []T) []T {
+	for x := b.head.next[0]; x != nil; x = x.next[0] {
+		dst = append(dst, x.item)
+	}
+	return dst
+}
+
+func (b *chunkedFIFO[T]) ItemsInto(dst []T) []T {
+	b.forEach(func(item T) bool {
+		dst = append(dst, item)
+		return true
+	})
+	return dst
+}
+
+func (b *listFIFO[T]) ItemsInto(dst []T) []T {
+	b.forEach(func(item T) bool {
+		dst = append(dst, item)
+		return true
+	})
+	return dst
+}
+
+// ItemsInto appends the items of the wrapped box to dst under the lock.
+func (c *concurrentBox[T]) ItemsInto(dst []T) []T {
+	c.rlock()
+	defer c.runlock()
+	return ItemsInto(c.box, dst)
+}
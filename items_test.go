@@ -0,0 +1,74 @@
+package blackbox
+
+import "testing"
+
+func TestUnsafeItems(t *testing.T) {
+	fifo := NewFIFO[int](0, 4)
+	for i := 1; i <= 4; i++ {
+		fifo.Put(i)
+	}
+	fifo.Get()
+	fifo.Get()
+	fifo.Put(5)
+	fifo.Put(6) // wraps around
+
+	items := fifo.UnsafeItems()
+	if !EqualInts(items, []int{3, 4, 5, 6}) {
+		t.Fatalf("Expected [3 4 5 6], got %v", items)
+	}
+	if &items[0] != &fifo.items[0] {
+		t.Error("Expected the internal slice to be returned")
+	}
+	fifo.Put(7)
+	for i := 3; i <= 7; i++ {
+		if item, _ := fifo.Get(); item != i {
+			t.Errorf("Expected %d after rotating, got %d", i, item)
+		}
+	}
+
+	lifo := NewLIFOFrom[int]([]int{1, 2, 3}, 0)
+	if items := lifo.UnsafeItems(); !EqualInts(items, lifo.Items()) || &items[0] != &lifo.items[0] {
+		t.Errorf("Expected the internal slice [1 2 3], got %v", items)
+	}
+
+	var box BlackBox[int] = NewRandomFrom[int]([]int{1, 2}, 0, nil)
+	if u, ok := box.(UnsafeItemser[int]); !ok || len(u.UnsafeItems()) != 2 {
+		t.Error("Expected random boxes to implement UnsafeItemser")
+	}
+	if items := NewDeque[int](0, 0).UnsafeItems(); len(items) != 0 {
+		t.Errorf("Expected no items, got %v", items)
+	}
+}
+
+func TestItemsInto(t *testing.T) {
+	boxes := map[string]BlackBox[int]{
+		"fifo":       NewFIFOFrom[int]([]int{1, 2, 3}, 0),
+		"lifo":       NewLIFOFrom[int]([]int{1, 2, 3}, 0),
+		"random":     NewRandomFrom[int]([]int{1, 2, 3}, 0, nil),
+		"sorted":     NewSortedFrom[int]([]int{3, 1, 2}, lessInt, 0),
+		"chunked":    NewChunkedFIFOFrom[int]([]int{1, 2, 3}, 0, 2),
+		"list":       NewFIFOListFrom[int]([]int{1, 2, 3}, 0),
+		"concurrent": NewConcurrent[int](NewFIFOFrom[int]([]int{1, 2, 3}, 0)),
+		"timed":      NewFrom[int]([]int{1, 2, 3}, WithStrategy(StrategyFIFO), WithTTL(0)),
+	}
+	buf := make([]int, 0, 8)
+	for name, box := range boxes {
+		buf = ItemsInto(box, buf[:0])
+		if !EqualInts(buf, box.Items()) {
+			t.Errorf("%s: Expected %v, got %v", name, box.Items(), buf)
+		}
+		if cap(buf) != 8 {
+			t.Errorf("%s: Expected the buffer to be reused, got capacity %d", name, cap(buf))
+		}
+	}
+
+	wrapped := NewFIFO[int](0, 3)
+	wrapped.Put(0)
+	wrapped.Put(1)
+	wrapped.Get()
+	wrapped.Put(2)
+	wrapped.Put(3)
+	if got := ItemsInto[int](wrapped, []int{9}); !EqualInts(got, []int{9, 1, 2, 3}) {
+		t.Errorf("Expected [9 1 2 3], got %v", got)
+	}
+}
@@ -0,0 +1,292 @@
+package blackbox
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrNotSerializable = errors.New("blackbox wraps a BlackBox[T] that cannot be serialized")
+
+// Envelope is the portable representation of a blackbox, used by MarshalJSON
+// and NewFromJSON. Items are listed in the order of Items.
+type Envelope[T any] struct {
+	// Version is the layout version, see EnvelopeVersion.
+	Version  int    `json:"version"`
+	Strategy string `json:"strategy"`
+	MaxSize  int    `json:"maxSize"`
+	// Seed is the seed of the RNG of a Random box, when known.
+	Seed  *int64 `json:"seed,omitempty"`
+	Items []T    `json:"items"`
+}
+
+// enveloper is implemented by the boxes of this package to convert their
+// content from and to an Envelope. load replaces the items and the maximum
+// size but keeps the other options of the box. Only wrappers return errors.
+type enveloper[T any] interface {
+	envelope() (Envelope[T], error)
+	load(env Envelope[T]) error
+}
+
+// NewFromJSON creates a new BlackBox from the JSON encoding of an Envelope, as
+// produced by MarshalJSON. The strategy, maximum size and seed come from the
+// envelope and opts are applied afterwards, so they can override them or add
+// options that are not serialized, such as WithEvictCallback.
+//
+// Built-in strategies, "deque" and strategies registered with RegisterStrategy
+// are supported. A Sorted box cannot be created this way since its less
+// function cannot be serialized; unmarshal into a box from NewSorted instead.
+func NewFromJSON[T any](data []byte, opts ...Option) (BlackBox[T], error) {
+	env, err := decodeEnvelope[T](data)
+	if err != nil {
+		return nil, err
+	}
+	return newFromEnvelope(env, opts)
+}
+
+func newFromEnvelope[T any](env Envelope[T], opts []Option) (BlackBox[T], error) {
+	envOpts := []Option{WithMaxSize(env.MaxSize)}
+	if env.Seed != nil {
+		envOpts = append(envOpts, WithSeed(*env.Seed))
+	}
+	opts = append(envOpts, opts...)
+
+	if env.Strategy == "deque" {
+		return NewDequeFrom[T](env.Items, parseOptions(opts).maxSize), nil
+	}
+	if strategy, err := ParseStrategy(env.Strategy); err == nil {
+		return NewFrom[T](env.Items, append([]Option{WithStrategy(strategy)}, opts...)...), nil
+	}
+	box, err := NewByName[T](env.Strategy, opts...)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range env.Items {
+		if err := box.Put(item); err != nil {
+			return nil, fmt.Errorf("blackbox: restoring %q box: %w", env.Strategy, err)
+		}
+	}
+	return box, nil
+}
+
+func marshalBox[T any](e enveloper[T]) ([]byte, error) {
+	env, err := e.envelope()
+	if err != nil {
+		return nil, err
+	}
+	env.Version = EnvelopeVersion
+	return json.Marshal(env)
+}
+
+func unmarshalBox[T any](e enveloper[T], data []byte) error {
+	env, err := decodeEnvelope[T](data)
+	if err != nil {
+		return err
+	}
+	return e.load(env)
+}
+
+// fromMaxSize adjusts maxSize to hold size items, like the From constructors.
+func fromMaxSize(maxSize, size int) int {
+	if maxSize > 0 && maxSize < size {
+		return size
+	}
+	return maxSize
+}
+
+func (b *fifoBox[T]) envelope() (Envelope[T], error) {
+	strategy := StrategyFIFO
+	if b.overwrite {
+		strategy = StrategyRing
+	}
+	return Envelope[T]{Strategy: strategy.String(), MaxSize: b.maxSize, Items: b.Items()}, nil
+}
+
+func (b *fifoBox[T]) load(env Envelope[T]) error {
+	if b.overwrite && env.MaxSize < 1 {
+		env.MaxSize = 1
+	}
+	b.ring = newRingFrom(env.Items, env.MaxSize)
+	return nil
+}
+
+func (b *fifoBox[T]) MarshalJSON() ([]byte, error) { return marshalBox[T](b) }
+
+// UnmarshalJSON replaces the items and maximum size of the box with the
+// content of an Envelope, keeping the other options.
+func (b *fifoBox[T]) UnmarshalJSON(data []byte) error { return unmarshalBox[T](b, data) }
+
+func (b *dequeBox[T]) envelope() (Envelope[T], error) {
+	return Envelope[T]{Strategy: "deque", MaxSize: b.maxSize, Items: b.Items()}, nil
+}
+
+func (b *dequeBox[T]) load(env Envelope[T]) error {
+	b.ring = newRingFrom(env.Items, env.MaxSize)
+	return nil
+}
+
+func (b *dequeBox[T]) MarshalJSON() ([]byte, error) { return marshalBox[T](b) }
+
+// UnmarshalJSON replaces the items and maximum size of the box with the
+// content of an Envelope.
+func (b *dequeBox[T]) UnmarshalJSON(data []byte) error { return unmarshalBox[T](b, data) }
+
+func (b *lifoBox[T]) envelope() (Envelope[T], error) {
+	return Envelope[T]{Strategy: StrategyLIFO.String(), MaxSize: b.maxSize, Items: b.Items()}, nil
+}
+
+func (b *lifoBox[T]) load(env Envelope[T]) error {
+	b.items = env.Items
+	b.maxSize = fromMaxSize(env.MaxSize, len(env.Items))
+	return nil
+}
+
+func (b *lifoBox[T]) MarshalJSON() ([]byte, error) { return marshalBox[T](b) }
+
+// UnmarshalJSON replaces the items and maximum size of the box with the
+// content of an Envelope, keeping the other options.
+func (b *lifoBox[T]) UnmarshalJSON(data []byte) error { return unmarshalBox[T](b, data) }
+
+// envelope includes the seed of the RNG when known. Weights set by PutWeighted
+// are not part of the envelope.
+func (b *randomBox[T]) envelope() (Envelope[T], error) {
+	env := Envelope[T]{Strategy: StrategyRandom.String(), MaxSize: b.maxSize, Items: b.Items()}
+	if b.src != nil {
+		seed := b.src.seed
+		env.Seed = &seed
+	}
+	return env, nil
+}
+
+// load keeps the RNG of the box; loaded items weigh 1 and are treated as if
+// they were put now.
+func (b *randomBox[T]) load(env Envelope[T]) error {
+	b.items = env.Items
+	b.maxSize = fromMaxSize(env.MaxSize, len(env.Items))
+	b.itemWeights = nil
+	b.drawn = 0
+	if b.bias != nil {
+		b.setAgeBias(b.bias, b.now)
+	}
+	return nil
+}
+
+func (b *randomBox[T]) MarshalJSON() ([]byte, error) { return marshalBox[T](b) }
+
+// UnmarshalJSON replaces the items and maximum size of the box with the
+// content of an Envelope, keeping the other options and the RNG.
+func (b *randomBox[T]) UnmarshalJSON(data []byte) error { return unmarshalBox[T](b, data) }
+
+func (b *sortedBox[T]) envelope() (Envelope[T], error) {
+	return Envelope[T]{Strategy: "sorted", MaxSize: b.maxSize, Items: b.Items()}, nil
+}
+
+func (b *sortedBox[T]) load(env Envelope[T]) error {
+	b.Clean()
+	b.maxSize = fromMaxSize(env.MaxSize, len(env.Items))
+	for _, item := range env.Items {
+		b.insert(item)
+	}
+	return nil
+}
+
+func (b *sortedBox[T]) MarshalJSON() ([]byte, error) { return marshalBox[T](b) }
+
+// UnmarshalJSON replaces the items and maximum size of the box with the
+// content of an Envelope, keeping the less function.
+func (b *sortedBox[T]) UnmarshalJSON(data []byte) error { return unmarshalBox[T](b, data) }
+
+// envelope lists the unexpired items; their expiry is not part of the envelope.
+func (t *timedBox[T]) envelope() (Envelope[T], error) {
+	t.purge()
+	inner, err := asEnveloper(t.box)
+	if err != nil {
+		return Envelope[T]{}, err
+	}
+	env, err := inner.envelope()
+	return Envelope[T]{Strategy: env.Strategy, MaxSize: env.MaxSize, Seed: env.Seed, Items: values(env.Items)}, err
+}
+
+// load restarts the default TTL of the loaded items from now.
+func (t *timedBox[T]) load(env Envelope[T]) error {
+	inner, err := asEnveloper(t.box)
+	if err != nil {
+		return err
+	}
+	t.nextExpiry = time.Time{}
+	now := t.clock.Now()
+	items := make([]timedItem[T], len(env.Items))
+	for i, value := range env.Items {
+		items[i] = t.stamp(value, t.ttl, now)
+	}
+	return inner.load(Envelope[timedItem[T]]{MaxSize: env.MaxSize, Items: items})
+}
+
+func (t *timedBox[T]) MarshalJSON() ([]byte, error) { return marshalBox[T](t) }
+
+// UnmarshalJSON replaces the items and maximum size of the box with the
+// content of an Envelope. Loaded items expire after the default TTL.
+func (t *timedBox[T]) UnmarshalJSON(data []byte) error { return unmarshalBox[T](t, data) }
+
+func (c *categoryBox[T, K]) envelope() (Envelope[T], error) {
+	inner, err := asEnveloper(c.box)
+	if err != nil {
+		return Envelope[T]{}, err
+	}
+	return inner.envelope()
+}
+
+func (c *categoryBox[T, K]) load(env Envelope[T]) error {
+	inner, err := asEnveloper(c.box)
+	if err != nil {
+		return err
+	}
+	if err := inner.load(env); err != nil {
+		return err
+	}
+	c.sync()
+	return nil
+}
+
+func (c *categoryBox[T, K]) MarshalJSON() ([]byte, error) { return marshalBox[T](c) }
+
+// UnmarshalJSON replaces the items of the wrapped box. Loaded items are
+// counted but never rejected.
+func (c *categoryBox[T, K]) UnmarshalJSON(data []byte) error { return unmarshalBox[T](c, data) }
+
+func (c *concurrentBox[T]) envelope() (Envelope[T], error) {
+	inner, err := asEnveloper(c.box)
+	if err != nil {
+		return Envelope[T]{}, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return inner.envelope()
+}
+
+func (c *concurrentBox[T]) load(env Envelope[T]) error {
+	inner, err := asEnveloper(c.box)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	err = inner.load(env)
+	c.broadcast()
+	return err
+}
+
+func (c *concurrentBox[T]) MarshalJSON() ([]byte, error) { return marshalBox[T](c) }
+
+// UnmarshalJSON replaces the items of the wrapped box.
+func (c *concurrentBox[T]) UnmarshalJSON(data []byte) error { return unmarshalBox[T](c, data) }
+
+// asEnveloper returns a wrapped box as an enveloper, or ErrNotSerializable.
+func asEnveloper[T any](box BlackBox[T]) (enveloper[T], error) {
+	e, ok := box.(enveloper[T])
+	if !ok {
+		return nil, ErrNotSerializable
+	}
+	return e, nil
+}
@@ -0,0 +1,91 @@
+package blackbox
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	boxes := map[string]BlackBox[int]{
+		"FIFO":   NewFIFOFrom[int]([]int{1, 2, 3}, 5),
+		"LIFO":   NewLIFOFrom[int]([]int{1, 2, 3}, 5),
+		"Deque":  NewDequeFrom[int]([]int{1, 2, 3}, 5),
+		"Random": NewFrom[int]([]int{1, 2, 3}, WithSeed(9), WithMaxSize(5)),
+		"Timed":  NewFrom[int]([]int{1, 2, 3}, WithStrategy(StrategyLIFO), WithMaxSize(5), WithTTL(time.Hour)),
+	}
+	for name, box := range boxes {
+		data, err := json.Marshal(box)
+		if err != nil {
+			t.Fatalf("%s: Failed to marshal: %v", name, err)
+		}
+		restored, err := NewFromJSON[int](data)
+		if err != nil {
+			t.Fatalf("%s: Failed to restore %s: %v", name, data, err)
+		}
+		if restored.MaxSize() != 5 {
+			t.Errorf("%s: Expected max size 5, got %d", name, restored.MaxSize())
+		}
+		if want, got := Drain(box), Drain(restored); !EqualInts(got, want) {
+			t.Errorf("%s: Expected %v, got %v", name, want, got)
+		}
+	}
+}
+
+func TestJSONEnvelope(t *testing.T) {
+	data, _ := json.Marshal(NewFrom[string]([]string{"a", "b"}, WithSeed(7)))
+	want := `{"version":1,"strategy":"random","maxSize":0,"seed":7,"items":["a","b"]}`
+	if string(data) != want {
+		t.Errorf("Expected %s, got %s", want, data)
+	}
+
+	box, err := NewFromJSON[string]([]byte(`{"strategy":"fifo","maxSize":1,"items":["a","b"]}`))
+	if err != nil {
+		t.Fatalf("Failed to restore: %v", err)
+	}
+	if box.MaxSize() != 2 {
+		t.Errorf("Expected max size to grow to 2, got %d", box.MaxSize())
+	}
+
+	if _, err := NewFromJSON[string]([]byte(`{"strategy":"sorted","items":[]}`)); !errors.Is(err, ErrUnknownStrategy) {
+		t.Errorf("Expected ErrUnknownStrategy, got %v", err)
+	}
+	if _, err := NewFromJSON[string]([]byte(`{`)); err == nil {
+		t.Error("Expected a syntax error")
+	}
+}
+
+func TestJSONUnmarshalKeepsOptions(t *testing.T) {
+	var evicted []int
+	box := New[int](WithStrategy(StrategyFIFO), WithEvictCallback(func(item int) {
+		evicted = append(evicted, item)
+	}))
+	if err := json.Unmarshal([]byte(`{"strategy":"fifo","maxSize":2,"items":[1,2]}`), box); err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+	box.Clean()
+	if !EqualInts(evicted, []int{1, 2}) {
+		t.Errorf("Expected evict callback to be kept, got %v", evicted)
+	}
+
+	sorted := NewSorted[int](lessInt, 0)
+	if err := json.Unmarshal([]byte(`{"strategy":"sorted","items":[3,1,2]}`), sorted); err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+	if items := sorted.Items(); !EqualInts(items, []int{1, 2, 3}) {
+		t.Errorf("Expected [1 2 3], got %v", items)
+	}
+
+	category := New[int](WithStrategy(StrategyLIFO), WithCategoryLimit(isEven, 1))
+	if err := json.Unmarshal([]byte(`{"items":[2,4]}`), category); err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+	if err := category.Put(6); err != ErrCategoryFull {
+		t.Errorf("Expected loaded items to be counted, got %v", err)
+	}
+
+	if _, err := json.Marshal(NewConcurrent[int](NewChaos[int](NewFIFO[int](0, 1), ChaosConfig{}))); !errors.Is(err, ErrNotSerializable) {
+		t.Errorf("Expected ErrNotSerializable, got %v", err)
+	}
+}
@@ -0,0 +1,226 @@
+// Package kvbox provides durable FIFO and LIFO blackboxes stored in an
+// ordered key-value store, for embedded queues larger than memory.
+//
+// The package has no dependencies: any ordered store can back a box by
+// implementing Store. For example, with a bbolt bucket:
+//
+//	type boltStore struct {
+//		db     *bolt.DB
+//		bucket []byte
+//	}
+//
+//	func (s boltStore) Put(key, value []byte) error {
+//		return s.db.Update(func(tx *bolt.Tx) error {
+//			return tx.Bucket(s.bucket).Put(key, value)
+//		})
+//	}
+//
+//	func (s boltStore) First() (key, value []byte, err error) {
+//		err = s.db.View(func(tx *bolt.Tx) error {
+//			k, v := tx.Bucket(s.bucket).Cursor().First()
+//			key, value = clone(k), clone(v)
+//			return nil
+//		})
+//		return key, value, err
+//	}
+//
+// and similarly Last with Cursor().Last, Delete with Bucket.Delete and
+// ForEach with Bucket.ForEach. Badger works the same way with an iterator.
+package kvbox
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/raditzlawliet/blackbox"
+)
+
+// Store is an ordered key-value store. Keys are ordered bytewise.
+// Returned slices must stay valid after the call returns.
+type Store interface {
+	Put(key, value []byte) error
+	Delete(key []byte) error
+	// First returns the smallest key and its value, or a nil key if the store is empty.
+	First() (key, value []byte, err error)
+	// Last returns the largest key and its value, or a nil key if the store is empty.
+	Last() (key, value []byte, err error)
+	// ForEach calls fn for every key in ascending order, stopping at the first error.
+	ForEach(fn func(key, value []byte) error) error
+}
+
+// kvBox stores its items under 8-byte big-endian sequence numbers, so the
+// store keeps them in insertion order.
+type kvBox[T any] struct {
+	store   Store
+	codec   blackbox.ItemCodec[T]
+	lifo    bool
+	maxSize int
+	size    int
+	next    uint64
+	err     error
+}
+
+// NewFIFO creates a FIFO blackbox backed by store, encoding items with codec.
+// Items already in store are kept, so a queue survives restarts.
+// Returns a concrete instance of kv blackbox without interface.
+func NewFIFO[T any](store Store, maxSize int, codec blackbox.ItemCodec[T]) (*kvBox[T], error) {
+	return newKVBox(store, maxSize, codec, false)
+}
+
+// NewLIFO creates a LIFO blackbox backed by store, encoding items with codec.
+// Items already in store are kept, so a stack survives restarts.
+// Returns a concrete instance of kv blackbox without interface.
+func NewLIFO[T any](store Store, maxSize int, codec blackbox.ItemCodec[T]) (*kvBox[T], error) {
+	return newKVBox(store, maxSize, codec, true)
+}
+
+func newKVBox[T any](store Store, maxSize int, codec blackbox.ItemCodec[T], lifo bool) (*kvBox[T], error) {
+	b := &kvBox[T]{store: store, codec: codec, lifo: lifo}
+	err := store.ForEach(func(key, value []byte) error {
+		if len(key) != 8 {
+			return errInvalidKey
+		}
+		b.size++
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	last, _, err := store.Last()
+	if err != nil {
+		return nil, err
+	}
+	if last != nil {
+		b.next = binary.BigEndian.Uint64(last) + 1
+	}
+	if maxSize > 0 && maxSize < b.size {
+		maxSize = b.size
+	}
+	b.maxSize = maxSize
+	return b, nil
+}
+
+var errInvalidKey = errors.New("kvbox: store holds a key that was not written by kvbox")
+
+func key(seq uint64) []byte {
+	k := make([]byte, 8)
+	binary.BigEndian.PutUint64(k, seq)
+	return k
+}
+
+// Err returns the last store error hit by a method that cannot return one
+// (Size, IsFull, IsEmpty, Clean and Items).
+func (b *kvBox[T]) Err() error {
+	return b.err
+}
+
+func (b *kvBox[T]) Put(item T) error {
+	if b.IsFull() {
+		return blackbox.ErrBlackBoxFull
+	}
+	data, err := b.codec.Encode(item)
+	if err != nil {
+		return err
+	}
+	if err := b.store.Put(key(b.next), data); err != nil {
+		return err
+	}
+	b.next++
+	b.size++
+	return nil
+}
+
+// head returns the key and value of the next item.
+func (b *kvBox[T]) head() ([]byte, []byte, error) {
+	if b.lifo {
+		return b.store.Last()
+	}
+	return b.store.First()
+}
+
+func (b *kvBox[T]) Get() (T, error) {
+	var zero T
+	k, v, err := b.head()
+	if err != nil {
+		return zero, err
+	}
+	if k == nil {
+		return zero, blackbox.ErrEmptyBlackBox
+	}
+	item, err := b.codec.Decode(v)
+	if err != nil {
+		return zero, err
+	}
+	if err := b.store.Delete(k); err != nil {
+		return zero, err
+	}
+	b.size--
+	return item, nil
+}
+
+func (b *kvBox[T]) Peek() (T, error) {
+	var zero T
+	k, v, err := b.head()
+	if err != nil {
+		return zero, err
+	}
+	if k == nil {
+		return zero, blackbox.ErrEmptyBlackBox
+	}
+	return b.codec.Decode(v)
+}
+
+func (b *kvBox[T]) Size() int {
+	return b.size
+}
+
+func (b *kvBox[T]) MaxSize() int {
+	return b.maxSize
+}
+
+func (b *kvBox[T]) IsFull() bool {
+	return b.maxSize > 0 && b.size >= b.maxSize
+}
+
+func (b *kvBox[T]) IsEmpty() bool {
+	return b.size == 0
+}
+
+func (b *kvBox[T]) Clean() {
+	var keys [][]byte
+	err := b.store.ForEach(func(key, _ []byte) error {
+		keys = append(keys, append([]byte(nil), key...))
+		return nil
+	})
+	for _, k := range keys {
+		if err != nil {
+			break
+		}
+		if err = b.store.Delete(k); err == nil {
+			b.size--
+		}
+	}
+	if err != nil {
+		b.err = err
+	}
+}
+
+// Items returns a copy of all items in insertion order.
+func (b *kvBox[T]) Items() []T {
+	items := make([]T, 0, b.size)
+	err := b.store.ForEach(func(_, value []byte) error {
+		item, err := b.codec.Decode(value)
+		if err != nil {
+			return err
+		}
+		items = append(items, item)
+		return nil
+	})
+	if err != nil {
+		b.err = err
+	}
+	return items
+}
+
+// Compile-time assertion that kvBox implements BlackBox[T].
+var _ blackbox.BlackBox[any] = (*kvBox[any])(nil)
@@ -0,0 +1,68 @@
+package kvbox
+
+import (
+	"testing"
+
+	"github.com/raditzlawliet/blackbox"
+	"github.com/raditzlawliet/blackbox/blackboxtest"
+)
+
+func TestFIFO(t *testing.T) {
+	store := NewMemStore()
+	box, err := NewFIFO[string](store, 3, blackbox.DefaultItemCodec[string]())
+	if err != nil {
+		t.Fatalf("Failed to create box: %v", err)
+	}
+	box.Put("a")
+	box.Put("b")
+	box.Put("c")
+	if err := box.Put("d"); err != blackbox.ErrBlackBoxFull {
+		t.Errorf("Expected ErrBlackBoxFull, got %v", err)
+	}
+	if item, _ := box.Get(); item != "a" {
+		t.Errorf("Expected a, got %s", item)
+	}
+
+	// Reopening the store keeps the queue and its order.
+	reopened, err := NewFIFO[string](store, 3, blackbox.DefaultItemCodec[string]())
+	if err != nil {
+		t.Fatalf("Failed to reopen box: %v", err)
+	}
+	reopened.Put("d")
+	want := []string{"b", "c", "d"}
+	for _, w := range want {
+		if item, _ := reopened.Get(); item != w {
+			t.Errorf("Expected %s, got %s", w, item)
+		}
+	}
+	if _, err := reopened.Get(); err != blackbox.ErrEmptyBlackBox {
+		t.Errorf("Expected ErrEmptyBlackBox, got %v", err)
+	}
+}
+
+func TestLIFO(t *testing.T) {
+	box, _ := NewLIFO[int](NewMemStore(), 0, blackbox.DefaultItemCodec[int]())
+	for i := 1; i <= 3; i++ {
+		box.Put(i)
+	}
+	if item, _ := box.Peek(); item != 3 {
+		t.Errorf("Expected 3, got %d", item)
+	}
+	if items := box.Items(); len(items) != 3 || items[0] != 1 {
+		t.Errorf("Expected items in insertion order, got %v", items)
+	}
+	box.Clean()
+	if !box.IsEmpty() || box.Err() != nil {
+		t.Errorf("Expected empty box after Clean(), got size %d, err %v", box.Size(), box.Err())
+	}
+}
+
+func TestConformance(t *testing.T) {
+	blackboxtest.RunConformance(t, func(maxSize int) blackbox.BlackBox[int] {
+		box, err := NewFIFO[int](NewMemStore(), maxSize, blackbox.DefaultItemCodec[int]())
+		if err != nil {
+			t.Fatalf("Failed to create box: %v", err)
+		}
+		return box
+	})
+}
@@ -0,0 +1,78 @@
+package kvbox
+
+import (
+	"bytes"
+	"sort"
+)
+
+// MemStore is an in-memory Store, useful for tests.
+type MemStore struct {
+	keys   [][]byte
+	values [][]byte
+}
+
+// NewMemStore creates an empty in-memory store.
+func NewMemStore() *MemStore {
+	return &MemStore{}
+}
+
+func (s *MemStore) search(key []byte) (int, bool) {
+	i := sort.Search(len(s.keys), func(i int) bool {
+		return bytes.Compare(s.keys[i], key) >= 0
+	})
+	return i, i < len(s.keys) && bytes.Equal(s.keys[i], key)
+}
+
+func (s *MemStore) Put(key, value []byte) error {
+	key = append([]byte(nil), key...)
+	value = append([]byte(nil), value...)
+	i, found := s.search(key)
+	if found {
+		s.values[i] = value
+		return nil
+	}
+	s.keys = append(s.keys, nil)
+	s.values = append(s.values, nil)
+	copy(s.keys[i+1:], s.keys[i:])
+	copy(s.values[i+1:], s.values[i:])
+	s.keys[i] = key
+	s.values[i] = value
+	return nil
+}
+
+func (s *MemStore) Delete(key []byte) error {
+	i, found := s.search(key)
+	if !found {
+		return nil
+	}
+	s.keys = append(s.keys[:i], s.keys[i+1:]...)
+	s.values = append(s.values[:i], s.values[i+1:]...)
+	return nil
+}
+
+func (s *MemStore) First() (key, value []byte, err error) {
+	if len(s.keys) == 0 {
+		return nil, nil, nil
+	}
+	return s.keys[0], s.values[0], nil
+}
+
+func (s *MemStore) Last() (key, value []byte, err error) {
+	if len(s.keys) == 0 {
+		return nil, nil, nil
+	}
+	n := len(s.keys) - 1
+	return s.keys[n], s.values[n], nil
+}
+
+func (s *MemStore) ForEach(fn func(key, value []byte) error) error {
+	for i := range s.keys {
+		if err := fn(s.keys[i], s.values[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Compile-time assertion that MemStore implements Store.
+var _ Store = (*MemStore)(nil)
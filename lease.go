@@ -0,0 +1,259 @@
+package blackbox
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrUnknownLease is returned by Ack and Nack for a lease that was already
+// settled or has expired and been redelivered.
+var ErrUnknownLease = errors.New("blackbox lease is unknown or expired")
+
+// LeaseConfig configures the lease-based consumer returned by NewLeasing.
+type LeaseConfig struct {
+	// Timeout is how long a leased item may stay unacknowledged before it is
+	// redelivered.
+	Timeout time.Duration
+	// Clock is used to measure the timeout. When nil, the wall clock is used.
+	Clock Clock
+}
+
+// Lease identifies an item handed out by GetLease until it is settled with
+// Ack or Nack.
+type Lease struct {
+	ID uint64
+	// Deadline is the time after which the item is redelivered.
+	Deadline time.Time
+}
+
+type leasedItem[T any] struct {
+	lease Lease
+	item  T
+}
+
+// leaseBox hands out items under a visibility timeout, like a work queue.
+type leaseBox[T any] struct {
+	// mu guards the leases and serializes the calls on box.
+	mu     sync.Mutex
+	box    BlackBox[T]
+	cfg    LeaseConfig
+	nextID uint64
+	// leases are the in-flight items in the order they were leased, which is
+	// also the order of their deadlines.
+	leases []leasedItem[T]
+	// waker wakes up a concurrent wrapper once the next lease expires.
+	waker wakeTimer
+}
+
+// NewLeasing wraps box with a lease-based consumption mode. GetLease removes
+// an item from box and returns it with a Lease; the item is put back into box
+// if the lease is not acknowledged with Ack within cfg.Timeout, and Nack puts
+// it back immediately. Plain Get keeps working and needs no acknowledgement.
+//
+// Expired leases are redelivered lazily: every call on the returned box first
+// puts the items whose lease has expired back into box, in the order they
+// were leased. If box is full, the item stays in flight and is retried on the
+// next call. Size, IsEmpty and Items only reflect items available in box; use
+// InFlight to see how many items are leased. When wrapped with NewConcurrent,
+// an expiring lease also wakes up callers blocked in GetCtx or WaitNotEmpty.
+//
+// The returned box is safe for concurrent use, so several workers can share
+// it; box must then only be accessed through it.
+// Returns a concrete instance of leasing blackbox.
+func NewLeasing[T any](box BlackBox[T], cfg LeaseConfig) *leaseBox[T] {
+	if cfg.Clock == nil {
+		cfg.Clock = systemClock{}
+	}
+	return &leaseBox[T]{box: box, cfg: cfg}
+}
+
+// expire puts the items whose lease has expired back into the wrapped box.
+func (l *leaseBox[T]) expire() {
+	if len(l.leases) == 0 {
+		return
+	}
+	now := l.cfg.Clock.Now()
+	kept := l.leases[:0]
+	for _, li := range l.leases {
+		if now.Before(li.lease.Deadline) || l.box.Put(li.item) != nil {
+			kept = append(kept, li)
+		}
+	}
+	var zero leasedItem[T]
+	for i := len(kept); i < len(l.leases); i++ {
+		l.leases[i] = zero
+	}
+	l.leases = kept
+	l.arm(now)
+}
+
+// arm sets the waker for the first lease that has not expired yet.
+func (l *leaseBox[T]) arm(now time.Time) {
+	for _, li := range l.leases {
+		if now.Before(li.lease.Deadline) {
+			l.waker.arm(li.lease.Deadline, now)
+			return
+		}
+	}
+}
+
+func (l *leaseBox[T]) setWake(wake func()) {
+	l.waker.set(wake)
+	l.mu.Lock()
+	l.arm(l.cfg.Clock.Now())
+	l.mu.Unlock()
+}
+
+// held reports no items: a leased item belongs to its consumer until the
+// lease expires, and Ack doesn't notify a concurrent wrapper, so Process
+// doesn't wait for it.
+func (l *leaseBox[T]) held() int {
+	return 0
+}
+
+// settle removes the in-flight item of lease and returns it.
+func (l *leaseBox[T]) settle(lease Lease) (T, bool) {
+	for i, li := range l.leases {
+		if li.lease.ID == lease.ID {
+			copy(l.leases[i:], l.leases[i+1:])
+			l.leases[len(l.leases)-1] = leasedItem[T]{}
+			l.leases = l.leases[:len(l.leases)-1]
+			return li.item, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// GetLease retrieves an item like Get, but keeps it in flight until Ack is
+// called with the returned lease. If neither Ack nor Nack is called before
+// the lease deadline, the item is redelivered.
+func (l *leaseBox[T]) GetLease() (T, Lease, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.expire()
+	item, err := l.box.Get()
+	if err != nil {
+		return item, Lease{}, err
+	}
+	l.nextID++
+	now := l.cfg.Clock.Now()
+	lease := Lease{ID: l.nextID, Deadline: now.Add(l.cfg.Timeout)}
+	l.leases = append(l.leases, leasedItem[T]{lease: lease, item: item})
+	l.waker.arm(lease.Deadline, now)
+	return item, lease, nil
+}
+
+// Ack marks the leased item as processed, so it is never redelivered.
+// It returns ErrUnknownLease if the lease was already settled or expired.
+func (l *leaseBox[T]) Ack(lease Lease) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.expire()
+	if _, ok := l.settle(lease); !ok {
+		return ErrUnknownLease
+	}
+	return nil
+}
+
+// Nack puts the leased item back into the wrapped box immediately. It returns
+// ErrUnknownLease if the lease was already settled or expired, or the error
+// of the wrapped box, in which case the item stays in flight until its
+// deadline.
+func (l *leaseBox[T]) Nack(lease Lease) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.expire()
+	for _, li := range l.leases {
+		if li.lease.ID != lease.ID {
+			continue
+		}
+		if err := l.box.Put(li.item); err != nil {
+			return err
+		}
+		l.settle(lease)
+		return nil
+	}
+	return ErrUnknownLease
+}
+
+// InFlight returns the number of leased items that are neither acknowledged
+// nor redelivered yet.
+func (l *leaseBox[T]) InFlight() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.expire()
+	return len(l.leases)
+}
+
+func (l *leaseBox[T]) Put(item T) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.expire()
+	return l.box.Put(item)
+}
+
+func (l *leaseBox[T]) Get() (T, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.expire()
+	return l.box.Get()
+}
+
+func (l *leaseBox[T]) Peek() (T, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.expire()
+	return l.box.Peek()
+}
+
+func (l *leaseBox[T]) Size() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.expire()
+	return l.box.Size()
+}
+
+func (l *leaseBox[T]) MaxSize() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.box.MaxSize()
+}
+
+func (l *leaseBox[T]) IsFull() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.expire()
+	return l.box.IsFull()
+}
+
+func (l *leaseBox[T]) IsEmpty() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.expire()
+	return l.box.IsEmpty()
+}
+
+// Clean removes all items, including in-flight ones; their leases become unknown.
+func (l *leaseBox[T]) Clean() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.leases = nil
+	l.waker.stop()
+	l.box.Clean()
+}
+
+func (l *leaseBox[T]) Items() []T {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.expire()
+	return l.box.Items()
+}
+
+func (l *leaseBox[T]) mutatesOnRead() bool {
+	return true
+}
+
+// Compile-time assertion that leaseBox implements BlackBox[T].
+var _ BlackBox[any] = (*leaseBox[any])(nil)
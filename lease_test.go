@@ -0,0 +1,171 @@
+package blackbox
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLeaseAckAndRedelivery(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	box := NewLeasing[int](NewFIFO[int](0, 4), LeaseConfig{Timeout: time.Minute, Clock: clock})
+	for i := 1; i <= 3; i++ {
+		box.Put(i)
+	}
+
+	first, lease1, err := box.GetLease()
+	if err != nil || first != 1 {
+		t.Fatalf("Expected item 1, got %d (%v)", first, err)
+	}
+	second, lease2, _ := box.GetLease()
+	if second != 2 {
+		t.Fatalf("Expected item 2, got %d", second)
+	}
+	if lease1.ID == lease2.ID {
+		t.Errorf("Expected distinct lease IDs, got %d twice", lease1.ID)
+	}
+	if box.InFlight() != 2 || box.Size() != 1 {
+		t.Fatalf("Expected 2 in flight and 1 available, got %d and %d", box.InFlight(), box.Size())
+	}
+
+	if err := box.Ack(lease1); err != nil {
+		t.Errorf("Expected Ack to succeed, got %v", err)
+	}
+	if err := box.Ack(lease1); err != ErrUnknownLease {
+		t.Errorf("Expected ErrUnknownLease on double Ack, got %v", err)
+	}
+
+	clock.Advance(time.Minute)
+	if box.InFlight() != 0 {
+		t.Errorf("Expected the expired lease to be redelivered, got %d in flight", box.InFlight())
+	}
+	if !EqualInts(box.Items(), []int{3, 2}) {
+		t.Errorf("Expected items [3 2], got %v", box.Items())
+	}
+	if err := box.Ack(lease2); err != ErrUnknownLease {
+		t.Errorf("Expected ErrUnknownLease after expiry, got %v", err)
+	}
+}
+
+func TestLeaseNack(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	box := NewLeasing[int](NewFIFO[int](1, 1), LeaseConfig{Timeout: time.Minute, Clock: clock})
+	box.Put(1)
+
+	_, lease, _ := box.GetLease()
+	box.Put(2)
+	if err := box.Nack(lease); err != ErrBlackBoxFull {
+		t.Errorf("Expected ErrBlackBoxFull when the box is full, got %v", err)
+	}
+	if box.InFlight() != 1 {
+		t.Errorf("Expected the item to stay in flight, got %d", box.InFlight())
+	}
+
+	box.Get()
+	if err := box.Nack(lease); err != nil {
+		t.Errorf("Expected Nack to succeed, got %v", err)
+	}
+	if item, _ := box.Get(); item != 1 {
+		t.Errorf("Expected nacked item 1 to be available again, got %d", item)
+	}
+
+	box.Put(3)
+	_, lease, _ = box.GetLease()
+	box.Put(4)
+	clock.Advance(2 * time.Minute)
+	if box.InFlight() != 1 {
+		t.Errorf("Expected the expired item to wait for space, got %d in flight", box.InFlight())
+	}
+	box.Get()
+	if box.InFlight() != 0 || box.Size() != 1 {
+		t.Errorf("Expected the expired item to be redelivered once space is free, got %d in flight", box.InFlight())
+	}
+
+	box.Clean()
+	if err := box.Ack(lease); err != ErrUnknownLease {
+		t.Errorf("Expected ErrUnknownLease after Clean, got %v", err)
+	}
+}
+
+func TestLeaseConcurrentWorkers(t *testing.T) {
+	box := NewLeasing[int](NewFIFO[int](0, 8), LeaseConfig{Timeout: time.Hour})
+	for i := 0; i < 1000; i++ {
+		box.Put(i)
+	}
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	acked := make(map[int]bool)
+	nacked := make(map[int]bool)
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for {
+				item, lease, err := box.GetLease()
+				if err != nil {
+					return
+				}
+				// Half of the workers give odd items back once.
+				if item%2 == 1 && w%2 == 0 {
+					mu.Lock()
+					retried := nacked[item]
+					nacked[item] = true
+					mu.Unlock()
+					if !retried {
+						if err := box.Nack(lease); err != nil {
+							t.Errorf("Failed to nack item %d: %v", item, err)
+						}
+						continue
+					}
+				}
+				if err := box.Ack(lease); err != nil {
+					t.Errorf("Failed to ack item %d: %v", item, err)
+				}
+				mu.Lock()
+				if acked[item] {
+					t.Errorf("Item %d acknowledged twice", item)
+				}
+				acked[item] = true
+				mu.Unlock()
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	if len(acked) != 1000 || box.InFlight() != 0 || !box.IsEmpty() {
+		t.Errorf("Expected 1000 acknowledged items, got %d with %d in flight", len(acked), box.InFlight())
+	}
+}
+
+func TestLeaseWakesBlockedConsumer(t *testing.T) {
+	leasing := NewLeasing[int](NewFIFO[int](0, 4), LeaseConfig{Timeout: 10 * time.Millisecond})
+	box := NewConcurrent[int](leasing)
+	box.Put(1)
+	if _, _, err := leasing.GetLease(); err != nil {
+		t.Fatalf("Expected a lease, got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	start := time.Now()
+	if err := box.WaitNotEmpty(ctx); err != nil {
+		t.Fatalf("Expected WaitNotEmpty to return, got %v", err)
+	}
+	item, err := box.GetCtx(ctx)
+	if err != nil || item != 1 {
+		t.Fatalf("Expected the redelivered item, got %d (%v)", item, err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the consumer to wake up when the lease expired, took %v", elapsed)
+	}
+
+	// A blocked GetCtx wakes up as well.
+	box.Put(2)
+	if _, _, err := leasing.GetLease(); err != nil {
+		t.Fatalf("Expected a lease, got %v", err)
+	}
+	if item, err := box.GetCtx(ctx); err != nil || item != 2 {
+		t.Errorf("Expected the redelivered item, got %d (%v)", item, err)
+	}
+}
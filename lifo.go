@@ -1,8 +1,11 @@
 package blackbox
 
 type lifoBox[T any] struct {
-	items   []T
-	maxSize int
+	items    []T
+	maxSize  int
+	overflow OverflowPolicy
+	onEvict  func(item T)
+	name     string // see WithName
 }
 
 // NewLIFO creates a new LIFO blackbox with the specified maximum size and capacity.
@@ -40,9 +43,47 @@ func NewLIFOFromBlackBox[T any](box BlackBox[T], maxSize int) *lifoBox[T] {
 	}
 }
 
+// NewLIFODropOldest creates a new bounded LIFO blackbox that keeps the last
+// maxSize items: once full, Put drops the oldest item at the bottom of the
+// stack instead of rejecting the new one, e.g. to keep the last 10 commands
+// of an undo history. Like New with StrategyLIFO and OverflowDropOldest.
+// Returns a concrete instance of lifo blackbox without interface.
+func NewLIFODropOldest[T any](maxSize, capacity int) *lifoBox[T] {
+	b := NewLIFO[T](maxSize, capacity)
+	b.overflow = OverflowDropOldest
+	return b
+}
+
+// removeBottom removes and returns the oldest item at the bottom of the stack.
+// The slice is advanced rather than shifted, so that a full box evicting on
+// every Put costs amortized O(1): append reallocates once the capacity
+// behind the items is used up.
+func (b *lifoBox[T]) removeBottom() T {
+	item := b.items[0]
+	var zero T
+	b.items[0] = zero
+	b.items = b.items[1:]
+	return item
+}
+
+// evict reports an item removed by the box itself to the evict callback.
+func (b *lifoBox[T]) evict(item T) {
+	if b.onEvict != nil {
+		b.onEvict(item)
+	}
+}
+
 func (b *lifoBox[T]) Put(item T) error {
 	if b.maxSize > 0 && len(b.items) >= b.maxSize {
-		return ErrBlackBoxFull
+		switch b.overflow {
+		case OverflowDropOldest:
+			b.evict(b.removeBottom())
+		case OverflowDropNewest:
+			b.evict(item)
+			return nil
+		default:
+			return fullError(b.name, len(b.items), b.maxSize)
+		}
 	}
 	b.items = append(b.items, item)
 	return nil
@@ -51,10 +92,12 @@ func (b *lifoBox[T]) Put(item T) error {
 func (b *lifoBox[T]) Get() (T, error) {
 	if len(b.items) == 0 {
 		var zero T
-		return zero, ErrEmptyBlackBox
+		return zero, emptyError(b.name)
 	}
 	lastIdx := len(b.items) - 1
 	item := b.items[lastIdx]
+	var zero T
+	b.items[lastIdx] = zero
 	b.items = b.items[:lastIdx]
 	return item, nil
 }
@@ -62,11 +105,31 @@ func (b *lifoBox[T]) Get() (T, error) {
 func (b *lifoBox[T]) Peek() (T, error) {
 	if len(b.items) == 0 {
 		var zero T
-		return zero, ErrEmptyBlackBox
+		return zero, emptyError(b.name)
 	}
 	return b.items[len(b.items)-1], nil
 }
 
+// removeFunc removes every item for which pred returns true, keeping the
+// order of the remaining items, and returns the removed items.
+func (b *lifoBox[T]) removeFunc(pred func(item T) bool) []T {
+	var removed []T
+	kept := b.items[:0]
+	for _, item := range b.items {
+		if pred(item) {
+			removed = append(removed, item)
+			continue
+		}
+		kept = append(kept, item)
+	}
+	var zero T
+	for i := len(kept); i < len(b.items); i++ {
+		b.items[i] = zero
+	}
+	b.items = kept
+	return removed
+}
+
 func (b *lifoBox[T]) Size() int {
 	return len(b.items)
 }
@@ -84,6 +147,15 @@ func (b *lifoBox[T]) IsEmpty() bool {
 }
 
 func (b *lifoBox[T]) Clean() {
+	if b.onEvict != nil {
+		for _, item := range b.items {
+			b.onEvict(item)
+		}
+	}
+	var zero T
+	for i := range b.items {
+		b.items[i] = zero
+	}
 	b.items = b.items[:0]
 }
 
@@ -0,0 +1,156 @@
+package blackbox
+
+import (
+	"sync/atomic"
+	"unsafe"
+)
+
+// lockFreeNode is an element of the linked stack of lockFreeLIFO.
+type lockFreeNode[T any] struct {
+	item T
+	next *lockFreeNode[T]
+}
+
+// lockFreeLIFO is a goroutine-safe LIFO blackbox implemented as a Treiber
+// stack: Put and Get swap the top of a linked stack with atomic CAS instead
+// of taking a lock. Popped nodes are never reused, so the garbage collector
+// rules out the ABA problem.
+type lockFreeLIFO[T any] struct {
+	// size comes first so that it is 64-bit aligned on 32-bit platforms,
+	// as atomic operations require.
+	size    int64
+	top     unsafe.Pointer // *lockFreeNode[T]
+	maxSize int
+	// dropNewest discards items put into a full box instead of returning
+	// ErrBlackBoxFull.
+	dropNewest bool
+	onEvict    func(item T)
+	name       string // see WithName
+}
+
+// NewLockFreeLIFO creates a new lock-free LIFO blackbox with the specified
+// maximum size. Unlike the other boxes it is safe for concurrent use without
+// NewConcurrent, which makes it a good fit for high-contention producer and
+// consumer workloads. Size may briefly count an item whose Put is still in
+// progress.
+// Returns a concrete instance of lock-free lifo blackbox without interface.
+func NewLockFreeLIFO[T any](maxSize int) *lockFreeLIFO[T] {
+	return &lockFreeLIFO[T]{maxSize: maxSize}
+}
+
+// NewLockFreeLIFOFrom creates a new lock-free LIFO blackbox from a slice of
+// items with the specified maximum size.
+func NewLockFreeLIFOFrom[T any](items []T, maxSize int) *lockFreeLIFO[T] {
+	if maxSize > 0 && maxSize < len(items) {
+		maxSize = len(items)
+	}
+	b := NewLockFreeLIFO[T](maxSize)
+	for _, item := range items {
+		b.Put(item)
+	}
+	return b
+}
+
+func (b *lockFreeLIFO[T]) load() *lockFreeNode[T] {
+	return (*lockFreeNode[T])(atomic.LoadPointer(&b.top))
+}
+
+// reserve claims room for one item, reporting false if the box is full.
+func (b *lockFreeLIFO[T]) reserve() bool {
+	for {
+		size := atomic.LoadInt64(&b.size)
+		if b.maxSize > 0 && size >= int64(b.maxSize) {
+			return false
+		}
+		if atomic.CompareAndSwapInt64(&b.size, size, size+1) {
+			return true
+		}
+	}
+}
+
+func (b *lockFreeLIFO[T]) Put(item T) error {
+	if !b.reserve() {
+		if b.dropNewest {
+			if b.onEvict != nil {
+				b.onEvict(item)
+			}
+			return nil
+		}
+		return fullError(b.name, b.Size(), b.maxSize)
+	}
+	node := &lockFreeNode[T]{item: item}
+	for {
+		top := b.load()
+		node.next = top
+		if atomic.CompareAndSwapPointer(&b.top, unsafe.Pointer(top), unsafe.Pointer(node)) {
+			return nil
+		}
+	}
+}
+
+func (b *lockFreeLIFO[T]) Get() (T, error) {
+	for {
+		top := b.load()
+		if top == nil {
+			var zero T
+			return zero, emptyError(b.name)
+		}
+		if atomic.CompareAndSwapPointer(&b.top, unsafe.Pointer(top), unsafe.Pointer(top.next)) {
+			atomic.AddInt64(&b.size, -1)
+			return top.item, nil
+		}
+	}
+}
+
+func (b *lockFreeLIFO[T]) Peek() (T, error) {
+	top := b.load()
+	if top == nil {
+		var zero T
+		return zero, emptyError(b.name)
+	}
+	return top.item, nil
+}
+
+func (b *lockFreeLIFO[T]) Size() int {
+	return int(atomic.LoadInt64(&b.size))
+}
+
+func (b *lockFreeLIFO[T]) MaxSize() int {
+	return b.maxSize
+}
+
+func (b *lockFreeLIFO[T]) IsFull() bool {
+	return b.maxSize > 0 && b.Size() >= b.maxSize
+}
+
+func (b *lockFreeLIFO[T]) IsEmpty() bool {
+	return b.load() == nil
+}
+
+// Clean atomically detaches every item, passing them to the evict callback.
+func (b *lockFreeLIFO[T]) Clean() {
+	node := (*lockFreeNode[T])(atomic.SwapPointer(&b.top, nil))
+	var n int64
+	for ; node != nil; node = node.next {
+		if b.onEvict != nil {
+			b.onEvict(node.item)
+		}
+		n++
+	}
+	atomic.AddInt64(&b.size, -n)
+}
+
+// Items returns a snapshot of the items from the bottom to the top of the stack.
+func (b *lockFreeLIFO[T]) Items() []T {
+	items := make([]T, 0)
+	for node := b.load(); node != nil; node = node.next {
+		items = append(items, node.item)
+	}
+	for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+		items[i], items[j] = items[j], items[i]
+	}
+	return items
+}
+
+// Compile-time assertion that lockFreeLIFO implements BlackBox[T].
+var _ BlackBox[any] = (*lockFreeLIFO[any])(nil)
@@ -0,0 +1,112 @@
+package blackbox
+
+import (
+	"sync"
+	"testing"
+	"time"
+	"unsafe"
+)
+
+func TestLockFreeLIFO(t *testing.T) {
+	box := New[int](WithStrategy(StrategyLIFO), WithLockFree(), WithMaxSize(3))
+	if _, ok := box.(*lockFreeLIFO[int]); !ok {
+		t.Fatalf("Expected a lock-free LIFO, got %T", box)
+	}
+	for i := 1; i <= 3; i++ {
+		if err := box.Put(i); err != nil {
+			t.Fatalf("Failed to put item %d: %v", i, err)
+		}
+	}
+	if err := box.Put(4); err != ErrBlackBoxFull {
+		t.Errorf("Expected ErrBlackBoxFull, got %v", err)
+	}
+	if !box.IsFull() || !EqualInts(box.Items(), []int{1, 2, 3}) {
+		t.Errorf("Expected a full box with [1 2 3], got %v", box.Items())
+	}
+	if item, _ := box.Peek(); item != 3 {
+		t.Errorf("Expected Peek to return 3, got %d", item)
+	}
+	if item, _ := box.Get(); item != 3 || box.Size() != 2 {
+		t.Errorf("Expected Get to return 3 leaving 2 items, got %d and %d", item, box.Size())
+	}
+
+	box.Clean()
+	if !box.IsEmpty() || box.Size() != 0 {
+		t.Errorf("Expected empty box, got size %d", box.Size())
+	}
+	if _, err := box.Get(); err != ErrEmptyBlackBox {
+		t.Errorf("Expected ErrEmptyBlackBox, got %v", err)
+	}
+
+	var evicted []int
+	dropping := NewFrom[int]([]int{1, 2}, WithStrategy(StrategyLIFO), WithLockFree(), WithMaxSize(2),
+		WithOverflowPolicy(OverflowDropNewest), WithEvictCallback(func(item int) { evicted = append(evicted, item) }))
+	if err := dropping.Put(3); err != nil || !EqualInts(evicted, []int{3}) {
+		t.Errorf("Expected item 3 to be dropped, got %v (%v)", evicted, err)
+	}
+
+	oldest := New[int](WithStrategy(StrategyLIFO), WithLockFree(), WithOverflowPolicy(OverflowDropOldest))
+	if _, ok := oldest.(*lifoBox[int]); !ok {
+		t.Errorf("Expected OverflowDropOldest to keep the regular LIFO, got %T", oldest)
+	}
+}
+
+func TestLockFreeLIFOConcurrent(t *testing.T) {
+	box := NewLockFreeLIFO[int](0)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	seen := make(map[int]bool)
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				box.Put(g*500 + i)
+				if item, err := box.Get(); err == nil {
+					mu.Lock()
+					if seen[item] {
+						t.Errorf("Item %d retrieved twice", item)
+					}
+					seen[item] = true
+					mu.Unlock()
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	if len(seen)+box.Size() != 4000 {
+		t.Errorf("Expected 4000 items in total, got %d", len(seen)+box.Size())
+	}
+}
+
+func TestLockFreeWithTTL(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	box := New[int](WithStrategy(StrategyLIFO), WithLockFree(), WithTTL(time.Minute), WithClock(clock))
+	box.Put(1)
+	clock.Advance(30 * time.Second)
+	box.Put(2)
+	clock.Advance(30 * time.Second)
+	if items := box.Items(); !EqualInts(items, []int{2}) {
+		t.Errorf("Expected [2], got %v", items)
+	}
+	if item, err := box.Get(); err != nil || item != 2 {
+		t.Errorf("Expected 2, got %d (%v)", item, err)
+	}
+
+	retained := New[int](WithStrategy(StrategyLIFO), WithLockFree(), WithRetention(time.Minute), WithClock(clock))
+	retained.Put(1)
+	clock.Advance(time.Minute)
+	if !retained.IsEmpty() {
+		t.Errorf("Expected the item to be dropped, got %v", retained.Items())
+	}
+}
+
+func TestLockFreeLIFOAlignment(t *testing.T) {
+	// 64-bit atomics need 64-bit alignment on 32-bit platforms, which Go only
+	// guarantees for the first word of an allocated struct.
+	var b lockFreeLIFO[int]
+	if off := unsafe.Offsetof(b.size); off != 0 {
+		t.Errorf("Expected size to be the first field, got offset %d", off)
+	}
+}
@@ -0,0 +1,82 @@
+package blackbox
+
+import (
+	"errors"
+	"math/rand"
+)
+
+// LootTier is a tier of a loot table: a sub-box of items rolled with a
+// probability proportional to Weight.
+type LootTier[T any] struct {
+	Name   string
+	Weight float64
+	// Box holds the items of the tier. Roll takes its items with Get, so a
+	// Random box draws them at random and a tier runs dry once emptied.
+	Box BlackBox[T]
+}
+
+// lootTable rolls a tier by weight, then an item from the tier's sub-box.
+type lootTable[T any] struct {
+	tiers   []LootTier[T]
+	weights []float64
+	rng     *rand.Rand
+}
+
+// NewLootTable creates a new loot table from tiers listed from the rarest to
+// the most common, e.g. legendary, epic, rare, common. Roll picks a tier with
+// a probability proportional to its weight; when that tier is empty, the roll
+// falls through to the next, more common tier that still has items.
+// Tiers are drawn from rng, or from a time-seeded RNG if rng is nil.
+// Returns a concrete instance of loot table.
+func NewLootTable[T any](rng *rand.Rand, tiers ...LootTier[T]) *lootTable[T] {
+	if rng == nil {
+		rng, _ = config{}.rng()
+	}
+	weights := make([]float64, len(tiers))
+	for i, tier := range tiers {
+		weights[i] = tier.Weight
+	}
+	return &lootTable[T]{tiers: tiers, weights: weights, rng: rng}
+}
+
+// Roll picks a tier, then takes an item from it, and returns the item along
+// with the name of the tier it came from. It returns ErrEmptyBlackBox when
+// the rolled tier and all the tiers after it are empty, or when no tier has
+// a positive weight.
+func (l *lootTable[T]) Roll() (item T, tier string, err error) {
+	idx, ok := pickWeighted(l.rng, l.weights)
+	if !ok {
+		return item, "", ErrEmptyBlackBox
+	}
+	for _, t := range l.tiers[idx:] {
+		if t.Box.Size() == 0 {
+			continue
+		}
+		if item, err = t.Box.Get(); err == nil {
+			return item, t.Name, nil
+		}
+		if !errors.Is(err, ErrEmptyBlackBox) {
+			return item, t.Name, err
+		}
+	}
+	return item, "", ErrEmptyBlackBox
+}
+
+// Tier returns the sub-box of the named tier, e.g. to restock it.
+func (l *lootTable[T]) Tier(name string) (BlackBox[T], bool) {
+	for _, t := range l.tiers {
+		if t.Name == name {
+			return t.Box, true
+		}
+	}
+	return nil, false
+}
+
+// Size returns the number of items left in all tiers.
+func (l *lootTable[T]) Size() int {
+	size := 0
+	for _, t := range l.tiers {
+		size += t.Box.Size()
+	}
+	return size
+}
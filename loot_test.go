@@ -0,0 +1,69 @@
+package blackbox
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestLootTableRoll(t *testing.T) {
+	table := NewLootTable(rand.New(rand.NewSource(1)),
+		LootTier[string]{Name: "rare", Weight: 1, Box: NewFIFOFrom([]string{"r"}, 0)},
+		LootTier[string]{Name: "common", Weight: 9, Box: NewFIFOFrom([]string{"c", "c", "c", "c", "c", "c", "c", "c", "c"}, 0)},
+	)
+	if table.Size() != 10 {
+		t.Fatalf("Expected size 10, got %d", table.Size())
+	}
+	counts := map[string]int{}
+	for i := 0; i < 10; i++ {
+		item, tier, err := table.Roll()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if item[0] != tier[0] {
+			t.Errorf("Expected item %s to come from tier %s", item, tier)
+		}
+		counts[tier]++
+	}
+	if counts["rare"] != 1 || counts["common"] != 9 {
+		t.Errorf("Expected every item to be rolled once, got %v", counts)
+	}
+	if _, _, err := table.Roll(); err != ErrEmptyBlackBox {
+		t.Errorf("Expected ErrEmptyBlackBox, got %v", err)
+	}
+}
+
+func TestLootTableFallthrough(t *testing.T) {
+	table := NewLootTable(rand.New(rand.NewSource(1)),
+		LootTier[int]{Name: "legendary", Weight: 1, Box: NewFIFO[int](0, 0)},
+		LootTier[int]{Name: "common", Weight: 0, Box: NewFIFOFrom([]int{1, 2}, 0)},
+	)
+	// The legendary tier is always rolled but empty, so rolls fall through.
+	for _, want := range []int{1, 2} {
+		item, tier, err := table.Roll()
+		if err != nil || item != want || tier != "common" {
+			t.Errorf("Expected %d from common, got %d from %q (%v)", want, item, tier, err)
+		}
+	}
+	if _, _, err := table.Roll(); err != ErrEmptyBlackBox {
+		t.Errorf("Expected ErrEmptyBlackBox, got %v", err)
+	}
+
+	legendary, ok := table.Tier("legendary")
+	if !ok {
+		t.Fatal("Expected legendary tier")
+	}
+	legendary.Put(7)
+	if item, tier, _ := table.Roll(); item != 7 || tier != "legendary" {
+		t.Errorf("Expected restocked 7 from legendary, got %d from %q", item, tier)
+	}
+	if _, ok := table.Tier("missing"); ok {
+		t.Error("Expected no tier named missing")
+	}
+}
+
+func TestLootTableNoWeights(t *testing.T) {
+	table := NewLootTable[int](nil, LootTier[int]{Name: "a", Box: NewFIFOFrom([]int{1}, 0)})
+	if _, _, err := table.Roll(); err != ErrEmptyBlackBox {
+		t.Errorf("Expected ErrEmptyBlackBox, got %v", err)
+	}
+}
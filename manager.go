@@ -0,0 +1,239 @@
+package blackbox
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrQuotaExceeded is returned by Put on a box of a Manager when the box or
+// the manager as a whole holds as many items as its quota allows.
+var ErrQuotaExceeded = errors.New("blackbox quota exceeded")
+
+// ManagerConfig configures the registry returned by NewManager.
+type ManagerConfig struct {
+	// Options configure every box the manager creates.
+	Options []Option
+	// IdleTimeout is how long an empty box must have gone without being
+	// looked up before Collect removes it. Zero removes every empty box.
+	IdleTimeout time.Duration
+	// Clock is used to measure IdleTimeout. When nil, the wall clock is used.
+	Clock Clock
+	// MaxSizes limits the number of items of the boxes with the given names,
+	// e.g. per tenant plan. Other boxes are only limited by Options.
+	MaxSizes map[string]int
+	// MaxTotal limits the number of items across all boxes, so one tenant
+	// cannot consume the entire memory budget. Zero means unlimited.
+	MaxTotal int
+}
+
+type managedEntry[T any] struct {
+	box      BlackBox[T]
+	quota    *quotaBox[T]
+	lastUsed time.Time
+}
+
+// quotaBox enforces the quotas of a Manager on one of its boxes. It is
+// wrapped with NewConcurrent, so its methods are serialized.
+type quotaBox[T any] struct {
+	box   BlackBox[T]
+	limit int
+	total *int64 // items across the boxes of the manager
+	// counted is the number of items of the box included in total.
+	counted int64
+	max     int
+}
+
+// track runs fn and accounts for the change of the size of the box, which
+// also picks up items that expired since the last call. reserved is the
+// number of items already added to the total for fn.
+func (q *quotaBox[T]) track(reserved int64, fn func()) {
+	fn()
+	size := int64(q.box.Size())
+	atomic.AddInt64(q.total, size-atomic.LoadInt64(&q.counted)-reserved)
+	atomic.StoreInt64(&q.counted, size)
+}
+
+func (q *quotaBox[T]) Put(item T) (err error) {
+	if q.limit > 0 && q.box.Size() >= q.limit {
+		return ErrQuotaExceeded
+	}
+	var reserved int64
+	if q.max > 0 {
+		// Reserve room for the item, so concurrent puts into other boxes
+		// cannot both take the last slot.
+		if atomic.AddInt64(q.total, 1) > int64(q.max) {
+			atomic.AddInt64(q.total, -1)
+			return ErrQuotaExceeded
+		}
+		reserved = 1
+	}
+	q.track(reserved, func() { err = q.box.Put(item) })
+	return err
+}
+
+func (q *quotaBox[T]) Get() (item T, err error) {
+	q.track(0, func() { item, err = q.box.Get() })
+	return item, err
+}
+
+func (q *quotaBox[T]) Peek() (T, error) {
+	return q.box.Peek()
+}
+
+func (q *quotaBox[T]) Size() int {
+	return q.box.Size()
+}
+
+func (q *quotaBox[T]) MaxSize() int {
+	return q.box.MaxSize()
+}
+
+func (q *quotaBox[T]) IsFull() bool {
+	return q.box.IsFull()
+}
+
+func (q *quotaBox[T]) IsEmpty() bool {
+	return q.box.IsEmpty()
+}
+
+func (q *quotaBox[T]) Clean() {
+	q.track(0, q.box.Clean)
+}
+
+func (q *quotaBox[T]) Items() []T {
+	return q.box.Items()
+}
+
+func (q *quotaBox[T]) forEach(fn func(item T) bool) {
+	eachItem(q.box, fn)
+}
+
+func (q *quotaBox[T]) mutatesOnRead() bool {
+	return mutatesOnRead(q.box)
+}
+
+func (q *quotaBox[T]) mutatesOnPeek() bool {
+	return mutatesOnPeek(q.box)
+}
+
+func (q *quotaBox[T]) drawPlan() drawPlan[T] {
+	return planOf(q.box)
+}
+
+// Manager holds named boxes, e.g. one queue per tenant in a multi-tenant
+// service. Boxes are created on first use with the shared options and are
+// goroutine-safe, as is the manager itself.
+type Manager[T any] struct {
+	mu    sync.Mutex
+	cfg   ManagerConfig
+	boxes map[string]*managedEntry[T]
+	total int64
+}
+
+// NewManager creates an empty registry of named boxes configured by cfg.
+func NewManager[T any](cfg ManagerConfig) *Manager[T] {
+	if cfg.Clock == nil {
+		cfg.Clock = systemClock{}
+	}
+	return &Manager[T]{cfg: cfg, boxes: make(map[string]*managedEntry[T])}
+}
+
+// Get returns the box with the given name, creating it with the options of
+// the manager if it does not exist yet. The box is wrapped with
+// NewConcurrent and its Put returns ErrQuotaExceeded when the quotas of the
+// manager are reached. Do not keep it across calls to Delete or Collect: once
+// removed, a later Get returns a new box.
+func (m *Manager[T]) Get(name string) BlackBox[T] {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.boxes[name]
+	if !ok {
+		q := &quotaBox[T]{
+			box:   New[T](m.cfg.Options...),
+			limit: m.cfg.MaxSizes[name],
+			total: &m.total,
+			max:   m.cfg.MaxTotal,
+		}
+		q.track(0, func() {})
+		e = &managedEntry[T]{box: NewConcurrent[T](q), quota: q}
+		m.boxes[name] = e
+	}
+	e.lastUsed = m.cfg.Clock.Now()
+	return e.box
+}
+
+// Lookup returns the box with the given name without creating it.
+func (m *Manager[T]) Lookup(name string) (BlackBox[T], bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.boxes[name]
+	if !ok {
+		return nil, false
+	}
+	e.lastUsed = m.cfg.Clock.Now()
+	return e.box, true
+}
+
+// Delete removes the box with the given name, along with its items, and
+// reports whether it existed.
+func (m *Manager[T]) Delete(name string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.boxes[name]
+	if ok {
+		m.remove(name, e)
+	}
+	return ok
+}
+
+// remove drops a box and its items from the total. The caller must hold m.mu.
+func (m *Manager[T]) remove(name string, e *managedEntry[T]) {
+	delete(m.boxes, name)
+	atomic.AddInt64(&m.total, -atomic.LoadInt64(&e.quota.counted))
+}
+
+// Total returns the number of items across all boxes, as counted for
+// MaxTotal. Expired items are only uncounted by the next Put, Get or Clean on
+// their box.
+func (m *Manager[T]) Total() int {
+	return int(atomic.LoadInt64(&m.total))
+}
+
+// Names returns the names of the boxes in ascending order.
+func (m *Manager[T]) Names() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	names := make([]string, 0, len(m.boxes))
+	for name := range m.boxes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Len returns the number of boxes.
+func (m *Manager[T]) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.boxes)
+}
+
+// Collect removes the empty boxes that have not been looked up for
+// IdleTimeout and returns how many were removed. Call it periodically, e.g.
+// from a time.Ticker, to release the boxes of inactive tenants.
+func (m *Manager[T]) Collect() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.cfg.Clock.Now()
+	removed := 0
+	for name, e := range m.boxes {
+		if now.Sub(e.lastUsed) >= m.cfg.IdleTimeout && e.box.IsEmpty() {
+			m.remove(name, e)
+			removed++
+		}
+	}
+	return removed
+}
@@ -0,0 +1,103 @@
+package blackbox
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestManager(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	m := NewManager[int](ManagerConfig{
+		Options:     []Option{WithStrategy(StrategyFIFO), WithMaxSize(2)},
+		IdleTimeout: time.Minute,
+		Clock:       clock,
+	})
+
+	box := m.Get("tenant-42")
+	if m.Get("tenant-42") != box {
+		t.Fatal("Expected Get to return the same box")
+	}
+	if box.MaxSize() != 2 {
+		t.Errorf("Expected the shared options to apply, got max size %d", box.MaxSize())
+	}
+	if _, ok := m.Lookup("tenant-7"); ok {
+		t.Error("Expected Lookup not to create a box")
+	}
+	box.Put(1)
+	m.Get("tenant-7")
+	m.Get("tenant-1")
+	if names := m.Names(); len(names) != 3 || names[0] != "tenant-1" || names[2] != "tenant-7" {
+		t.Errorf("Expected sorted names, got %v", names)
+	}
+
+	clock.Advance(30 * time.Second)
+	m.Get("tenant-7")
+	clock.Advance(30 * time.Second)
+	// tenant-42 holds an item and tenant-7 was used 30s ago.
+	if removed := m.Collect(); removed != 1 {
+		t.Errorf("Expected 1 box to be collected, got %d", removed)
+	}
+	if _, ok := m.Lookup("tenant-1"); ok {
+		t.Error("Expected tenant-1 to be collected")
+	}
+	if !m.Delete("tenant-42") || m.Delete("tenant-42") {
+		t.Error("Expected tenant-42 to be deleted once")
+	}
+	if m.Len() != 1 {
+		t.Errorf("Expected 1 box, got %d", m.Len())
+	}
+}
+
+func TestManagerQuotas(t *testing.T) {
+	m := NewManager[int](ManagerConfig{
+		Options:  []Option{WithStrategy(StrategyFIFO)},
+		MaxSizes: map[string]int{"free": 1},
+		MaxTotal: 3,
+	})
+	free, paid := m.Get("free"), m.Get("paid")
+
+	free.Put(1)
+	if err := free.Put(2); !errors.Is(err, ErrQuotaExceeded) {
+		t.Errorf("Expected ErrQuotaExceeded for the free tenant, got %v", err)
+	}
+	paid.Put(1)
+	paid.Put(2)
+	if err := paid.Put(3); !errors.Is(err, ErrQuotaExceeded) {
+		t.Errorf("Expected ErrQuotaExceeded for the total, got %v", err)
+	}
+	if m.Total() != 3 {
+		t.Errorf("Expected 3 items in total, got %d", m.Total())
+	}
+
+	paid.Get()
+	if err := paid.Put(3); err != nil {
+		t.Errorf("Expected room after Get, got %v", err)
+	}
+	m.Delete("free")
+	paid.Clean()
+	if m.Total() != 0 {
+		t.Errorf("Expected no items in total, got %d", m.Total())
+	}
+}
+
+func TestManagerQuotaWithTTL(t *testing.T) {
+	m := NewManager[int](ManagerConfig{
+		Options:  []Option{WithStrategy(StrategyFIFO), WithTTL(time.Hour)},
+		MaxTotal: 1,
+	})
+	box := m.Get("a")
+	timed := box.(*concurrentBox[int]).box.(*quotaBox[int]).box.(*timedBox[int])
+	clock := &fakeClock{now: time.Now()}
+	timed.clock = clock
+
+	box.Put(1)
+	clock.Advance(2 * time.Hour)
+	if err := m.Get("b").Put(2); !errors.Is(err, ErrQuotaExceeded) {
+		t.Errorf("Expected the expired item to count until its box is used, got %v", err)
+	}
+	box.Get()
+	if err := m.Get("b").Put(2); err != nil {
+		t.Errorf("Expected the expired item to be uncounted, got %v", err)
+	}
+}
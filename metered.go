@@ -0,0 +1,138 @@
+package blackbox
+
+import (
+	"expvar"
+	"sync"
+	"sync/atomic"
+)
+
+// Metrics holds the counters of a metered blackbox.
+type Metrics struct {
+	Size     int   `json:"size"`
+	MaxSize  int   `json:"maxSize"`
+	Puts     int64 `json:"puts"`     // successful Put calls
+	Gets     int64 `json:"gets"`     // successful Get calls
+	Rejected int64 `json:"rejected"` // Put calls that returned an error
+}
+
+// meteredBox counts the operations on any BlackBox[T]. Counters are updated
+// atomically, so they can be read from other goroutines, e.g. by expvar,
+// while the box is used.
+type meteredBox[T any] struct {
+	box      BlackBox[T]
+	size     int64
+	maxSize  int64
+	puts     int64
+	gets     int64
+	rejected int64
+
+	// clock, mu, history and nextSample keep the samples of Stats.
+	clock      Clock
+	mu         sync.Mutex
+	history    []statsSample
+	head       int   // index of the oldest sample once history is full
+	nextSample int64 // UnixNano of the next sample
+}
+
+// NewMetered wraps box to count its operations, see Metrics and PublishExpvar.
+// It does not make box goroutine-safe; wrap a box from NewConcurrent for that.
+// Only WithClock is used from opts, for the samples of Stats.
+// Returns a concrete instance of metered blackbox.
+func NewMetered[T any](box BlackBox[T], opts ...Option) *meteredBox[T] {
+	m := &meteredBox[T]{box: box, clock: parseOptions(opts).now()}
+	atomic.StoreInt64(&m.size, int64(box.Size()))
+	atomic.StoreInt64(&m.maxSize, int64(box.MaxSize()))
+	return m
+}
+
+// sync records the current size of the box for readers on other goroutines.
+func (m *meteredBox[T]) sync() {
+	atomic.StoreInt64(&m.size, int64(m.box.Size()))
+	atomic.StoreInt64(&m.maxSize, int64(m.box.MaxSize()))
+	m.sample()
+}
+
+// Metrics returns a snapshot of the counters. It is safe to call concurrently
+// with the other methods.
+func (m *meteredBox[T]) Metrics() Metrics {
+	return Metrics{
+		Size:     int(atomic.LoadInt64(&m.size)),
+		MaxSize:  int(atomic.LoadInt64(&m.maxSize)),
+		Puts:     atomic.LoadInt64(&m.puts),
+		Gets:     atomic.LoadInt64(&m.gets),
+		Rejected: atomic.LoadInt64(&m.rejected),
+	}
+}
+
+// PublishExpvar publishes the Metrics of the box under name with expvar, so
+// they show up in /debug/vars. Like expvar.Publish, it panics if name is
+// already in use.
+func (m *meteredBox[T]) PublishExpvar(name string) {
+	expvar.Publish(name, expvar.Func(func() any {
+		return m.Metrics()
+	}))
+}
+
+func (m *meteredBox[T]) Put(item T) error {
+	err := m.box.Put(item)
+	if err != nil {
+		atomic.AddInt64(&m.rejected, 1)
+	} else {
+		atomic.AddInt64(&m.puts, 1)
+	}
+	m.sync()
+	return err
+}
+
+func (m *meteredBox[T]) Get() (T, error) {
+	item, err := m.box.Get()
+	if err == nil {
+		atomic.AddInt64(&m.gets, 1)
+		m.sync()
+	}
+	return item, err
+}
+
+func (m *meteredBox[T]) Peek() (T, error) {
+	return m.box.Peek()
+}
+
+func (m *meteredBox[T]) Size() int {
+	return m.box.Size()
+}
+
+func (m *meteredBox[T]) MaxSize() int {
+	return m.box.MaxSize()
+}
+
+func (m *meteredBox[T]) IsFull() bool {
+	return m.box.IsFull()
+}
+
+func (m *meteredBox[T]) IsEmpty() bool {
+	return m.box.IsEmpty()
+}
+
+func (m *meteredBox[T]) Clean() {
+	m.box.Clean()
+	m.sync()
+}
+
+func (m *meteredBox[T]) Items() []T {
+	return m.box.Items()
+}
+
+func (m *meteredBox[T]) mutatesOnRead() bool {
+	return mutatesOnRead(m.box)
+}
+
+func (m *meteredBox[T]) mutatesOnPeek() bool {
+	return mutatesOnPeek(m.box)
+}
+
+func (m *meteredBox[T]) drawPlan() drawPlan[T] {
+	return planOf(m.box)
+}
+
+// Compile-time assertion that meteredBox implements BlackBox[T].
+var _ BlackBox[any] = (*meteredBox[any])(nil)
@@ -0,0 +1,32 @@
+package blackbox
+
+import (
+	"encoding/json"
+	"expvar"
+	"testing"
+)
+
+func TestMetered(t *testing.T) {
+	box := NewMetered[int](NewFIFO[int](2, 2))
+	box.Put(1)
+	box.Put(2)
+	box.Put(3)
+	box.Get()
+	box.Get()
+	box.Get()
+
+	want := Metrics{Size: 0, MaxSize: 2, Puts: 2, Gets: 2, Rejected: 1}
+	if got := box.Metrics(); got != want {
+		t.Errorf("Expected %+v, got %+v", want, got)
+	}
+
+	box.PublishExpvar("blackbox_test_metered")
+	box.Put(4)
+	var published Metrics
+	if err := json.Unmarshal([]byte(expvar.Get("blackbox_test_metered").String()), &published); err != nil {
+		t.Fatalf("Failed to decode expvar: %v", err)
+	}
+	if published.Size != 1 || published.Puts != 3 {
+		t.Errorf("Expected published metrics to follow the box, got %+v", published)
+	}
+}
@@ -0,0 +1,67 @@
+package blackbox
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// MoveTo atomically transfers up to n items, in retrieval order, from the box
+// into dst and returns how many were moved; a negative n moves every item.
+// No other caller of this wrapper, or of dst if it is also a concurrent
+// wrapper, observes the items in between, so moves such as undo to redo
+// can't lose items if a goroutine is interrupted half way.
+//
+// Moving stops early when dst is full. If dst rejects an item, the item is
+// put back into the box, where it may change position depending on the
+// strategy, and the error of dst is returned. Should the box reject the item
+// too, it is lost and the returned error reports both errors.
+func (c *concurrentBox[T]) MoveTo(dst BlackBox[T], n int) (int, error) {
+	other, _ := dst.(*concurrentBox[T])
+	if other == c {
+		return 0, nil
+	}
+	if other != nil {
+		// Lock both boxes in address order so that concurrent moves in
+		// opposite directions can't deadlock.
+		first, second := c, other
+		if uintptr(unsafe.Pointer(other)) < uintptr(unsafe.Pointer(c)) {
+			first, second = other, c
+		}
+		first.mu.Lock()
+		second.mu.Lock()
+		defer func() {
+			second.mu.Unlock()
+			first.mu.Unlock()
+		}()
+		dst = other.box
+	} else {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
+
+	moved, lost := 0, false
+	var err error
+	for ; n < 0 || moved < n; moved++ {
+		if c.box.IsEmpty() || dst.IsFull() {
+			break
+		}
+		item, getErr := c.box.Get()
+		if getErr != nil {
+			break
+		}
+		if err = dst.Put(item); err != nil {
+			if perr := c.box.Put(item); perr != nil {
+				err = fmt.Errorf("%w, and putting the item back failed: %v", err, perr)
+				lost = true
+			}
+			break
+		}
+	}
+	if moved > 0 || lost {
+		c.broadcast()
+		if other != nil {
+			other.broadcast()
+		}
+	}
+	return moved, err
+}
@@ -0,0 +1,79 @@
+package blackbox
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestMoveTo(t *testing.T) {
+	undo := NewConcurrent[int](NewLIFO[int](0, 8))
+	redo := NewConcurrent[int](NewLIFO[int](0, 8))
+	for i := 1; i <= 5; i++ {
+		undo.Put(i)
+	}
+
+	moved, err := undo.MoveTo(redo, 2)
+	if err != nil || moved != 2 {
+		t.Fatalf("Expected 2 moved items, got %d (%v)", moved, err)
+	}
+	if !EqualInts(undo.Items(), []int{1, 2, 3}) || !EqualInts(redo.Items(), []int{5, 4}) {
+		t.Errorf("Expected [1 2 3] and [5 4], got %v and %v", undo.Items(), redo.Items())
+	}
+
+	bounded := NewFIFO[int](2, 2)
+	bounded.Put(0)
+	moved, _ = undo.MoveTo(bounded, -1)
+	if moved != 1 || !EqualInts(bounded.Items(), []int{0, 3}) {
+		t.Errorf("Expected the move to stop once dst is full, got %d moved and %v", moved, bounded.Items())
+	}
+
+	if moved, _ := undo.MoveTo(undo, -1); moved != 0 {
+		t.Errorf("Expected no move into itself, got %d", moved)
+	}
+}
+
+func TestMoveToPutBackError(t *testing.T) {
+	src := NewConcurrent[int](&fullFor{BlackBox: NewFIFOFrom[int]([]int{1, 2}, 0), n: 1})
+	errNo := errors.New("no")
+	dst := NewValidator[int](NewFIFO[int](0, 4), func(item int) error { return errNo })
+
+	moved, err := src.MoveTo(dst, -1)
+	if moved != 0 || !errors.Is(err, ErrInvalidItem) || !strings.Contains(err.Error(), "blackbox is full") {
+		t.Errorf("Expected the errors of dst and of the put back, got %d moved and %v", moved, err)
+	}
+	if src.Size() != 1 {
+		t.Errorf("Expected the source size to reflect the lost item, got %d", src.Size())
+	}
+}
+
+func TestMoveToConcurrent(t *testing.T) {
+	a := NewConcurrent[int](NewFIFO[int](0, 100))
+	b := NewConcurrent[int](NewFIFO[int](0, 100))
+	for i := 0; i < 100; i++ {
+		a.Put(i)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				a.MoveTo(b, 3)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				b.MoveTo(a, 3)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if total := a.Size() + b.Size(); total != 100 {
+		t.Errorf("Expected 100 items in total, got %d", total)
+	}
+}
@@ -0,0 +1,213 @@
+package blackbox
+
+import (
+	"sync/atomic"
+	"unsafe"
+)
+
+// cacheLinePad separates hot atomic counters to avoid false sharing.
+type cacheLinePad [64]byte
+
+// mpmcCell is a slot of the ring of mpmcFIFO. seq tells whether the slot is
+// ready to be written (seq == 2*position) or read (seq == 2*position+1);
+// doubling the position keeps the two states apart even with a single slot,
+// where position+1 is also the next position to write. item
+// points to a *T that is never written once published, so Peek and Items can
+// read it without claiming the slot.
+type mpmcCell[T any] struct {
+	seq uint64
+	// _ pads the cell to a multiple of 8 bytes on 32-bit platforms, so that
+	// seq stays 64-bit aligned in every cell of the ring.
+	_    [(8 - unsafe.Sizeof(uintptr(0))%8) % 8]byte
+	item unsafe.Pointer
+}
+
+// mpmcFIFO is a goroutine-safe bounded FIFO blackbox implemented as a
+// Vyukov-style multi-producer multi-consumer ring: producers and consumers
+// claim positions with atomic CAS on separate counters, so they don't
+// serialize on a lock or on each other.
+type mpmcFIFO[T any] struct {
+	// enq and deq come first so that they are 64-bit aligned on 32-bit
+	// platforms, as atomic operations require.
+	enq   uint64
+	_     cacheLinePad
+	deq   uint64
+	_     cacheLinePad
+	cells []mpmcCell[T]
+	// dropNewest discards items put into a full box instead of returning
+	// ErrBlackBoxFull.
+	dropNewest bool
+	onEvict    func(item T)
+	name       string // see WithName
+}
+
+// NewMPMCFIFO creates a new lock-free bounded FIFO blackbox holding at most
+// maxSize items, which must be positive. Unlike the other boxes it is safe
+// for concurrent use without NewConcurrent, and it keeps scaling with the
+// number of cores where the mutex of NewConcurrent caps throughput.
+//
+// Size is exact only while no Put or Get is in progress. Peek and Items read
+// the ring without claiming items, so under concurrent Gets Items may include
+// items taken while it runs.
+// Returns a concrete instance of mpmc fifo blackbox without interface.
+func NewMPMCFIFO[T any](maxSize int) *mpmcFIFO[T] {
+	if maxSize < 1 {
+		panic("blackbox: NewMPMCFIFO needs a positive maxSize")
+	}
+	b := &mpmcFIFO[T]{cells: make([]mpmcCell[T], maxSize)}
+	for i := range b.cells {
+		b.cells[i].seq = 2 * uint64(i)
+	}
+	return b
+}
+
+// NewMPMCFIFOFrom creates a new lock-free bounded FIFO blackbox from a slice
+// of items with the specified maximum size.
+func NewMPMCFIFOFrom[T any](items []T, maxSize int) *mpmcFIFO[T] {
+	if maxSize < len(items) {
+		maxSize = len(items)
+	}
+	if maxSize < 1 {
+		maxSize = 1
+	}
+	b := NewMPMCFIFO[T](maxSize)
+	for _, item := range items {
+		b.Put(item)
+	}
+	return b
+}
+
+func (b *mpmcFIFO[T]) cell(pos uint64) *mpmcCell[T] {
+	return &b.cells[pos%uint64(len(b.cells))]
+}
+
+func (b *mpmcFIFO[T]) Put(item T) error {
+	pos := atomic.LoadUint64(&b.enq)
+	for {
+		cell := b.cell(pos)
+		diff := int64(atomic.LoadUint64(&cell.seq) - 2*pos)
+		switch {
+		case diff == 0:
+			if atomic.CompareAndSwapUint64(&b.enq, pos, pos+1) {
+				atomic.StorePointer(&cell.item, unsafe.Pointer(&item))
+				atomic.StoreUint64(&cell.seq, 2*pos+1)
+				return nil
+			}
+			pos = atomic.LoadUint64(&b.enq)
+		case diff < 0:
+			// The slot still holds an item from the previous lap.
+			if b.dropNewest {
+				if b.onEvict != nil {
+					b.onEvict(item)
+				}
+				return nil
+			}
+			return fullError(b.name, b.Size(), len(b.cells))
+		default:
+			pos = atomic.LoadUint64(&b.enq)
+		}
+	}
+}
+
+func (b *mpmcFIFO[T]) Get() (T, error) {
+	pos := atomic.LoadUint64(&b.deq)
+	for {
+		cell := b.cell(pos)
+		diff := int64(atomic.LoadUint64(&cell.seq) - (2*pos + 1))
+		switch {
+		case diff == 0:
+			if atomic.CompareAndSwapUint64(&b.deq, pos, pos+1) {
+				item := *(*T)(atomic.LoadPointer(&cell.item))
+				atomic.StorePointer(&cell.item, nil)
+				atomic.StoreUint64(&cell.seq, 2*(pos+uint64(len(b.cells))))
+				return item, nil
+			}
+			pos = atomic.LoadUint64(&b.deq)
+		case diff < 0:
+			var zero T
+			return zero, emptyError(b.name)
+		default:
+			pos = atomic.LoadUint64(&b.deq)
+		}
+	}
+}
+
+// load returns the item at pos, or false if the slot doesn't hold it, e.g.
+// because it was taken meanwhile. seq is checked again after loading the
+// item, so it can't be an item of the next lap.
+func (b *mpmcFIFO[T]) load(pos uint64) (T, bool) {
+	cell := b.cell(pos)
+	if atomic.LoadUint64(&cell.seq) == 2*pos+1 {
+		p := atomic.LoadPointer(&cell.item)
+		if p != nil && atomic.LoadUint64(&cell.seq) == 2*pos+1 {
+			return *(*T)(p), true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+func (b *mpmcFIFO[T]) Peek() (T, error) {
+	for {
+		pos := atomic.LoadUint64(&b.deq)
+		if item, ok := b.load(pos); ok {
+			return item, nil
+		}
+		// Retry if a Get took the item meanwhile, otherwise the box is empty.
+		if atomic.LoadUint64(&b.deq) == pos {
+			var zero T
+			return zero, emptyError(b.name)
+		}
+	}
+}
+
+func (b *mpmcFIFO[T]) Size() int {
+	deq := atomic.LoadUint64(&b.deq)
+	enq := atomic.LoadUint64(&b.enq)
+	if enq <= deq {
+		return 0
+	}
+	return int(enq - deq)
+}
+
+func (b *mpmcFIFO[T]) MaxSize() int {
+	return len(b.cells)
+}
+
+func (b *mpmcFIFO[T]) IsFull() bool {
+	return b.Size() >= len(b.cells)
+}
+
+func (b *mpmcFIFO[T]) IsEmpty() bool {
+	return b.Size() == 0
+}
+
+// Clean removes every item, passing them to the evict callback.
+func (b *mpmcFIFO[T]) Clean() {
+	for {
+		item, err := b.Get()
+		if err != nil {
+			return
+		}
+		if b.onEvict != nil {
+			b.onEvict(item)
+		}
+	}
+}
+
+// Items returns the items in FIFO order.
+func (b *mpmcFIFO[T]) Items() []T {
+	items := make([]T, 0)
+	deq := atomic.LoadUint64(&b.deq)
+	for pos := deq; pos < deq+uint64(len(b.cells)); pos++ {
+		item, ok := b.load(pos)
+		if !ok {
+			return items
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// Compile-time assertion that mpmcFIFO implements BlackBox[T].
+var _ BlackBox[any] = (*mpmcFIFO[any])(nil)
@@ -0,0 +1,189 @@
+package blackbox
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+	"unsafe"
+)
+
+func TestMPMCFIFO(t *testing.T) {
+	box := New[int](WithStrategy(StrategyFIFO), WithLockFree(), WithMaxSize(3))
+	if _, ok := box.(*mpmcFIFO[int]); !ok {
+		t.Fatalf("Expected a lock-free FIFO, got %T", box)
+	}
+	for round := 0; round < 3; round++ {
+		for i := 1; i <= 3; i++ {
+			if err := box.Put(i); err != nil {
+				t.Fatalf("Failed to put item %d: %v", i, err)
+			}
+		}
+		if err := box.Put(4); err != ErrBlackBoxFull {
+			t.Errorf("Expected ErrBlackBoxFull, got %v", err)
+		}
+		if !box.IsFull() || !EqualInts(box.Items(), []int{1, 2, 3}) {
+			t.Errorf("Expected a full box with [1 2 3], got %v", box.Items())
+		}
+		if item, _ := box.Peek(); item != 1 {
+			t.Errorf("Expected Peek to return 1, got %d", item)
+		}
+		for i := 1; i <= 3; i++ {
+			if item, err := box.Get(); err != nil || item != i {
+				t.Errorf("Expected item %d, got %d (%v)", i, item, err)
+			}
+		}
+		if _, err := box.Get(); err != ErrEmptyBlackBox {
+			t.Errorf("Expected ErrEmptyBlackBox, got %v", err)
+		}
+	}
+
+	var evicted []int
+	dropping := NewFrom[int]([]int{1, 2}, WithStrategy(StrategyFIFO), WithLockFree(), WithMaxSize(2),
+		WithOverflowPolicy(OverflowDropNewest), WithEvictCallback(func(item int) { evicted = append(evicted, item) }))
+	dropping.Put(3)
+	dropping.Clean()
+	if !EqualInts(evicted, []int{3, 1, 2}) || !dropping.IsEmpty() {
+		t.Errorf("Expected evicted items [3 1 2], got %v", evicted)
+	}
+
+	if _, ok := New[int](WithStrategy(StrategyFIFO), WithLockFree()).(*fifoBox[int]); !ok {
+		t.Error("Expected an unbounded FIFO to keep the regular box")
+	}
+}
+
+func TestMPMCFIFOConcurrent(t *testing.T) {
+	box := NewMPMCFIFO[int](64)
+	const producers, perProducer = 4, 1000
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < perProducer; {
+				if box.Put(p*perProducer+i) != nil {
+					runtime.Gosched()
+					continue
+				}
+				i++
+			}
+		}(p)
+	}
+
+	var mu sync.Mutex
+	seen := make(map[int]bool)
+	var consumers sync.WaitGroup
+	for c := 0; c < 4; c++ {
+		consumers.Add(1)
+		go func() {
+			defer consumers.Done()
+			for {
+				mu.Lock()
+				done := len(seen) == producers*perProducer
+				mu.Unlock()
+				if done {
+					return
+				}
+				item, err := box.Get()
+				if err != nil {
+					runtime.Gosched()
+					continue
+				}
+				mu.Lock()
+				if seen[item] {
+					t.Errorf("Item %d retrieved twice", item)
+				}
+				seen[item] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	consumers.Wait()
+
+	if len(seen) != producers*perProducer || !box.IsEmpty() {
+		t.Errorf("Expected %d items retrieved and an empty box, got %d", producers*perProducer, len(seen))
+	}
+}
+
+func TestMPMCFIFOPeekDuringGet(t *testing.T) {
+	box := NewMPMCFIFOFrom[int]([]int{1, 2, 3, 4}, 8)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 1; i <= 10000; i++ {
+			box.Put(i)
+			box.Get()
+		}
+	}()
+	for {
+		select {
+		case <-done:
+			return
+		default:
+		}
+		if item, err := box.Peek(); err == nil && item < 1 {
+			t.Fatalf("Expected Peek to return a queued item, got %d", item)
+		}
+		for _, item := range box.Items() {
+			if item < 1 {
+				t.Fatalf("Expected Items to return queued items, got %d", item)
+			}
+		}
+	}
+}
+
+func TestMPMCFIFOFromEmpty(t *testing.T) {
+	box := NewMPMCFIFOFrom[int](nil, 0)
+	if box.MaxSize() != 1 {
+		t.Errorf("Expected MaxSize 1, got %d", box.MaxSize())
+	}
+	for i := 1; i <= 3; i++ {
+		if err := box.Put(i); err != nil {
+			t.Fatalf("Failed to put item %d: %v", i, err)
+		}
+		if err := box.Put(i + 10); err != ErrBlackBoxFull {
+			t.Errorf("Expected a single slot to be full, got %v", err)
+		}
+		if item, err := box.Get(); err != nil || item != i {
+			t.Errorf("Expected item %d, got %d (%v)", i, item, err)
+		}
+	}
+	if items := box.Items(); items == nil || len(items) != 0 {
+		t.Errorf("Expected an empty slice, got %#v", items)
+	}
+	if items := NewLockFreeLIFO[int](0).Items(); items == nil || len(items) != 0 {
+		t.Errorf("Expected an empty slice, got %#v", items)
+	}
+}
+
+func TestMPMCFIFOWithTTL(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	box := New[int](WithStrategy(StrategyFIFO), WithLockFree(), WithMaxSize(2), WithTTL(time.Minute), WithClock(clock))
+	box.Put(1)
+	clock.Advance(30 * time.Second)
+	box.Put(2)
+	if err := box.Put(3); !errors.Is(err, ErrBlackBoxFull) {
+		t.Errorf("Expected ErrBlackBoxFull, got %v", err)
+	}
+	clock.Advance(30 * time.Second)
+	if err := box.Put(3); err != nil {
+		t.Errorf("Expected the expired item to make room, got %v", err)
+	}
+	if items := box.Items(); !EqualInts(items, []int{2, 3}) {
+		t.Errorf("Expected [2 3], got %v", items)
+	}
+}
+
+func TestMPMCFIFOAlignment(t *testing.T) {
+	// 64-bit atomics need 64-bit alignment on 32-bit platforms.
+	var b mpmcFIFO[int]
+	if unsafe.Offsetof(b.enq)%8 != 0 || unsafe.Offsetof(b.deq)%8 != 0 {
+		t.Errorf("Expected aligned counters, got offsets %d and %d", unsafe.Offsetof(b.enq), unsafe.Offsetof(b.deq))
+	}
+	if size := unsafe.Sizeof(mpmcCell[int]{}); size%8 != 0 {
+		t.Errorf("Expected cells of a multiple of 8 bytes, got %d", size)
+	}
+}
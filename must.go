@@ -0,0 +1,27 @@
+package blackbox
+
+// MustPut puts item into box and panics if Put returns an error. It is meant
+// for tests and scripts where a full box is a programming error.
+func MustPut[T any](box BlackBox[T], item T) {
+	if err := box.Put(item); err != nil {
+		panic("blackbox: MustPut: " + err.Error())
+	}
+}
+
+// MustGet returns the next item of box and panics if Get returns an error.
+// It is meant for tests and scripts where an empty box is a programming error.
+func MustGet[T any](box BlackBox[T]) T {
+	item, err := box.Get()
+	if err != nil {
+		panic("blackbox: MustGet: " + err.Error())
+	}
+	return item
+}
+
+// TryGet returns the next item of box and true, or the zero value and false
+// if Get returns an error such as ErrEmptyBlackBox, for callers who prefer
+// the comma-ok idiom.
+func TryGet[T any](box BlackBox[T]) (T, bool) {
+	item, err := box.Get()
+	return item, err == nil
+}
@@ -0,0 +1,32 @@
+package blackbox
+
+import "testing"
+
+func TestMustAndTryGet(t *testing.T) {
+	box := NewFIFO[int](1, 1)
+	MustPut[int](box, 1)
+	assertPanics(t, "MustPut on a full box", func() { MustPut[int](box, 2) })
+
+	if item := MustGet[int](box); item != 1 {
+		t.Errorf("Expected MustGet to return 1, got %d", item)
+	}
+	assertPanics(t, "MustGet on an empty box", func() { MustGet[int](box) })
+
+	if item, ok := TryGet[int](box); ok || item != 0 {
+		t.Errorf("Expected TryGet to fail on an empty box, got %d, %v", item, ok)
+	}
+	box.Put(2)
+	if item, ok := TryGet[int](box); !ok || item != 2 {
+		t.Errorf("Expected TryGet to return 2, got %d, %v", item, ok)
+	}
+}
+
+func assertPanics(t *testing.T, name string, fn func()) {
+	t.Helper()
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected %s to panic", name)
+		}
+	}()
+	fn()
+}
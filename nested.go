@@ -0,0 +1,192 @@
+package blackbox
+
+import "reflect"
+
+// flattenBox unpacks a box of boxes one inner box at a time.
+type flattenBox[T any] struct {
+	outer BlackBox[BlackBox[T]]
+	// open is the inner box taken out of outer, nil until the next Get or Peek.
+	open BlackBox[T]
+}
+
+// Flatten returns a view of a box of boxes as a single box, e.g. to unpack a
+// multi-level mystery box without nested loops. Get takes the next inner box
+// from outer as outer's strategy decides, then returns its items as the inner
+// box's strategy decides before moving on to the next one; empty inner boxes
+// are skipped. Items lists the items in that order. Put returns ErrReadOnly:
+// put into the inner boxes or outer instead. Flatten can be applied again
+// for deeper nesting.
+func Flatten[T any](outer BlackBox[BlackBox[T]]) BlackBox[T] {
+	return &flattenBox[T]{outer: outer}
+}
+
+// next returns the inner box holding the next item, taking inner boxes out
+// of outer until one is not empty, or nil when all are empty.
+func (f *flattenBox[T]) next() BlackBox[T] {
+	for f.open == nil || f.open.IsEmpty() {
+		box, err := f.outer.Get()
+		if err != nil {
+			f.open = nil
+			return nil
+		}
+		f.open = box
+	}
+	return f.open
+}
+
+func (f *flattenBox[T]) Put(item T) error {
+	return ErrReadOnly
+}
+
+func (f *flattenBox[T]) Get() (T, error) {
+	box := f.next()
+	if box == nil {
+		var zero T
+		return zero, ErrEmptyBlackBox
+	}
+	return box.Get()
+}
+
+func (f *flattenBox[T]) Peek() (T, error) {
+	box := f.next()
+	if box == nil {
+		var zero T
+		return zero, ErrEmptyBlackBox
+	}
+	return box.Peek()
+}
+
+func (f *flattenBox[T]) Size() int {
+	size := 0
+	f.forEachBox(func(box BlackBox[T]) bool {
+		size += box.Size()
+		return true
+	})
+	return size
+}
+
+func (f *flattenBox[T]) MaxSize() int {
+	return 0
+}
+
+func (f *flattenBox[T]) IsFull() bool {
+	return false
+}
+
+func (f *flattenBox[T]) IsEmpty() bool {
+	empty := true
+	f.forEachBox(func(box BlackBox[T]) bool {
+		empty = box.IsEmpty()
+		return empty
+	})
+	return empty
+}
+
+// Clean drops the inner boxes from outer, leaving their items in them.
+func (f *flattenBox[T]) Clean() {
+	f.open = nil
+	f.outer.Clean()
+}
+
+func (f *flattenBox[T]) Items() []T {
+	items := make([]T, 0)
+	f.forEach(func(item T) bool {
+		items = append(items, item)
+		return true
+	})
+	return items
+}
+
+// forEachBox visits the opened inner box, then the inner boxes of outer in
+// retrieval order, skipping nil boxes.
+func (f *flattenBox[T]) forEachBox(fn func(box BlackBox[T]) bool) {
+	if f.open != nil && !fn(f.open) {
+		return
+	}
+	eachItem(f.outer, func(box BlackBox[T]) bool {
+		return box == nil || fn(box)
+	})
+}
+
+func (f *flattenBox[T]) forEach(fn func(item T) bool) {
+	more := true
+	f.forEachBox(func(box BlackBox[T]) bool {
+		eachItem(box, func(item T) bool {
+			more = fn(item)
+			return more
+		})
+		return more
+	})
+}
+
+func (f *flattenBox[T]) mutatesOnRead() bool {
+	return true
+}
+
+// Compile-time assertion that flattenBox implements BlackBox[T].
+var _ BlackBox[any] = (*flattenBox[any])(nil)
+
+// Count returns the number of items in box, counting the items inside nested
+// boxes recursively instead of the boxes themselves, at any depth. Any item
+// implementing BlackBox for some item type counts as a box, and nil boxes
+// hold no items. For example a box holding two boxes of 3 items each
+// counts 6. box is left untouched.
+func Count[T any](box BlackBox[T]) int {
+	return countItems(reflect.ValueOf(box.Items()))
+}
+
+// countItems counts the leaf items of a slice of items.
+func countItems(items reflect.Value) int {
+	n := 0
+	for i := 0; i < items.Len(); i++ {
+		item := items.Index(i)
+		if item.Kind() == reflect.Interface {
+			if item.IsNil() {
+				if !isBoxType(item.Type()) {
+					n++
+				}
+				continue
+			}
+			item = item.Elem()
+		}
+		if !isBoxType(item.Type()) {
+			n++
+			continue
+		}
+		if item.Kind() == reflect.Pointer && item.IsNil() {
+			continue
+		}
+		n += countItems(item.MethodByName("Items").Call(nil)[0])
+	}
+	return n
+}
+
+// isBoxType reports whether t implements BlackBox[U] for some item type U.
+func isBoxType(t reflect.Type) bool {
+	get, ok := t.MethodByName("Get")
+	if !ok {
+		return false
+	}
+	items, ok := t.MethodByName("Items")
+	if !ok {
+		return false
+	}
+	put, ok := t.MethodByName("Put")
+	if !ok {
+		return false
+	}
+	// Method types of concrete types include the receiver.
+	in := 0
+	if t.Kind() != reflect.Interface {
+		in = 1
+	}
+	errorType := reflect.TypeOf((*error)(nil)).Elem()
+	g, it, p := get.Type, items.Type, put.Type
+	if g.NumIn() != in || g.NumOut() != 2 || g.Out(1) != errorType {
+		return false
+	}
+	if it.NumIn() != in || it.NumOut() != 1 || it.Out(0) != reflect.SliceOf(g.Out(0)) {
+		return false
+	}
+	return p.NumIn() == in+1 && p.In(in) == g.Out(0) && p.NumOut() == 1 && p.Out(0) == errorType
+}
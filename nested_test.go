@@ -0,0 +1,85 @@
+package blackbox
+
+import "testing"
+
+func TestFlatten(t *testing.T) {
+	inner1 := NewFIFOFrom([]int{1, 2}, 0)
+	inner2 := NewLIFOFrom([]int{3, 4, 5}, 0)
+	outer := New[BlackBox[int]](WithStrategy(StrategyFIFO))
+	outer.Put(inner1)
+	outer.Put(New[int]())
+	outer.Put(nil)
+	outer.Put(inner2)
+
+	box := Flatten(outer)
+	if box.Size() != 5 || box.IsEmpty() {
+		t.Errorf("Expected 5 items, got %d", box.Size())
+	}
+	if items := box.Items(); !EqualInts(items, []int{1, 2, 5, 4, 3}) {
+		t.Errorf("Expected [1 2 5 4 3], got %v", items)
+	}
+	if err := box.Put(6); err != ErrReadOnly {
+		t.Errorf("Expected ErrReadOnly, got %v", err)
+	}
+
+	var got []int
+	for !box.IsEmpty() {
+		peeked, _ := box.Peek()
+		item, err := box.Get()
+		if err != nil || item != peeked {
+			t.Fatalf("Expected the peeked %d, got %d, %v", peeked, item, err)
+		}
+		got = append(got, item)
+	}
+	if !EqualInts(got, []int{1, 2, 5, 4, 3}) {
+		t.Errorf("Expected [1 2 5 4 3], got %v", got)
+	}
+	if _, err := box.Get(); err != ErrEmptyBlackBox {
+		t.Errorf("Expected ErrEmptyBlackBox, got %v", err)
+	}
+	if !outer.IsEmpty() {
+		t.Errorf("Expected the inner boxes to be taken out, got %d", outer.Size())
+	}
+
+	// Flatten composes for deeper nesting.
+	deep := New[BlackBox[BlackBox[int]]]()
+	deep.Put(NewFIFOFrom([]BlackBox[int]{NewFIFOFrom([]int{1, 2}, 0)}, 0))
+	deep.Put(NewFIFOFrom([]BlackBox[int]{NewFIFOFrom([]int{3}, 0)}, 0))
+	flat := Flatten(Flatten(deep))
+	if flat.Size() != 3 {
+		t.Errorf("Expected 3 items, got %d", flat.Size())
+	}
+	flat.Clean()
+	if !flat.IsEmpty() || deep.Size() != 0 {
+		t.Errorf("Expected Clean to drop the boxes, got %d", flat.Size())
+	}
+}
+
+func TestCount(t *testing.T) {
+	outer := New[BlackBox[int]]()
+	outer.Put(NewFIFOFrom([]int{1, 2, 3}, 0))
+	outer.Put(NewLIFOFrom([]int{4, 5, 6}, 0))
+	outer.Put(nil)
+	if n := Count(outer); n != 6 {
+		t.Errorf("Expected 6, got %d", n)
+	}
+
+	deep := New[BlackBox[BlackBox[int]]]()
+	deep.Put(outer)
+	deep.Put(NewFIFOFrom([]BlackBox[int]{NewFIFOFrom([]int{7}, 0)}, 0))
+	if n := Count(deep); n != 7 {
+		t.Errorf("Expected 7, got %d", n)
+	}
+
+	mixed := New[any]()
+	mixed.Put(1)
+	mixed.Put(nil)
+	mixed.Put(NewFIFOFrom([]string{"a", "b"}, 0))
+	mixed.Put(NewConcurrent[int](NewFIFOFrom([]int{1}, 0)))
+	if n := Count(mixed); n != 5 {
+		t.Errorf("Expected 5, got %d", n)
+	}
+	if n := Count[int](NewFIFOFrom([]int{1, 2}, 0)); n != 2 {
+		t.Errorf("Expected 2, got %d", n)
+	}
+}
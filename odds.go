@@ -0,0 +1,453 @@
+package blackbox
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"sync/atomic"
+)
+
+// oddsSimulations is the number of seeded simulation runs used to estimate
+// within-k odds for non-uniform random boxes.
+const oddsSimulations = 10000
+
+// Odds describes the chance of an item being drawn from a blackbox.
+type Odds[T any] struct {
+	Item T
+	// Count is the number of copies of Item currently in the box.
+	Count int
+	// Next is the probability that the next Get returns Item.
+	Next float64
+	// Within is the probability that Item is returned at least once within the next k Gets.
+	Within float64
+}
+
+// OddsOf computes the odds of item being drawn next and within the next k draws.
+//
+// Odds are exact for the boxes of this package and the wrappers that keep the
+// retrieval order of the box they wrap: deterministic boxes (FIFO, LIFO,
+// Sorted and their chunked, list, lock-free, fair and sharded variants) yield
+// 0 or 1 from the retrieval order, items already drawn by PeekN come first, and
+// the Random strategy is computed analytically for sampling without
+// replacement. When the Random strategy is biased (see WithAgeBias and
+// PutWeighted), Next is still exact while Within is estimated with a seeded
+// simulation. Any other BlackBox[T] implementation is assumed to draw
+// uniformly at random.
+func OddsOf[T comparable](box BlackBox[T], item T, k int) Odds[T] {
+	for _, odds := range planOdds(planOf(box), k) {
+		if odds.Item == item {
+			return odds
+		}
+	}
+	return Odds[T]{Item: item}
+}
+
+// OddsReport computes the odds of every distinct item in the box, ordered from
+// the most to the least likely next draw. It is meant for publishing drop-rate
+// tables straight from a box definition.
+func OddsReport[T comparable](box BlackBox[T], k int) []Odds[T] {
+	report := planOdds(planOf(box), k)
+	sort.SliceStable(report, func(i, j int) bool {
+		return report[i].Next > report[j].Next
+	})
+	return report
+}
+
+// drawPlan describes how a box returns its next items: first the fixed items,
+// in order, then the rest drawn at random with the given weights, or
+// uniformly when weights is nil.
+type drawPlan[T any] struct {
+	fixed   []T
+	rest    []T
+	weights []float64
+}
+
+// planOf returns the draw plan of box, see Probabilities.
+func planOf[T any](box BlackBox[T]) drawPlan[T] {
+	if p, ok := box.(prober[T]); ok {
+		return p.drawPlan()
+	}
+	if order, ok := orderOf(box); ok {
+		return drawPlan[T]{fixed: order}
+	}
+	return drawPlan[T]{rest: box.Items()}
+}
+
+// planOdds computes the odds of every distinct item of p, in the order of the plan.
+func planOdds[T comparable](p drawPlan[T], k int) []Odds[T] {
+	index := make(map[T]int)
+	var report []Odds[T]
+	for _, it := range p.fixed {
+		if _, ok := index[it]; !ok {
+			index[it] = len(report)
+			report = append(report, orderedOdds(p.fixed, it, k))
+		}
+	}
+
+	restK := k - len(p.fixed)
+	if restK < 0 {
+		restK = 0
+	}
+	var rest []Odds[T]
+	if usableWeights(p.weights) {
+		rest = weightedOdds(p.rest, p.weights, restK)
+	} else {
+		counts := make(map[T]int, len(p.rest))
+		var distinct []T
+		for _, it := range p.rest {
+			if counts[it] == 0 {
+				distinct = append(distinct, it)
+			}
+			counts[it]++
+		}
+		for _, it := range distinct {
+			rest = append(rest, uniformOdds(it, counts[it], len(p.rest), restK))
+		}
+	}
+	for _, odds := range rest {
+		if len(p.fixed) > 0 {
+			// The next item is the first fixed one.
+			odds.Next = 0
+		}
+		i, ok := index[odds.Item]
+		if !ok {
+			index[odds.Item] = len(report)
+			report = append(report, odds)
+			continue
+		}
+		report[i].Count += odds.Count
+		if report[i].Within < 1 {
+			report[i].Within = odds.Within
+		}
+	}
+	return report
+}
+
+// probabilities lists the items of p in the order of the plan.
+func (p drawPlan[T]) probabilities() []Probability[T] {
+	probs := make([]Probability[T], 0, len(p.fixed)+len(p.rest))
+	for _, item := range p.fixed {
+		probs = append(probs, Probability[T]{Item: item})
+	}
+	if len(probs) > 0 {
+		probs[0].Probability = 1
+		for _, item := range p.rest {
+			probs = append(probs, Probability[T]{Item: item})
+		}
+		return probs
+	}
+	if !usableWeights(p.weights) {
+		return uniformProbabilities(p.rest)
+	}
+	total := 0.0
+	for _, w := range p.weights {
+		if w > 0 {
+			total += w
+		}
+	}
+	for i, item := range p.rest {
+		prob := Probability[T]{Item: item}
+		if p.weights[i] > 0 {
+			prob.Probability = p.weights[i] / total
+		}
+		probs = append(probs, prob)
+	}
+	return probs
+}
+
+// orderer is implemented by the boxes of this package that return their items
+// in a fixed order, and by wrappers around them.
+type orderer[T any] interface {
+	// retrievalOrder returns the items in the order Get would return them,
+	// or false if the order is not fixed.
+	retrievalOrder() ([]T, bool)
+}
+
+// orderOf returns the items of a deterministic box in the order Get would return them.
+func orderOf[T any](box BlackBox[T]) ([]T, bool) {
+	if o, ok := box.(orderer[T]); ok {
+		return o.retrievalOrder()
+	}
+	return nil, false
+}
+
+func (b *ring[T]) retrievalOrder() ([]T, bool) {
+	return b.Items(), true
+}
+
+func (b *lifoBox[T]) retrievalOrder() ([]T, bool) {
+	items := b.Items()
+	reverse(items)
+	return items, true
+}
+
+func (b *sortedBox[T]) retrievalOrder() ([]T, bool) {
+	return b.Items(), true
+}
+
+func (b *chunkedFIFO[T]) retrievalOrder() ([]T, bool) {
+	return b.Items(), true
+}
+
+func (b *listFIFO[T]) retrievalOrder() ([]T, bool) {
+	return b.Items(), true
+}
+
+func (b *fairFIFO[T, K]) retrievalOrder() ([]T, bool) {
+	return b.Items(), true
+}
+
+func (b *lockFreeLIFO[T]) retrievalOrder() ([]T, bool) {
+	items := b.Items()
+	reverse(items)
+	return items, true
+}
+
+func (b *mpmcFIFO[T]) retrievalOrder() ([]T, bool) {
+	return b.Items(), true
+}
+
+func (t *timedBox[T]) retrievalOrder() ([]T, bool) {
+	t.purge()
+	order, ok := orderOf(t.box)
+	return values(order), ok
+}
+
+func (t *ticketBox[T]) retrievalOrder() ([]T, bool) {
+	order, ok := orderOf(t.box)
+	result := make([]T, len(order))
+	for i, it := range order {
+		result[i] = it.value
+	}
+	return result, ok
+}
+
+// retrievalOrder replays the round-robin of Get over the orders of the shards.
+func (s *shardedBox[T]) retrievalOrder() ([]T, bool) {
+	queues := make([][]T, len(s.shards))
+	left := 0
+	for i, shard := range s.shards {
+		p := planOf[T](shard)
+		if len(p.rest) > 0 {
+			return nil, false
+		}
+		queues[i] = p.fixed
+		left += len(p.fixed)
+	}
+	order := make([]T, 0, left)
+	for gets := atomic.LoadUint64(&s.gets); left > 0; gets++ {
+		start := int(gets % uint64(len(queues)))
+		for i := range queues {
+			q := &queues[(start+i)%len(queues)]
+			if len(*q) > 0 {
+				order = append(order, (*q)[0])
+				*q = (*q)[1:]
+				left--
+				break
+			}
+		}
+	}
+	return order, true
+}
+
+func orderedOdds[T comparable](order []T, item T, k int) Odds[T] {
+	odds := Odds[T]{Item: item}
+	for i, it := range order {
+		if it != item {
+			continue
+		}
+		odds.Count++
+		if i == 0 {
+			odds.Next = 1
+		}
+		if i < k {
+			odds.Within = 1
+		}
+	}
+	return odds
+}
+
+// uniformOdds computes odds for drawing without replacement from n items of which count match.
+func uniformOdds[T any](item T, count, n, k int) Odds[T] {
+	odds := Odds[T]{Item: item, Count: count}
+	if count == 0 || n == 0 {
+		return odds
+	}
+	odds.Next = float64(count) / float64(n)
+	if k <= 0 {
+		return odds
+	}
+	// P(miss k times) = C(n-count, k) / C(n, k), expanded as a running product.
+	miss := 1.0
+	for i := 0; i < k; i++ {
+		if n-count-i <= 0 {
+			miss = 0
+			break
+		}
+		miss *= float64(n-count-i) / float64(n-i)
+	}
+	odds.Within = 1 - miss
+	return odds
+}
+
+// usableWeights reports whether weights select items non-uniformly. Without a
+// positive finite total, randomBox falls back to uniform selection.
+func usableWeights(weights []float64) bool {
+	if weights == nil {
+		return false
+	}
+	total := 0.0
+	for _, w := range weights {
+		if w > 0 {
+			total += w
+		}
+	}
+	return total > 0 && !math.IsInf(total, 1)
+}
+
+// weightedOdds computes the odds of every distinct item for weighted draws
+// without replacement. Next is exact; Within is estimated by simulation.
+func weightedOdds[T comparable](items []T, weights []float64, k int) []Odds[T] {
+	index := make(map[T]int, len(items))
+	report := make([]Odds[T], 0, len(items))
+	owner := make([]int, len(items))
+	total := 0.0
+	for i, it := range items {
+		d, ok := index[it]
+		if !ok {
+			d = len(report)
+			index[it] = d
+			report = append(report, Odds[T]{Item: it})
+		}
+		owner[i] = d
+		report[d].Count++
+		if weights[i] > 0 {
+			report[d].Next += weights[i]
+			total += weights[i]
+		}
+	}
+	for d := range report {
+		report[d].Next /= total
+	}
+	if k <= 0 {
+		return report
+	}
+
+	// base holds non-negative weights; drawn items are marked with -1 in buf.
+	base := make([]float64, len(weights))
+	for i, w := range weights {
+		if w > 0 {
+			base[i] = w
+		}
+	}
+	rng := rand.New(rand.NewSource(1))
+	buf := make([]float64, len(base))
+	hits := make([]int, len(report))
+	seen := make([]int, len(report))
+	for run := 1; run <= oddsSimulations; run++ {
+		copy(buf, base)
+		for draw := 0; draw < k && draw < len(buf); draw++ {
+			idx, ok := pickWeighted(rng, buf)
+			if !ok {
+				// Mirror randomBox, which falls back to uniform selection.
+				idx = nthUndrawn(buf, rng.Intn(len(buf)-draw))
+			}
+			buf[idx] = -1
+			if d := owner[idx]; seen[d] != run {
+				seen[d] = run
+				hits[d]++
+			}
+		}
+	}
+	for d := range report {
+		report[d].Within = float64(hits[d]) / oddsSimulations
+	}
+	return report
+}
+
+// nthUndrawn returns the index of the n-th entry of buf not marked as drawn.
+func nthUndrawn(buf []float64, n int) int {
+	for i, w := range buf {
+		if w < 0 {
+			continue
+		}
+		if n == 0 {
+			return i
+		}
+		n--
+	}
+	return len(buf) - 1
+}
+
+// Probability is the chance of one of the items in a box being returned by
+// the next Get.
+type Probability[T any] struct {
+	Item        T
+	Probability float64
+}
+
+// prober is implemented by the boxes of this package that draw their next
+// item at random, and by the wrappers around them.
+type prober[T any] interface {
+	drawPlan() drawPlan[T]
+}
+
+// Probabilities returns the current selection probability of every item left
+// in the box, e.g. to display legally required odds in a lucky draw. Copies of
+// an item are listed separately; the probabilities sum to 1 unless the box is
+// empty. The Random strategy accounts for PutWeighted and WithAgeBias, and
+// deterministic strategies (FIFO, LIFO, Sorted) give 1 to the next item. Any
+// other BlackBox[T] implementation is assumed to draw uniformly at random.
+func Probabilities[T any](box BlackBox[T]) []Probability[T] {
+	return planOf(box).probabilities()
+}
+
+func uniformProbabilities[T any](items []T) []Probability[T] {
+	probs := make([]Probability[T], len(items))
+	for i, item := range items {
+		probs[i] = Probability[T]{Item: item, Probability: 1 / float64(len(items))}
+	}
+	return probs
+}
+
+// Probabilities returns the probability of each item being returned by the
+// next Get. Items already drawn by PeekN come first, in the order Get returns
+// them; the next one is certain.
+func (b *randomBox[T]) Probabilities() []Probability[T] {
+	return b.drawPlan().probabilities()
+}
+
+// drawPlan returns the items drawn by PeekN in the order Get returns them,
+// followed by the others.
+func (b *randomBox[T]) drawPlan() drawPlan[T] {
+	n := len(b.items) - b.drawn
+	p := drawPlan[T]{rest: make([]T, n)}
+	copy(p.rest, b.items[:n])
+	for i := len(b.items) - 1; i >= n; i-- {
+		p.fixed = append(p.fixed, b.items[i])
+	}
+	if weights := b.weights(); weights != nil {
+		p.weights = weights[:n]
+	}
+	return p
+}
+
+// Probabilities returns the selection probabilities of the wrapped box with
+// the lock held, like Peek.
+func (c *concurrentBox[T]) Probabilities() []Probability[T] {
+	return c.drawPlan().probabilities()
+}
+
+func (c *concurrentBox[T]) drawPlan() drawPlan[T] {
+	c.rlock()
+	defer c.runlock()
+	return planOf(c.box)
+}
+
+func (c *categoryBox[T, K]) Probabilities() []Probability[T] {
+	return Probabilities(c.box)
+}
+
+func (c *categoryBox[T, K]) drawPlan() drawPlan[T] {
+	return planOf(c.box)
+}
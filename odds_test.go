@@ -0,0 +1,247 @@
+package blackbox
+
+import (
+	"io"
+	"math"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+)
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestOddsRandom(t *testing.T) {
+	box := New[string](WithSeed(1))
+	for _, item := range []string{"common", "common", "common", "rare"} {
+		box.Put(item)
+	}
+
+	rare := OddsOf[string](box, "rare", 2)
+	if rare.Count != 1 {
+		t.Errorf("Expected count 1, got %d", rare.Count)
+	}
+	if !almostEqual(rare.Next, 0.25) {
+		t.Errorf("Expected next odds 0.25, got %v", rare.Next)
+	}
+	// 1 - (3/4 * 2/3) = 0.5
+	if !almostEqual(rare.Within, 0.5) {
+		t.Errorf("Expected within-2 odds 0.5, got %v", rare.Within)
+	}
+
+	common := OddsOf[string](box, "common", 2)
+	if !almostEqual(common.Within, 1) {
+		t.Errorf("Expected within-2 odds 1, got %v", common.Within)
+	}
+
+	missing := OddsOf[string](box, "missing", 4)
+	if missing.Count != 0 || missing.Next != 0 || missing.Within != 0 {
+		t.Errorf("Expected zero odds for missing item, got %+v", missing)
+	}
+
+	report := OddsReport[string](box, 1)
+	if len(report) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(report))
+	}
+	if report[0].Item != "common" || !almostEqual(report[0].Next, 0.75) {
+		t.Errorf("Expected common first with 0.75, got %+v", report[0])
+	}
+	if !almostEqual(report[1].Within, 0.25) {
+		t.Errorf("Expected rare within-1 odds 0.25, got %+v", report[1])
+	}
+}
+
+func TestOddsDeterministic(t *testing.T) {
+	fifo := NewFIFOFrom[int]([]int{1, 2, 3}, 0)
+	if odds := OddsOf[int](fifo, 1, 1); odds.Next != 1 || odds.Within != 1 {
+		t.Errorf("Expected FIFO head to be certain, got %+v", odds)
+	}
+	if odds := OddsOf[int](fifo, 3, 2); odds.Next != 0 || odds.Within != 0 {
+		t.Errorf("Expected FIFO tail to be out of reach, got %+v", odds)
+	}
+
+	lifo := NewLIFOFrom[int]([]int{1, 2, 3}, 0)
+	if odds := OddsOf[int](lifo, 3, 1); odds.Next != 1 {
+		t.Errorf("Expected LIFO top to be certain, got %+v", odds)
+	}
+	report := OddsReport[int](lifo, 2)
+	if report[0].Item != 3 || report[1].Within != 1 || report[2].Within != 0 {
+		t.Errorf("Unexpected LIFO report %+v", report)
+	}
+}
+
+func TestOddsUnknownImplementationIsUniform(t *testing.T) {
+	// Embedding the interface hides the concrete box.
+	box := struct{ BlackBox[int] }{NewFIFOFrom[int]([]int{1, 2}, 0)}
+	if odds := OddsOf[int](box, 2, 1); !almostEqual(odds.Next, 0.5) {
+		t.Errorf("Expected uniform odds 0.5, got %+v", odds)
+	}
+}
+
+func TestOddsWrapped(t *testing.T) {
+	box := NewConcurrent[int](NewFIFOFrom[int]([]int{1, 2, 3}, 0))
+	if odds := OddsOf[int](box, 1, 1); odds.Next != 1 || odds.Within != 1 {
+		t.Errorf("Expected the head of a wrapped FIFO to be certain, got %+v", odds)
+	}
+	report := OddsReport[int](box, 2)
+	if report[0].Item != 1 || report[1].Within != 1 || report[2].Within != 0 {
+		t.Errorf("Unexpected wrapped FIFO report %+v", report)
+	}
+	probs := Probabilities[int](box)
+	for _, odds := range report {
+		for _, p := range probs {
+			if p.Item == odds.Item && p.Probability != odds.Next {
+				t.Errorf("Expected odds of %d to match its probability %v, got %v", p.Item, p.Probability, odds.Next)
+			}
+		}
+	}
+}
+
+func TestOddsPeeked(t *testing.T) {
+	box := NewRandomFrom[int]([]int{1, 2, 3, 4}, 0, rand.New(rand.NewSource(1)))
+	next := box.PeekN(1)[0]
+	report := OddsReport[int](box, 2)
+	if report[0].Item != next || report[0].Next != 1 || report[0].Within != 1 {
+		t.Errorf("Expected peeked item %d to be certain, got %+v", next, report[0])
+	}
+	for _, odds := range report[1:] {
+		// One of the other 3 items is drawn second.
+		if odds.Next != 0 || !almostEqual(odds.Within, 1.0/3) {
+			t.Errorf("Expected within-2 odds 1/3 for %d, got %+v", odds.Item, odds)
+		}
+	}
+}
+
+func TestProbabilitiesRandom(t *testing.T) {
+	box := NewRandom[string](0, 0, nil)
+	box.PutWeighted("common", 3)
+	box.PutWeighted("common", 3)
+	box.PutWeighted("rare", 1.5)
+	box.PutWeighted("broken", 0)
+
+	probs := Probabilities[string](NewConcurrent[string](box))
+	if len(probs) != 4 {
+		t.Fatalf("Expected 4 probabilities, got %d", len(probs))
+	}
+	expected := map[string]float64{"common": 0.8, "rare": 0.2, "broken": 0}
+	total := 0.0
+	for _, p := range probs {
+		total += p.Probability
+		if p.Item == "common" {
+			continue
+		}
+		if !almostEqual(p.Probability, expected[p.Item]) {
+			t.Errorf("Expected %s probability %v, got %v", p.Item, expected[p.Item], p.Probability)
+		}
+	}
+	if !almostEqual(total, 1) {
+		t.Errorf("Expected probabilities to sum to 1, got %v", total)
+	}
+
+	uniform := Probabilities[int](NewRandomFrom([]int{1, 2, 3, 4}, 0, nil))
+	for _, p := range uniform {
+		if !almostEqual(p.Probability, 0.25) {
+			t.Errorf("Expected uniform probability 0.25, got %v", p.Probability)
+		}
+	}
+}
+
+func TestProbabilitiesPeeked(t *testing.T) {
+	box := New[int](WithSeed(1))
+	for i := 1; i <= 4; i++ {
+		box.Put(i)
+	}
+	next := PeekN(box, 2)
+	probs := Probabilities(box)
+	if probs[0].Item != next[0] || probs[0].Probability != 1 {
+		t.Errorf("Expected peeked item %d to be certain, got %+v", next[0], probs[0])
+	}
+	for _, p := range probs[1:] {
+		if p.Probability != 0 {
+			t.Errorf("Expected probability 0 for %d, got %v", p.Item, p.Probability)
+		}
+	}
+}
+
+func TestProbabilitiesOrdered(t *testing.T) {
+	probs := Probabilities[int](NewLIFOFrom([]int{1, 2, 3}, 0))
+	if probs[0].Item != 3 || probs[0].Probability != 1 {
+		t.Errorf("Expected 3 to be certain, got %+v", probs[0])
+	}
+	if probs[2].Item != 1 || probs[2].Probability != 0 {
+		t.Errorf("Expected 1 to have probability 0, got %+v", probs[2])
+	}
+	if probs := Probabilities[int](NewFIFO[int](0, 0)); len(probs) != 0 {
+		t.Errorf("Expected no probabilities, got %v", probs)
+	}
+}
+
+func TestOddsConcurrentTimed(t *testing.T) {
+	// Computing odds purges expired items, so it must not share a read lock.
+	box := NewConcurrentRW[int](New[int](WithStrategy(StrategyFIFO), WithTTL(time.Millisecond)))
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				box.Put(i)
+				if i%20 == 0 {
+					time.Sleep(time.Millisecond)
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				OddsReport[int](box, 2)
+				Probabilities[int](box)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestOddsBackends(t *testing.T) {
+	data := []int{1, 2, 3}
+	fifo := []Option{WithStrategy(StrategyFIFO), WithMaxSize(4)}
+	sharded := NewSharded[int](2, func() BlackBox[int] { return NewFIFO[int](0, 2) })
+	fair := NewFairFIFO[int](func(i int) int { return i }, 0)
+	for _, box := range []BlackBox[int]{sharded, fair} {
+		for _, item := range data {
+			box.Put(item)
+		}
+	}
+	tests := []struct {
+		name string
+		box  BlackBox[int]
+		head int
+	}{
+		{"chunked", NewFrom(data, append(fifo, WithChunkSize(2))...), 1},
+		{"list", NewFIFOListFrom(data, 0), 1},
+		{"lockfree LIFO", NewFrom(data, WithStrategy(StrategyLIFO), WithLockFree()), 3},
+		{"MPMC", NewFrom(data, append(fifo, WithLockFree())...), 1},
+		{"tickets", NewFrom(data, append(fifo, WithTickets())...), 1},
+		{"timed tickets", NewFrom(data, append(fifo, WithTickets(), WithTTL(time.Hour))...), 1},
+		{"sharded", sharded, 1},
+		{"fair", fair, 1},
+		{"audit", NewFrom(data, append(fifo, WithAudit(io.Discard))...), 1},
+		{"metered", NewMetered[int](NewLIFOFrom(data, 0)), 3},
+	}
+	for _, tt := range tests {
+		if odds := OddsOf(tt.box, tt.head, 1); odds.Next != 1 || odds.Within != 1 {
+			t.Errorf("%s: Expected head %d to be certain, got %+v", tt.name, tt.head, odds)
+		}
+		want, _ := tt.box.Peek()
+		if probs := Probabilities(tt.box); probs[0].Item != want || probs[0].Probability != 1 {
+			t.Errorf("%s: Expected %d to be certain, got %+v", tt.name, want, probs[0])
+		}
+	}
+
+	// The sharded box takes the next item from the shards in turn.
+	if order, _ := orderOf[int](sharded); !EqualInts(order, Drain[int](sharded)) {
+		t.Errorf("Expected the sharded order to match Get, got %v", order)
+	}
+}
@@ -0,0 +1,39 @@
+package blackbox
+
+// nPeeker is implemented by the boxes of this package that decide the order of
+// their next items on demand.
+type nPeeker[T any] interface {
+	PeekN(n int) []T
+}
+
+// PeekN returns up to n items in retrieval order without removing them, so
+// 0 is the item the next Get returns, e.g. to show what is "up next" in a
+// queue. A Random box draws the items once and then returns them from Get in
+// that order. For boxes of other packages the order follows Items.
+func PeekN[T any](box BlackBox[T], n int) []T {
+	if n <= 0 {
+		return nil
+	}
+	if p, ok := box.(nPeeker[T]); ok {
+		return p.PeekN(n)
+	}
+	items := make([]T, 0, minInt(n, box.Size()))
+	eachItem(box, func(item T) bool {
+		items = append(items, item)
+		return len(items) < n
+	})
+	return items
+}
+
+// PeekN draws the next items of a Random box with the lock held.
+func (c *concurrentBox[T]) PeekN(n int) []T {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	items := PeekN(c.box, n)
+	c.refresh()
+	return items
+}
+
+func (c *categoryBox[T, K]) PeekN(n int) []T {
+	return PeekN(c.box, n)
+}
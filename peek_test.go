@@ -0,0 +1,72 @@
+package blackbox
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestPeekN(t *testing.T) {
+	data := []int{1, 2, 3, 4}
+	tests := []struct {
+		name string
+		box  BlackBox[int]
+		want []int
+	}{
+		{"FIFO", NewFIFOFrom[int](data, 0), []int{1, 2, 3}},
+		{"LIFO", NewLIFOFrom[int](data, 0), []int{4, 3, 2}},
+		{"Sorted", NewSortedFrom[int]([]int{3, 1, 4, 2}, lessInt, 0), []int{1, 2, 3}},
+		{"ConcurrentFIFO", NewConcurrent[int](NewFIFOFrom[int](data, 0)), []int{1, 2, 3}},
+		{"Short", NewFIFOFrom[int](data[:2], 0), []int{1, 2}},
+	}
+	for _, tt := range tests {
+		size := tt.box.Size()
+		if items := PeekN(tt.box, 3); !EqualInts(items, tt.want) {
+			t.Errorf("%s: Expected %v, got %v", tt.name, tt.want, items)
+		}
+		if tt.box.Size() != size {
+			t.Errorf("%s: Expected PeekN not to remove items, got size %d", tt.name, tt.box.Size())
+		}
+	}
+	if items := PeekN[int](NewFIFOFrom[int](data, 0), 0); len(items) != 0 {
+		t.Errorf("Expected no items, got %v", items)
+	}
+}
+
+func TestRandomPeekNIsStable(t *testing.T) {
+	box := NewRandomFrom[int]([]int{1, 2, 3, 4, 5}, 0, rand.New(rand.NewSource(1)))
+
+	next := box.PeekN(3)
+	if again := box.PeekN(3); !EqualInts(again, next) {
+		t.Fatalf("Expected repeated PeekN to return %v, got %v", next, again)
+	}
+	if longer := box.PeekN(4); !EqualInts(longer[:3], next) {
+		t.Fatalf("Expected %v to extend %v", longer, next)
+	}
+	if item, _ := box.Peek(); item != next[0] {
+		t.Errorf("Expected Peek to return %d, got %d", next[0], item)
+	}
+	box.Put(6)
+	for _, want := range next {
+		if item, _ := box.Get(); item != want {
+			t.Errorf("Expected %d, got %d", want, item)
+		}
+	}
+	if box.Size() != 3 || !ContainsInt(box.Items(), 6) {
+		t.Errorf("Expected 3 items including 6, got %v", box.Items())
+	}
+}
+
+func TestRandomPeekNClone(t *testing.T) {
+	box := New[int](WithStrategy(StrategyRandom), WithSeed(7)).(*randomBox[int])
+	for i := 1; i <= 10; i++ {
+		box.Put(i)
+	}
+	next := box.PeekN(5)
+	clone, _ := box.Clone()
+	if drained := Drain(clone); !EqualInts(drained[:5], next) {
+		t.Errorf("Expected the clone to start with %v, got %v", next, drained)
+	}
+	if drained := box.Drain(); !EqualInts(drained[:5], next) {
+		t.Errorf("Expected Drain to start with %v, got %v", next, drained)
+	}
+}
@@ -0,0 +1,116 @@
+package blackbox
+
+import "sync"
+
+// Recycler is implemented by boxes created with WithPool or NewPooled, to
+// reuse heavy items instead of allocating one per Put.
+type Recycler[T any] interface {
+	// Acquire returns an item from the pool to fill and Put, or the zero
+	// value of T when the pool is empty and has no New function.
+	Acquire() T
+	// Recycle returns an item retrieved with Get to the pool once the
+	// consumer is done with it. The item must not be used afterwards.
+	Recycle(item T)
+}
+
+// poolBox pairs any BlackBox[T] with a sync.Pool of items.
+type poolBox[T any] struct {
+	box  BlackBox[T]
+	pool *sync.Pool
+}
+
+// NewPooled wraps box with pool so that producers Acquire items from the
+// pool and consumers Recycle them after Get, cutting allocations in
+// high-throughput pipelines. pool.New should return a new T. Items removed
+// by Clean are recycled too; to recycle items evicted by the overflow policy
+// or TTL, create box with WithEvictCallback(func(item T) { pool.Put(item) }).
+// The pool is goroutine-safe, so for concurrent use wrap the box from
+// NewConcurrent: NewPooled(NewConcurrent(box), pool).
+// Returns a concrete instance of pooled blackbox.
+func NewPooled[T any](box BlackBox[T], pool *sync.Pool) *poolBox[T] {
+	return &poolBox[T]{box: box, pool: pool}
+}
+
+// WithPool makes the box implement Recycler[T] with pool, see NewPooled.
+func WithPool[T any](pool *sync.Pool) Option {
+	return func(c *config) {
+		c.decorators = append(c.decorators, func(box BlackBox[T]) BlackBox[T] {
+			return NewPooled(box, pool)
+		})
+	}
+}
+
+func (p *poolBox[T]) Acquire() T {
+	if item, ok := p.pool.Get().(T); ok {
+		return item
+	}
+	var zero T
+	return zero
+}
+
+func (p *poolBox[T]) Recycle(item T) {
+	p.pool.Put(item)
+}
+
+func (p *poolBox[T]) Put(item T) error {
+	return p.box.Put(item)
+}
+
+func (p *poolBox[T]) Get() (T, error) {
+	return p.box.Get()
+}
+
+func (p *poolBox[T]) Peek() (T, error) {
+	return p.box.Peek()
+}
+
+func (p *poolBox[T]) Size() int {
+	return p.box.Size()
+}
+
+func (p *poolBox[T]) MaxSize() int {
+	return p.box.MaxSize()
+}
+
+func (p *poolBox[T]) IsFull() bool {
+	return p.box.IsFull()
+}
+
+func (p *poolBox[T]) IsEmpty() bool {
+	return p.box.IsEmpty()
+}
+
+// Clean removes all items and recycles them.
+func (p *poolBox[T]) Clean() {
+	items := p.box.Items()
+	p.box.Clean()
+	for _, item := range items {
+		p.pool.Put(item)
+	}
+}
+
+func (p *poolBox[T]) Items() []T {
+	return p.box.Items()
+}
+
+func (p *poolBox[T]) forEach(fn func(item T) bool) {
+	eachItem(p.box, fn)
+}
+
+func (p *poolBox[T]) mutatesOnRead() bool {
+	return mutatesOnRead(p.box)
+}
+
+func (p *poolBox[T]) mutatesOnPeek() bool {
+	return mutatesOnPeek(p.box)
+}
+
+func (p *poolBox[T]) drawPlan() drawPlan[T] {
+	return planOf(p.box)
+}
+
+// Compile-time assertion that poolBox implements BlackBox[T].
+var _ BlackBox[any] = (*poolBox[any])(nil)
+
+// Compile-time assertion that poolBox implements Recycler[T].
+var _ Recycler[any] = (*poolBox[any])(nil)
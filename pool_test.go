@@ -0,0 +1,50 @@
+package blackbox
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+)
+
+func TestPooled(t *testing.T) {
+	allocs := 0
+	pool := &sync.Pool{New: func() any {
+		allocs++
+		return new(bytes.Buffer)
+	}}
+	box := New[*bytes.Buffer](WithStrategy(StrategyFIFO), WithPool[*bytes.Buffer](pool))
+	r, ok := box.(Recycler[*bytes.Buffer])
+	if !ok {
+		t.Fatal("Expected WithPool to implement Recycler")
+	}
+
+	buf := r.Acquire()
+	if buf == nil || allocs != 1 {
+		t.Fatalf("Expected a new buffer from the pool, got %v", buf)
+	}
+	buf.WriteString("payload")
+	box.Put(buf)
+	got, _ := box.Get()
+	if got.String() != "payload" {
+		t.Errorf("Expected payload, got %q", got.String())
+	}
+	got.Reset()
+	r.Recycle(got)
+
+	// The pool may drop items at any time, so only check Acquire still works.
+	if r.Acquire() == nil {
+		t.Error("Expected a buffer after recycling")
+	}
+
+	box.Put(r.Acquire())
+	box.Put(r.Acquire())
+	box.Clean()
+	if !box.IsEmpty() {
+		t.Errorf("Expected Clean to empty the box, got %d", box.Size())
+	}
+
+	empty := NewPooled[*bytes.Buffer](NewFIFO[*bytes.Buffer](0, 0), &sync.Pool{})
+	if empty.Acquire() != nil {
+		t.Error("Expected the zero value from an empty pool without New")
+	}
+}
@@ -0,0 +1,127 @@
+package blackbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ProcessFailure is an item whose processing failed, along with the error.
+type ProcessFailure[T any] struct {
+	Item T
+	Err  error
+}
+
+// ProcessError aggregates the errors returned by the fn of Process.
+type ProcessError[T any] struct {
+	Failures []ProcessFailure[T]
+}
+
+func (e *ProcessError[T]) Error() string {
+	if len(e.Failures) == 1 {
+		return fmt.Sprintf("blackbox: 1 item failed to process: %v", e.Failures[0].Err)
+	}
+	return fmt.Sprintf("blackbox: %d items failed to process, first: %v", len(e.Failures), e.Failures[0].Err)
+}
+
+// Unwrap returns the error of the first failure. Use Is and As, or
+// Failures, to look at the errors of the other failures.
+func (e *ProcessError[T]) Unwrap() error {
+	if len(e.Failures) == 0 {
+		return nil
+	}
+	return e.Failures[0].Err
+}
+
+// Is reports whether the error of any failure matches target.
+func (e *ProcessError[T]) Is(target error) bool {
+	for _, f := range e.Failures {
+		if errors.Is(f.Err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first error of a failure that matches target, like errors.As.
+func (e *ProcessError[T]) As(target any) bool {
+	for _, f := range e.Failures {
+		if errors.As(f.Err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Process consumes the box with the given number of worker goroutines, each
+// calling fn for the items it gets, until the box is empty and no worker is
+// busy anymore (items put by fn are processed too) or ctx is done. Items held
+// back by the wrapped box, such as retries scheduled with NewRetry or items
+// pending in NewDebounce, are waited for.
+//
+// It returns a *ProcessError listing every item for which fn returned an
+// error, otherwise ctx.Err() if ctx is done, or nil.
+func (c *concurrentBox[T]) Process(ctx context.Context, workers int, fn func(ctx context.Context, item T) error) error {
+	if workers < 1 {
+		workers = 1
+	}
+
+	var (
+		wg       sync.WaitGroup
+		failMu   sync.Mutex
+		failures []ProcessFailure[T]
+		// active is the number of workers running fn, guarded by c.mu.
+		active int
+	)
+	worker := func() {
+		defer wg.Done()
+		for ctx.Err() == nil {
+			c.mu.Lock()
+			item, err := c.box.Get()
+			if err == nil {
+				active++
+				c.broadcast()
+				c.mu.Unlock()
+
+				if err := fn(ctx, item); err != nil {
+					failMu.Lock()
+					failures = append(failures, ProcessFailure[T]{Item: item, Err: err})
+					failMu.Unlock()
+				}
+
+				c.mu.Lock()
+				active--
+				if active == 0 {
+					c.broadcast()
+				}
+				c.mu.Unlock()
+				continue
+			}
+			if active == 0 && held(c.box) == 0 {
+				c.mu.Unlock()
+				return
+			}
+			// A busy worker may still put new items, and the box may
+			// release the items it holds back, such as scheduled retries.
+			wait := c.waitCh()
+			c.mu.Unlock()
+
+			select {
+			case <-ctx.Done():
+			case <-wait:
+			}
+		}
+	}
+
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go worker()
+	}
+	wg.Wait()
+
+	if len(failures) > 0 {
+		return &ProcessError[T]{Failures: failures}
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,103 @@
+package blackbox
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestProcess(t *testing.T) {
+	box := NewConcurrent[int](NewFIFO[int](0, 16))
+	for i := 1; i <= 10; i++ {
+		box.Put(i)
+	}
+
+	var mu sync.Mutex
+	var seen []int
+	errOdd := errors.New("odd")
+	err := box.Process(context.Background(), 3, func(ctx context.Context, item int) error {
+		if item == 10 {
+			// Items put while processing are consumed as well.
+			box.Put(11)
+		}
+		mu.Lock()
+		seen = append(seen, item)
+		mu.Unlock()
+		if item == 3 || item == 7 {
+			return errOdd
+		}
+		return nil
+	})
+
+	if len(seen) != 11 {
+		t.Errorf("Expected 11 processed items, got %d", len(seen))
+	}
+	for i := 1; i <= 11; i++ {
+		if !ContainsInt(seen, i) {
+			t.Errorf("Expected item %d to be processed", i)
+		}
+	}
+	if !box.IsEmpty() {
+		t.Errorf("Expected empty box, got size %d", box.Size())
+	}
+
+	pe, ok := err.(*ProcessError[int])
+	if !ok {
+		t.Fatalf("Expected *ProcessError, got %v", err)
+	}
+	if len(pe.Failures) != 2 || pe.Unwrap() != errOdd {
+		t.Fatalf("Expected 2 failures, got %d", len(pe.Failures))
+	}
+	for _, f := range pe.Failures {
+		if (f.Item != 3 && f.Item != 7) || f.Err != errOdd {
+			t.Errorf("Unexpected failure %+v", f)
+		}
+	}
+}
+
+func TestProcessErrorMatchesEveryFailure(t *testing.T) {
+	box := NewConcurrent[int](NewFIFO[int](0, 4))
+	box.Put(1)
+	box.Put(2)
+
+	errOdd, errEven := errors.New("odd"), errors.New("even")
+	err := box.Process(context.Background(), 1, func(ctx context.Context, item int) error {
+		if item == 1 {
+			return errOdd
+		}
+		return &InvalidItemError{Err: errEven}
+	})
+	if !errors.Is(err, errOdd) || !errors.Is(err, ErrInvalidItem) {
+		t.Errorf("Expected the error to match the errors of both failures, got %v", err)
+	}
+	if errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the error not to match context.Canceled, got %v", err)
+	}
+	var invalid *InvalidItemError
+	if !errors.As(err, &invalid) || invalid.Err != errEven {
+		t.Errorf("Expected errors.As to find the *InvalidItemError, got %v", err)
+	}
+}
+
+func TestProcessCanceled(t *testing.T) {
+	box := NewConcurrent[int](NewFIFO[int](0, 4))
+	box.Put(1)
+	box.Put(2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	err := box.Process(ctx, 1, func(ctx context.Context, item int) error {
+		cancel()
+		return nil
+	})
+	if err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if box.Size() != 1 {
+		t.Errorf("Expected 1 unprocessed item, got %d", box.Size())
+	}
+
+	if err := box.Process(context.Background(), 2, func(ctx context.Context, item int) error { return nil }); err != nil {
+		t.Errorf("Expected nil error, got %v", err)
+	}
+}
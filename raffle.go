@@ -0,0 +1,120 @@
+package blackbox
+
+import (
+	crand "crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/rand"
+)
+
+var ErrRaffleMismatch = errors.New("blackbox raffle does not match its commitment")
+
+// raffleSecretSize is the size of the secret a raffle seeds its draws from.
+const raffleSecretSize = 32
+
+// Commitment is the SHA-256 hash of a raffle secret. It is published before
+// the draw and binds the raffle to its secret without disclosing it.
+type Commitment [sha256.Size]byte
+
+// String returns the commitment in hexadecimal, as it is usually published.
+func (c Commitment) String() string {
+	return hex.EncodeToString(c[:])
+}
+
+// raffle is a Random box whose RNG is seeded from a secret committed to
+// before the first draw, so that participants can verify the outcome once the
+// secret is revealed.
+type raffle[T any] struct {
+	box    *randomBox[T]
+	secret []byte
+	commit Commitment
+	drawn  []T
+}
+
+// NewRaffle creates a new verifiable raffle of the given participants with a
+// secret read from crypto/rand. Publish Commitment before the draw and the
+// secret returned by Reveal after it; anyone can then check the winners with
+// VerifyRaffle. Participants are copied and fixed for the life of the raffle.
+// Returns a concrete instance of raffle.
+func NewRaffle[T any](participants []T) (*raffle[T], error) {
+	secret := make([]byte, raffleSecretSize)
+	if _, err := crand.Read(secret); err != nil {
+		return nil, err
+	}
+	return NewRaffleSecret(participants, secret), nil
+}
+
+// NewRaffleSecret is like NewRaffle with the given secret, e.g. one mixing
+// the operator's secret with a public value such as a block hash. The secret
+// must stay private until the draw is over.
+func NewRaffleSecret[T any](participants []T, secret []byte) *raffle[T] {
+	return &raffle[T]{
+		box:    NewRandomFrom(participants, 0, raffleRand(secret)),
+		secret: append([]byte(nil), secret...),
+		commit: sha256.Sum256(secret),
+	}
+}
+
+// raffleRand returns the RNG of the draws made from secret.
+func raffleRand(secret []byte) *rand.Rand {
+	sum := sha256.Sum256(append([]byte("blackbox raffle seed:"), secret...))
+	return rand.New(rand.NewSource(int64(binary.BigEndian.Uint64(sum[:8]))))
+}
+
+// Commitment returns the hash of the secret to publish before the draw.
+func (r *raffle[T]) Commitment() Commitment {
+	return r.commit
+}
+
+// Draw draws the next winner. It returns ErrEmptyBlackBox once every
+// participant has been drawn.
+func (r *raffle[T]) Draw() (T, error) {
+	item, err := r.box.Get()
+	if err != nil {
+		return item, err
+	}
+	r.drawn = append(r.drawn, item)
+	return item, nil
+}
+
+// Drawn returns the winners in the order they were drawn.
+func (r *raffle[T]) Drawn() []T {
+	drawn := make([]T, len(r.drawn))
+	copy(drawn, r.drawn)
+	return drawn
+}
+
+// Remaining returns the number of participants not drawn yet.
+func (r *raffle[T]) Remaining() int {
+	return r.box.Size()
+}
+
+// Reveal returns the secret to publish after the draw. Once revealed, the
+// remaining draws are predictable, so reveal only when the raffle is over.
+func (r *raffle[T]) Reveal() []byte {
+	return append([]byte(nil), r.secret...)
+}
+
+// VerifyRaffle checks that secret matches commitment and that drawing from
+// participants with it yields winners, in order. It returns an error wrapping
+// ErrRaffleMismatch otherwise.
+func VerifyRaffle[T comparable](commitment Commitment, secret []byte, participants, winners []T) error {
+	sum := sha256.Sum256(secret)
+	if Commitment(sum) != commitment {
+		return fmt.Errorf("%w: secret hashes to %s, not %s", ErrRaffleMismatch, Commitment(sum), commitment)
+	}
+	r := NewRaffleSecret(participants, secret)
+	for i, want := range winners {
+		got, err := r.Draw()
+		if err != nil {
+			return fmt.Errorf("%w: %d winners for %d participants", ErrRaffleMismatch, len(winners), len(participants))
+		}
+		if got != want {
+			return fmt.Errorf("%w: winner %d is %v, not %v", ErrRaffleMismatch, i+1, got, want)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package blackbox
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRaffleVerify(t *testing.T) {
+	participants := []string{"alice", "bob", "carol", "dave", "erin"}
+	r, err := NewRaffle(participants)
+	if err != nil {
+		t.Fatal(err)
+	}
+	commitment := r.Commitment()
+	for i := 0; i < 3; i++ {
+		if _, err := r.Draw(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if r.Remaining() != 2 {
+		t.Errorf("Expected 2 remaining participants, got %d", r.Remaining())
+	}
+	winners := r.Drawn()
+	secret := r.Reveal()
+
+	if err := VerifyRaffle(commitment, secret, participants, winners); err != nil {
+		t.Errorf("Expected the raffle to verify, got %v", err)
+	}
+
+	rigged := []string{winners[1], winners[0], winners[2]}
+	if err := VerifyRaffle(commitment, secret, participants, rigged); !errors.Is(err, ErrRaffleMismatch) {
+		t.Errorf("Expected ErrRaffleMismatch for swapped winners, got %v", err)
+	}
+	other := append([]byte(nil), secret...)
+	other[0]++
+	if err := VerifyRaffle(commitment, other, participants, winners); !errors.Is(err, ErrRaffleMismatch) {
+		t.Errorf("Expected ErrRaffleMismatch for another secret, got %v", err)
+	}
+	if err := VerifyRaffle(commitment, secret, participants[:2], winners); !errors.Is(err, ErrRaffleMismatch) {
+		t.Errorf("Expected ErrRaffleMismatch for too many winners, got %v", err)
+	}
+}
+
+func TestRaffleSecretReproducible(t *testing.T) {
+	participants := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	a := NewRaffleSecret(participants, []byte("secret"))
+	b := NewRaffleSecret(participants, []byte("secret"))
+	if a.Commitment() != b.Commitment() {
+		t.Error("Expected equal commitments for equal secrets")
+	}
+	for range participants {
+		x, _ := a.Draw()
+		y, _ := b.Draw()
+		if x != y {
+			t.Fatalf("Expected equal draws, got %d and %d", x, y)
+		}
+	}
+	if _, err := a.Draw(); err != ErrEmptyBlackBox {
+		t.Errorf("Expected ErrEmptyBlackBox, got %v", err)
+	}
+	if len(a.Commitment().String()) != 64 {
+		t.Errorf("Expected a 64-digit hex commitment, got %s", a.Commitment())
+	}
+}
@@ -0,0 +1,33 @@
+//go:build go1.22
+
+package blackbox
+
+import (
+	"math/rand"
+	randv2 "math/rand/v2"
+)
+
+// WithRandV2 makes the Random strategy draw items from a math/rand/v2 source,
+// such as rand.NewPCG or rand.NewChaCha8. Requires Go 1.22 or later.
+func WithRandV2(src randv2.Source) Option {
+	return WithRandSource(v2Source{src})
+}
+
+// v2Source adapts a math/rand/v2 source to math/rand. Seed is a no-op;
+// seed the v2 source itself instead.
+type v2Source struct {
+	src randv2.Source
+}
+
+func (s v2Source) Seed(int64) {}
+
+func (s v2Source) Int63() int64 {
+	return int64(s.src.Uint64() &^ (1 << 63))
+}
+
+func (s v2Source) Uint64() uint64 {
+	return s.src.Uint64()
+}
+
+// Compile-time assertion that v2Source implements rand.Source64.
+var _ rand.Source64 = v2Source{}
@@ -0,0 +1,28 @@
+//go:build go1.22
+
+package blackbox
+
+import (
+	randv2 "math/rand/v2"
+	"testing"
+)
+
+func TestRandomWithRandV2(t *testing.T) {
+	draw := func() []int {
+		box := NewFrom[int]([]int{1, 2, 3, 4, 5, 6, 7, 8}, WithRandV2(randv2.NewPCG(1, 2)))
+		var items []int
+		for !box.IsEmpty() {
+			item, _ := box.Get()
+			items = append(items, item)
+		}
+		return items
+	}
+
+	first, second := draw(), draw()
+	if len(first) != 8 {
+		t.Fatalf("Expected 8 items, got %v", first)
+	}
+	if !EqualInts(first, second) {
+		t.Errorf("Expected the same PCG seed to give the same draws, got %v and %v", first, second)
+	}
+}
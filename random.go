@@ -1,13 +1,39 @@
 package blackbox
 
 import (
+	"math"
 	"math/rand"
+	"time"
 )
 
+// Weighted is implemented by blackboxes of the Random strategy.
+type Weighted[T any] interface {
+	BlackBox[T]
+	// PutWeighted inserts an item that is drawn with a probability proportional to weight.
+	PutWeighted(item T, weight float64) error
+}
+
 type randomBox[T any] struct {
-	items   []T
-	rng     *rand.Rand
-	maxSize int
+	items    []T
+	rng      *rand.Rand
+	src      *seededSource // nil unless rng is reproducible, see Clone
+	maxSize  int
+	overflow OverflowPolicy
+	onEvict  func(item T)
+	name     string // see WithName
+
+	// bias weights items by their age, see WithAgeBias.
+	// putAt is kept parallel to items only while bias is set.
+	bias  func(age time.Duration) float64
+	putAt []time.Time
+	now   func() time.Time
+
+	// itemWeights is kept parallel to items once PutWeighted has been used.
+	itemWeights []float64
+
+	// drawn counts the items at the end of items that PeekN has already
+	// drawn; the last item is the one the next Get returns.
+	drawn int
 }
 
 // NewRandom creates a new Random blackbox with the specified maximum size, capacity and rng.
@@ -48,38 +74,265 @@ func NewRandomFromBlackBox[T any](box BlackBox[T], maxSize int, rng *rand.Rand)
 	}
 }
 
+// setAgeBias enables age-biased selection. Items already in the box are
+// treated as if they were put now.
+func (b *randomBox[T]) setAgeBias(bias func(age time.Duration) float64, now func() time.Time) {
+	b.bias = bias
+	b.now = now
+	t := now()
+	b.putAt = make([]time.Time, len(b.items), cap(b.items))
+	for i := range b.putAt {
+		b.putAt[i] = t
+	}
+}
+
+// weights returns the selection weight of every item, or nil when selection is uniform.
+func (b *randomBox[T]) weights() []float64 {
+	if b.bias == nil && b.itemWeights == nil {
+		return nil
+	}
+	weights := make([]float64, len(b.items))
+	for i := range weights {
+		weights[i] = 1
+	}
+	if b.itemWeights != nil {
+		copy(weights, b.itemWeights)
+	}
+	if b.bias != nil {
+		now := b.now()
+		for i, t := range b.putAt {
+			weights[i] *= b.bias(now.Sub(t))
+		}
+	}
+	return weights
+}
+
+// pick selects the index of the next item to return. Items drawn by PeekN
+// are returned first, in the order they were drawn.
+func (b *randomBox[T]) pick() int {
+	if b.drawn > 0 {
+		b.drawn--
+		return len(b.items) - 1
+	}
+	return b.draw()
+}
+
+// draw selects the index of a random item among those not drawn by PeekN yet.
+func (b *randomBox[T]) draw() int {
+	n := len(b.items) - b.drawn
+	if weights := b.weights(); weights != nil {
+		if idx, ok := pickWeighted(b.rng, weights[:n]); ok {
+			return idx
+		}
+	}
+	return b.rng.Intn(n)
+}
+
+// swap exchanges the items at i and j along with their age and weight.
+func (b *randomBox[T]) swap(i, j int) {
+	b.items[i], b.items[j] = b.items[j], b.items[i]
+	if b.bias != nil {
+		b.putAt[i], b.putAt[j] = b.putAt[j], b.putAt[i]
+	}
+	if b.itemWeights != nil {
+		b.itemWeights[i], b.itemWeights[j] = b.itemWeights[j], b.itemWeights[i]
+	}
+}
+
+// pickWeighted selects an index with probability proportional to its weight.
+// Non-positive weights are never selected; ok is false when no weight is positive.
+func pickWeighted(rng *rand.Rand, weights []float64) (idx int, ok bool) {
+	total := 0.0
+	for _, w := range weights {
+		if w > 0 {
+			total += w
+		}
+	}
+	// Also rejects NaN and +Inf totals.
+	if !(total > 0) || math.IsInf(total, 1) {
+		return 0, false
+	}
+	r := rng.Float64() * total
+	last := 0
+	for i, w := range weights {
+		if !(w > 0) {
+			continue
+		}
+		if r < w {
+			return i, true
+		}
+		r -= w
+		last = i
+	}
+	// Floating point rounding can leave r marginally above the last weight.
+	return last, true
+}
+
+// remove removes the item at idx by swapping it with the last item.
+func (b *randomBox[T]) remove(idx int) T {
+	item := b.items[idx]
+	lastIdx := len(b.items) - 1
+	b.items[idx] = b.items[lastIdx]
+	var zero T
+	b.items[lastIdx] = zero
+	b.items = b.items[:lastIdx]
+	if b.bias != nil {
+		b.putAt[idx] = b.putAt[lastIdx]
+		b.putAt = b.putAt[:lastIdx]
+	}
+	if b.itemWeights != nil {
+		b.itemWeights[idx] = b.itemWeights[lastIdx]
+		b.itemWeights = b.itemWeights[:lastIdx]
+	}
+	return item
+}
+
+// oldest returns the index of the oldest item. Insertion order is only
+// tracked for age-biased boxes; otherwise a random index is returned.
+func (b *randomBox[T]) oldest() int {
+	if b.bias == nil {
+		return b.rng.Intn(len(b.items))
+	}
+	idx := 0
+	for i, t := range b.putAt {
+		if t.Before(b.putAt[idx]) {
+			idx = i
+		}
+	}
+	return idx
+}
+
+// evict reports an item removed by the box itself to the evict callback.
+func (b *randomBox[T]) evict(item T) {
+	if b.onEvict != nil {
+		b.onEvict(item)
+	}
+}
+
 func (b *randomBox[T]) Put(item T) error {
+	return b.put(item, 1)
+}
+
+// PutWeighted inserts an item that Get selects with a probability proportional
+// to weight; items inserted with Put have a weight of 1. An item with a weight
+// of 0 is only drawn once no item with a positive weight is left.
+// Returns ErrInvalidWeight if weight is negative, NaN or infinite.
+func (b *randomBox[T]) PutWeighted(item T, weight float64) error {
+	if !(weight >= 0) || math.IsInf(weight, 1) {
+		return ErrInvalidWeight
+	}
+	if b.itemWeights == nil && weight != 1 {
+		b.itemWeights = make([]float64, len(b.items), cap(b.items))
+		for i := range b.itemWeights {
+			b.itemWeights[i] = 1
+		}
+	}
+	return b.put(item, weight)
+}
+
+func (b *randomBox[T]) put(item T, weight float64) error {
 	if b.maxSize > 0 && len(b.items) >= b.maxSize {
-		return ErrBlackBoxFull
+		switch b.overflow {
+		case OverflowDropOldest:
+			b.drawn = 0
+			b.evict(b.remove(b.oldest()))
+		case OverflowDropNewest:
+			b.evict(item)
+			return nil
+		default:
+			return fullError(b.name, len(b.items), b.maxSize)
+		}
 	}
 	b.items = append(b.items, item)
+	if b.bias != nil {
+		b.putAt = append(b.putAt, b.now())
+	}
+	if b.itemWeights != nil {
+		b.itemWeights = append(b.itemWeights, weight)
+	}
+	// Keep the items drawn by PeekN at the end.
+	for i := len(b.items) - 1; i > len(b.items)-1-b.drawn; i-- {
+		b.swap(i, i-1)
+	}
 	return nil
 }
 
 func (b *randomBox[T]) Get() (T, error) {
 	if len(b.items) == 0 {
 		var zero T
-		return zero, ErrEmptyBlackBox
+		return zero, emptyError(b.name)
 	}
-
-	idx := b.rng.Intn(len(b.items))
-	item := b.items[idx]
-	lastIdx := len(b.items) - 1
-	b.items[idx] = b.items[lastIdx]
-	b.items = b.items[:lastIdx]
-	return item, nil
+	return b.remove(b.pick()), nil
 }
 
 // Peek returns a random item from the blackbox without removing it.
 // In Random Strategy, Peek() behaviour will return different items when called multiple times,
 // and not guaranteed to be the same item when Get() called as the last call to Peek().
+// Once PeekN has drawn the next items, Peek returns the one the next Get returns.
 func (b *randomBox[T]) Peek() (T, error) {
 	if len(b.items) == 0 {
 		var zero T
-		return zero, ErrEmptyBlackBox
+		return zero, emptyError(b.name)
+	}
+	if b.drawn > 0 {
+		return b.items[len(b.items)-1], nil
+	}
+	return b.items[b.draw()], nil
+}
+
+// PeekN draws the next n items without removing them, so that the following
+// n calls to Get return them in the same order. Items put afterwards are only
+// drawn after them. Evicting the oldest item on overflow and Clean discard the
+// drawn order.
+func (b *randomBox[T]) PeekN(n int) []T {
+	if n > len(b.items) {
+		n = len(b.items)
 	}
-	idx := b.rng.Intn(len(b.items))
-	return b.items[idx], nil
+	for b.drawn < n {
+		b.swap(b.draw(), len(b.items)-1-b.drawn)
+		b.drawn++
+	}
+	items := make([]T, 0, n)
+	for i := 0; i < n; i++ {
+		items = append(items, b.items[len(b.items)-1-i])
+	}
+	return items
+}
+
+// removeFunc removes every item for which pred returns true and returns the removed items.
+func (b *randomBox[T]) removeFunc(pred func(item T) bool) []T {
+	var removed []T
+	kept, drawn := 0, 0
+	for i, item := range b.items {
+		if pred(item) {
+			removed = append(removed, item)
+			continue
+		}
+		if i >= len(b.items)-b.drawn {
+			drawn++
+		}
+		b.items[kept] = item
+		if b.bias != nil {
+			b.putAt[kept] = b.putAt[i]
+		}
+		if b.itemWeights != nil {
+			b.itemWeights[kept] = b.itemWeights[i]
+		}
+		kept++
+	}
+	var zero T
+	for i := kept; i < len(b.items); i++ {
+		b.items[i] = zero
+	}
+	b.items = b.items[:kept]
+	b.drawn = drawn
+	if b.bias != nil {
+		b.putAt = b.putAt[:kept]
+	}
+	if b.itemWeights != nil {
+		b.itemWeights = b.itemWeights[:kept]
+	}
+	return removed
 }
 
 func (b *randomBox[T]) Size() int {
@@ -99,7 +352,19 @@ func (b *randomBox[T]) IsEmpty() bool {
 }
 
 func (b *randomBox[T]) Clean() {
+	if b.onEvict != nil {
+		for _, item := range b.items {
+			b.onEvict(item)
+		}
+	}
+	var zero T
+	for i := range b.items {
+		b.items[i] = zero
+	}
 	b.items = b.items[:0]
+	b.putAt = b.putAt[:0]
+	b.itemWeights = nil
+	b.drawn = 0
 }
 
 func (b *randomBox[T]) Items() []T {
@@ -107,3 +372,12 @@ func (b *randomBox[T]) Items() []T {
 	copy(items, b.items)
 	return items
 }
+
+// mutatesOnPeek reports true: Peek draws from the RNG, so concurrent Peeks
+// must not share a read lock.
+func (b *randomBox[T]) mutatesOnPeek() bool {
+	return true
+}
+
+// Compile-time assertion that randomBox implements Weighted[T].
+var _ Weighted[any] = (*randomBox[any])(nil)
@@ -0,0 +1,197 @@
+package blackbox
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestRandomAgeBiasFavoursFresh(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	box := NewRandom[string](0, 4, rand.New(rand.NewSource(1)))
+	box.setAgeBias(func(age time.Duration) float64 {
+		if age < time.Minute {
+			return 1
+		}
+		return 0
+	}, clock.Now)
+
+	box.Put("old")
+	clock.Advance(time.Hour)
+	box.Put("fresh")
+
+	for i := 0; i < 20; i++ {
+		if item, _ := box.Peek(); item != "fresh" {
+			t.Fatalf("Expected peek to favour fresh item, got %s", item)
+		}
+	}
+	if item, _ := box.Get(); item != "fresh" {
+		t.Fatalf("Expected fresh item, got %s", item)
+	}
+
+	// Only zero-weight items remain, so selection falls back to uniform.
+	if item, _ := box.Get(); item != "old" {
+		t.Fatalf("Expected old item, got %s", item)
+	}
+	if len(box.putAt) != 0 {
+		t.Errorf("Expected putAt to shrink with items, got %d", len(box.putAt))
+	}
+}
+
+func TestRandomAgeBiasFavoursOld(t *testing.T) {
+	box := NewFrom[int]([]int{1}, WithSeed(3), WithAgeBias(func(age time.Duration) float64 {
+		return float64(age)
+	})).(*randomBox[int])
+	clock := &fakeClock{now: time.Now().Add(time.Hour)}
+	box.now = clock.Now
+	box.Put(2)
+	clock.Advance(time.Nanosecond)
+
+	// Item 1 is an hour older than item 2, so it wins almost every draw.
+	odds := OddsOf[int](box, 1, 1)
+	if odds.Next < 0.99 {
+		t.Errorf("Expected old item to dominate, got next odds %v", odds.Next)
+	}
+	report := OddsReport[int](box, 2)
+	if report[0].Item != 1 || report[1].Within != 1 {
+		t.Errorf("Unexpected report %+v", report)
+	}
+
+	box.Clean()
+	if !box.IsEmpty() || len(box.putAt) != 0 {
+		t.Errorf("Expected Clean() to reset ages")
+	}
+}
+
+func TestPickWeighted(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	if _, ok := pickWeighted(rng, []float64{0, -1}); ok {
+		t.Error("Expected no selection when no weight is positive")
+	}
+	counts := make([]int, 3)
+	for i := 0; i < 10000; i++ {
+		idx, ok := pickWeighted(rng, []float64{1, 0, 3})
+		if !ok {
+			t.Fatal("Expected a selection")
+		}
+		counts[idx]++
+	}
+	if counts[1] != 0 {
+		t.Errorf("Zero weight item was selected %d times", counts[1])
+	}
+	if counts[2] < 2*counts[0] {
+		t.Errorf("Expected weight 3 to be drawn about 3x weight 1, got %v", counts)
+	}
+}
+
+func TestRandomPutWeighted(t *testing.T) {
+	box := NewRandom[string](0, 4, rand.New(rand.NewSource(1)))
+	box.Put("common")
+	if err := box.PutWeighted("rare", 0.25); err != nil {
+		t.Fatalf("Failed to put weighted item: %v", err)
+	}
+	box.PutWeighted("never", 0)
+
+	counts := map[string]int{}
+	for i := 0; i < 10000; i++ {
+		item, _ := box.Peek()
+		counts[item]++
+	}
+	if counts["never"] != 0 {
+		t.Errorf("Expected zero-weight item not to be drawn, got %d", counts["never"])
+	}
+	// Expected ratio rare/common is 0.25.
+	if ratio := float64(counts["rare"]) / float64(counts["common"]); ratio < 0.2 || ratio > 0.3 {
+		t.Errorf("Expected rare/common ratio around 0.25, got %v", ratio)
+	}
+
+	odds := OddsOf[string](box, "rare", 1)
+	if !almostEqual(odds.Next, 0.2) {
+		t.Errorf("Expected next odds 0.2, got %v", odds.Next)
+	}
+
+	for _, weight := range []float64{-1, math.NaN(), math.Inf(1)} {
+		if err := box.PutWeighted("bad", weight); err != ErrInvalidWeight {
+			t.Errorf("Weight=%v Expected ErrInvalidWeight, got %v", weight, err)
+		}
+	}
+
+	box.Get()
+	box.Get()
+	if item, _ := box.Get(); item != "never" {
+		t.Errorf("Expected zero-weight item to be drawn last, got %s", item)
+	}
+	if len(box.itemWeights) != 0 {
+		t.Errorf("Expected itemWeights to shrink with items, got %d", len(box.itemWeights))
+	}
+}
+
+func TestRandomPutWeightedThroughNew(t *testing.T) {
+	box, ok := New[int](WithSeed(1), WithMaxSize(1)).(Weighted[int])
+	if !ok {
+		t.Fatal("Expected random box to implement Weighted")
+	}
+	box.PutWeighted(1, 2)
+	if err := box.PutWeighted(2, 2); err != ErrBlackBoxFull {
+		t.Errorf("Expected ErrBlackBoxFull, got %v", err)
+	}
+}
+
+func TestRandomCryptoRand(t *testing.T) {
+	box := New[int](WithSeed(1), WithCryptoRand())
+	rb, ok := box.(*randomBox[int])
+	if !ok {
+		t.Fatal("Expected a random box")
+	}
+	for i := 0; i < 100; i++ {
+		if n := rb.rng.Int63(); n < 0 {
+			t.Fatalf("Expected a non-negative Int63, got %d", n)
+		}
+	}
+
+	for i := 0; i < 100; i++ {
+		box.Put(i)
+	}
+	seen := make([]int, 0, 100)
+	for !box.IsEmpty() {
+		item, err := box.Get()
+		if err != nil {
+			t.Fatalf("Failed to get item: %v", err)
+		}
+		seen = append(seen, item)
+	}
+	if len(seen) != 100 {
+		t.Errorf("Expected 100 items, got %d", len(seen))
+	}
+
+	seeded := parseOptions([]Option{WithCryptoRand(), WithSeed(1)})
+	if seeded.source != nil || !seeded.useSeed {
+		t.Error("Expected WithSeed after WithCryptoRand to win")
+	}
+}
+
+func TestRandomWithRand(t *testing.T) {
+	draw := func(opt Option) []int {
+		box := NewFrom[int]([]int{1, 2, 3, 4, 5, 6, 7, 8}, opt)
+		var items []int
+		for !box.IsEmpty() {
+			item, _ := box.Get()
+			items = append(items, item)
+		}
+		return items
+	}
+
+	seeded := draw(WithSeed(7))
+	if fromRand := draw(WithRand(rand.New(rand.NewSource(7)))); !EqualInts(fromRand, seeded) {
+		t.Errorf("Expected WithRand to match WithSeed, got %v and %v", fromRand, seeded)
+	}
+	if fromSource := draw(WithRandSource(rand.NewSource(7))); !EqualInts(fromSource, seeded) {
+		t.Errorf("Expected WithRandSource to match WithSeed, got %v and %v", fromSource, seeded)
+	}
+
+	r := rand.New(rand.NewSource(1))
+	if box := New[int](WithRand(r)).(*randomBox[int]); box.rng != r {
+		t.Error("Expected WithRand to use the given *rand.Rand")
+	}
+}
@@ -0,0 +1,250 @@
+// Package redisbox provides blackboxes stored in Redis, so that several
+// processes can share one logical blackbox with the same Put/Get/Peek API.
+//
+// The package has no dependencies: the boxes send commands through the
+// one-method Doer interface, which most Redis clients satisfy with a small
+// adapter. For example, with go-redis:
+//
+//	type doer struct{ *redis.Client }
+//
+//	func (d doer) Do(ctx context.Context, args ...any) (any, error) {
+//		v, err := d.Client.Do(ctx, args...).Result()
+//		if err == redis.Nil {
+//			return nil, nil
+//		}
+//		return v, err
+//	}
+package redisbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/raditzlawliet/blackbox"
+)
+
+// Doer sends a single command to Redis. It returns a nil reply with a nil
+// error for a Redis nil reply; bulk strings may be returned as string or
+// []byte, integers as int64 and arrays as []any.
+type Doer interface {
+	Do(ctx context.Context, args ...any) (any, error)
+}
+
+var errUnexpectedReply = errors.New("redisbox: unexpected reply")
+
+type kind int
+
+const (
+	kindFIFO kind = iota
+	kindLIFO
+	kindRandom
+)
+
+// redisBox stores FIFO and LIFO items in a Redis list, and Random items in a
+// Redis set whose members are prefixed with a unique id so that equal items
+// can be stored more than once.
+type redisBox[T any] struct {
+	client  Doer
+	key     string
+	kind    kind
+	maxSize int
+	codec   blackbox.ItemCodec[T]
+	err     error
+}
+
+// NewRedisFIFO creates a FIFO blackbox stored in the Redis list at key
+// (RPUSH/LPOP). A maxSize of 0 means unlimited; it is checked before every Put
+// and is therefore only approximate when several processes put concurrently.
+// Returns a concrete instance of redis blackbox without interface.
+func NewRedisFIFO[T any](client Doer, key string, maxSize int, codec blackbox.ItemCodec[T]) *redisBox[T] {
+	return &redisBox[T]{client: client, key: key, kind: kindFIFO, maxSize: maxSize, codec: codec}
+}
+
+// NewRedisLIFO creates a LIFO blackbox stored in the Redis list at key (RPUSH/RPOP).
+// Returns a concrete instance of redis blackbox without interface.
+func NewRedisLIFO[T any](client Doer, key string, maxSize int, codec blackbox.ItemCodec[T]) *redisBox[T] {
+	return &redisBox[T]{client: client, key: key, kind: kindLIFO, maxSize: maxSize, codec: codec}
+}
+
+// NewRedisRandom creates a Random blackbox stored in the Redis set at key
+// (SADD/SPOP/SRANDMEMBER), using key+":seq" to generate unique member ids.
+// Get removes a random item atomically, even across processes.
+// Returns a concrete instance of redis blackbox without interface.
+func NewRedisRandom[T any](client Doer, key string, maxSize int, codec blackbox.ItemCodec[T]) *redisBox[T] {
+	return &redisBox[T]{client: client, key: key, kind: kindRandom, maxSize: maxSize, codec: codec}
+}
+
+func (b *redisBox[T]) do(args ...any) (any, error) {
+	return b.client.Do(context.Background(), args...)
+}
+
+// Err returns the last Redis error hit by a method that cannot return one
+// (Size, IsFull, IsEmpty, Clean and Items).
+func (b *redisBox[T]) Err() error {
+	return b.err
+}
+
+func (b *redisBox[T]) size() (int, error) {
+	cmd := "LLEN"
+	if b.kind == kindRandom {
+		cmd = "SCARD"
+	}
+	reply, err := b.do(cmd, b.key)
+	if err != nil {
+		return 0, err
+	}
+	n, ok := reply.(int64)
+	if !ok {
+		return 0, errUnexpectedReply
+	}
+	return int(n), nil
+}
+
+func (b *redisBox[T]) Put(item T) error {
+	if b.maxSize > 0 {
+		size, err := b.size()
+		if err != nil {
+			return err
+		}
+		if size >= b.maxSize {
+			return blackbox.ErrBlackBoxFull
+		}
+	}
+	data, err := b.codec.Encode(item)
+	if err != nil {
+		return err
+	}
+	if b.kind != kindRandom {
+		_, err = b.do("RPUSH", b.key, data)
+		return err
+	}
+	reply, err := b.do("INCR", b.key+":seq")
+	if err != nil {
+		return err
+	}
+	id, ok := reply.(int64)
+	if !ok {
+		return errUnexpectedReply
+	}
+	member := strconv.FormatInt(id, 10) + ":" + string(data)
+	_, err = b.do("SADD", b.key, member)
+	return err
+}
+
+// decode decodes a stored value, stripping the member id of Random items.
+func (b *redisBox[T]) decode(reply any) (T, error) {
+	var zero T
+	var s string
+	switch v := reply.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return zero, errUnexpectedReply
+	}
+	if b.kind == kindRandom {
+		i := strings.IndexByte(s, ':')
+		if i < 0 {
+			return zero, fmt.Errorf("%w: member %q has no id", errUnexpectedReply, s)
+		}
+		s = s[i+1:]
+	}
+	return b.codec.Decode([]byte(s))
+}
+
+func (b *redisBox[T]) fetch(remove bool) (T, error) {
+	var args []any
+	switch {
+	case b.kind == kindRandom && remove:
+		args = []any{"SPOP", b.key}
+	case b.kind == kindRandom:
+		args = []any{"SRANDMEMBER", b.key}
+	case b.kind == kindFIFO && remove:
+		args = []any{"LPOP", b.key}
+	case b.kind == kindLIFO && remove:
+		args = []any{"RPOP", b.key}
+	case b.kind == kindFIFO:
+		args = []any{"LINDEX", b.key, 0}
+	default:
+		args = []any{"LINDEX", b.key, -1}
+	}
+	reply, err := b.do(args...)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	if reply == nil {
+		var zero T
+		return zero, blackbox.ErrEmptyBlackBox
+	}
+	return b.decode(reply)
+}
+
+func (b *redisBox[T]) Get() (T, error) {
+	return b.fetch(true)
+}
+
+func (b *redisBox[T]) Peek() (T, error) {
+	return b.fetch(false)
+}
+
+func (b *redisBox[T]) Size() int {
+	size, err := b.size()
+	if err != nil {
+		b.err = err
+	}
+	return size
+}
+
+func (b *redisBox[T]) MaxSize() int {
+	return b.maxSize
+}
+
+func (b *redisBox[T]) IsFull() bool {
+	return b.maxSize > 0 && b.Size() >= b.maxSize
+}
+
+func (b *redisBox[T]) IsEmpty() bool {
+	return b.Size() == 0
+}
+
+func (b *redisBox[T]) Clean() {
+	if _, err := b.do("DEL", b.key); err != nil {
+		b.err = err
+	}
+}
+
+// Items returns a copy of all items, in insertion order for FIFO and LIFO boxes.
+func (b *redisBox[T]) Items() []T {
+	args := []any{"LRANGE", b.key, 0, -1}
+	if b.kind == kindRandom {
+		args = []any{"SMEMBERS", b.key}
+	}
+	reply, err := b.do(args...)
+	if err != nil {
+		b.err = err
+		return nil
+	}
+	values, ok := reply.([]any)
+	if !ok {
+		b.err = errUnexpectedReply
+		return nil
+	}
+	items := make([]T, 0, len(values))
+	for _, v := range values {
+		item, err := b.decode(v)
+		if err != nil {
+			b.err = err
+			continue
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// Compile-time assertion that redisBox implements BlackBox[T].
+var _ blackbox.BlackBox[any] = (*redisBox[any])(nil)
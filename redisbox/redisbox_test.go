@@ -0,0 +1,161 @@
+package redisbox
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/raditzlawliet/blackbox"
+	"github.com/raditzlawliet/blackbox/blackboxtest"
+)
+
+// fakeRedis implements the few commands used by redisbox in memory.
+type fakeRedis struct {
+	lists    map[string][]string
+	sets     map[string]map[string]bool
+	counters map[string]int64
+}
+
+func newFakeRedis() *fakeRedis {
+	return &fakeRedis{
+		lists:    map[string][]string{},
+		sets:     map[string]map[string]bool{},
+		counters: map[string]int64{},
+	}
+}
+
+func str(v any) string {
+	if b, ok := v.([]byte); ok {
+		return string(b)
+	}
+	return fmt.Sprint(v)
+}
+
+func (r *fakeRedis) Do(_ context.Context, args ...any) (any, error) {
+	key := str(args[1])
+	list := r.lists[key]
+	switch args[0] {
+	case "RPUSH":
+		r.lists[key] = append(list, str(args[2]))
+		return int64(len(r.lists[key])), nil
+	case "LPOP", "RPOP":
+		if len(list) == 0 {
+			return nil, nil
+		}
+		if args[0] == "LPOP" {
+			r.lists[key] = list[1:]
+			return list[0], nil
+		}
+		r.lists[key] = list[:len(list)-1]
+		return list[len(list)-1], nil
+	case "LINDEX":
+		if len(list) == 0 {
+			return nil, nil
+		}
+		if args[2].(int) < 0 {
+			return list[len(list)-1], nil
+		}
+		return list[0], nil
+	case "LLEN":
+		return int64(len(list)), nil
+	case "LRANGE", "SMEMBERS":
+		var values []any
+		for _, v := range list {
+			values = append(values, v)
+		}
+		for m := range r.sets[key] {
+			values = append(values, m)
+		}
+		return values, nil
+	case "INCR":
+		r.counters[key]++
+		return r.counters[key], nil
+	case "SADD":
+		if r.sets[key] == nil {
+			r.sets[key] = map[string]bool{}
+		}
+		r.sets[key][str(args[2])] = true
+		return int64(1), nil
+	case "SPOP", "SRANDMEMBER":
+		for m := range r.sets[key] {
+			if args[0] == "SPOP" {
+				delete(r.sets[key], m)
+			}
+			return []byte(m), nil
+		}
+		return nil, nil
+	case "SCARD":
+		return int64(len(r.sets[key])), nil
+	case "DEL":
+		delete(r.lists, key)
+		delete(r.sets, key)
+		return int64(1), nil
+	}
+	return nil, fmt.Errorf("unknown command %v", args[0])
+}
+
+func TestRedisFIFOAndLIFO(t *testing.T) {
+	client := newFakeRedis()
+	fifo := NewRedisFIFO[string](client, "jobs", 2, blackbox.DefaultItemCodec[string]())
+	fifo.Put("a")
+	fifo.Put("b")
+	if err := fifo.Put("c"); err != blackbox.ErrBlackBoxFull {
+		t.Errorf("Expected ErrBlackBoxFull, got %v", err)
+	}
+
+	// A second process sees the same box.
+	other := NewRedisFIFO[string](client, "jobs", 2, blackbox.DefaultItemCodec[string]())
+	if item, _ := other.Peek(); item != "a" {
+		t.Errorf("Expected a, got %s", item)
+	}
+	if item, _ := other.Get(); item != "a" || fifo.Size() != 1 {
+		t.Errorf("Expected a with 1 item left, got %s and %d", item, fifo.Size())
+	}
+
+	lifo := NewRedisLIFO[int](client, "stack", 0, blackbox.DefaultItemCodec[int]())
+	lifo.Put(1)
+	lifo.Put(2)
+	if item, _ := lifo.Get(); item != 2 {
+		t.Errorf("Expected 2, got %d", item)
+	}
+	if items := lifo.Items(); len(items) != 1 || items[0] != 1 {
+		t.Errorf("Expected [1], got %v", items)
+	}
+	lifo.Clean()
+	if _, err := lifo.Get(); err != blackbox.ErrEmptyBlackBox || lifo.Err() != nil {
+		t.Errorf("Expected ErrEmptyBlackBox, got %v (%v)", err, lifo.Err())
+	}
+}
+
+func TestRedisRandomKeepsDuplicates(t *testing.T) {
+	box := NewRedisRandom[string](newFakeRedis(), "draw", 0, blackbox.DefaultItemCodec[string]())
+	box.Put("ticket")
+	box.Put("ticket")
+	if box.Size() != 2 {
+		t.Fatalf("Expected 2 items, got %d", box.Size())
+	}
+	for i := 0; i < 2; i++ {
+		if item, err := box.Get(); item != "ticket" || err != nil {
+			t.Errorf("Expected ticket, got %q, %v", item, err)
+		}
+	}
+	if !box.IsEmpty() {
+		t.Error("Box should be empty")
+	}
+}
+
+func TestConformance(t *testing.T) {
+	codec := blackbox.DefaultItemCodec[int]()
+	constructors := map[string]func(Doer, string, int, blackbox.ItemCodec[int]) *redisBox[int]{
+		"FIFO":   NewRedisFIFO[int],
+		"LIFO":   NewRedisLIFO[int],
+		"Random": NewRedisRandom[int],
+	}
+	for name, constructor := range constructors {
+		t.Run(name, func(t *testing.T) {
+			blackboxtest.RunConformance(t, func(maxSize int) blackbox.BlackBox[int] {
+				return constructor(newFakeRedis(), "queue", maxSize, codec)
+			})
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package blackbox
+
+// refillBox calls a refill function when Get finds the box empty.
+type refillBox[T any] struct {
+	box    BlackBox[T]
+	refill func() []T
+}
+
+// NewRefill wraps box so that Get on an empty box first puts the items
+// returned by refill, e.g. to reload a prize pool or fetch the next page of a
+// database table, and only returns ErrEmptyBlackBox when refill returns no
+// items. Items that do not fit into box are dropped. Peek does not refill.
+// Returns a concrete instance of refill blackbox.
+func NewRefill[T any](box BlackBox[T], refill func() []T) *refillBox[T] {
+	return &refillBox[T]{box: box, refill: refill}
+}
+
+// WithRefillFunc refills the box with fn when Get finds it empty, see NewRefill.
+func WithRefillFunc[T any](fn func() []T) Option {
+	return func(c *config) {
+		c.decorators = append(c.decorators, func(box BlackBox[T]) BlackBox[T] {
+			return NewRefill(box, fn)
+		})
+	}
+}
+
+func (r *refillBox[T]) Put(item T) error {
+	return r.box.Put(item)
+}
+
+func (r *refillBox[T]) Get() (T, error) {
+	if r.box.IsEmpty() {
+		for _, item := range r.refill() {
+			if r.box.Put(item) != nil {
+				break
+			}
+		}
+	}
+	return r.box.Get()
+}
+
+func (r *refillBox[T]) Peek() (T, error) {
+	return r.box.Peek()
+}
+
+func (r *refillBox[T]) Size() int {
+	return r.box.Size()
+}
+
+func (r *refillBox[T]) MaxSize() int {
+	return r.box.MaxSize()
+}
+
+func (r *refillBox[T]) IsFull() bool {
+	return r.box.IsFull()
+}
+
+func (r *refillBox[T]) IsEmpty() bool {
+	return r.box.IsEmpty()
+}
+
+func (r *refillBox[T]) Clean() {
+	r.box.Clean()
+}
+
+func (r *refillBox[T]) Items() []T {
+	return r.box.Items()
+}
+
+func (r *refillBox[T]) forEach(fn func(item T) bool) {
+	eachItem(r.box, fn)
+}
+
+func (r *refillBox[T]) mutatesOnRead() bool {
+	return mutatesOnRead(r.box)
+}
+
+func (r *refillBox[T]) mutatesOnPeek() bool {
+	return mutatesOnPeek(r.box)
+}
+
+// Compile-time assertion that refillBox implements BlackBox[T].
+var _ BlackBox[any] = (*refillBox[any])(nil)
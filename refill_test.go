@@ -0,0 +1,39 @@
+package blackbox
+
+import "testing"
+
+func TestRefill(t *testing.T) {
+	pages := [][]int{{1, 2, 3}, {4}}
+	calls := 0
+	box := New[int](WithStrategy(StrategyFIFO), WithMaxSize(2), WithRefillFunc(func() []int {
+		calls++
+		if len(pages) == 0 {
+			return nil
+		}
+		page := pages[0]
+		pages = pages[1:]
+		return page
+	}))
+
+	if _, err := box.Peek(); err != ErrEmptyBlackBox {
+		t.Errorf("Expected Peek not to refill, got %v", err)
+	}
+	var got []int
+	for {
+		item, err := box.Get()
+		if err != nil {
+			if err != ErrEmptyBlackBox {
+				t.Fatalf("Expected ErrEmptyBlackBox, got %v", err)
+			}
+			break
+		}
+		got = append(got, item)
+	}
+	// Item 3 does not fit into the box and is dropped.
+	if !EqualInts(got, []int{1, 2, 4}) {
+		t.Errorf("Expected [1 2 4], got %v", got)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 refills, got %d", calls)
+	}
+}
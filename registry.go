@@ -0,0 +1,93 @@
+package blackbox
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+var ErrUnknownStrategy = errors.New("blackbox strategy is unknown")
+
+var strategyNames = map[Strategy]string{
+	StrategyRandom: "random",
+	StrategyFIFO:   "fifo",
+	StrategyLIFO:   "lifo",
+	StrategyRing:   "ring",
+}
+
+// String returns the name of the strategy as accepted by ParseStrategy.
+func (s Strategy) String() string {
+	if name, ok := strategyNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("Strategy(%d)", int(s))
+}
+
+// ParseStrategy returns the built-in strategy with the given case-insensitive
+// name ("random", "fifo", "lifo" or "ring").
+func ParseStrategy(name string) (Strategy, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for s, n := range strategyNames {
+		if n == name {
+			return s, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %q", ErrUnknownStrategy, name)
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]any{} // name -> func(maxSize, capacity int) BlackBox[T]
+)
+
+// RegisterStrategy makes a custom strategy available to NewByName under the
+// given case-insensitive name. factory receives the maximum size and initial
+// capacity configured by the options, like the concrete constructors.
+//
+// RegisterStrategy panics if factory is nil, if name is a built-in strategy
+// or if it is called twice with the same name, like database/sql.Register.
+func RegisterStrategy[T any](name string, factory func(maxSize, capacity int) BlackBox[T]) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if factory == nil {
+		panic("blackbox: RegisterStrategy factory is nil")
+	}
+	if _, err := ParseStrategy(name); err == nil {
+		panic("blackbox: RegisterStrategy called for built-in strategy " + name)
+	}
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, dup := registry[name]; dup {
+		panic("blackbox: RegisterStrategy called twice for strategy " + name)
+	}
+	registry[name] = factory
+}
+
+// NewByName creates a new BlackBox of the strategy with the given
+// case-insensitive name, e.g. read from a configuration file. Built-in names
+// behave like New with WithStrategy; custom strategies must have been
+// registered with RegisterStrategy for the same item type. Any WithStrategy
+// option is overridden by name.
+//
+// Options stored on the built-in boxes (overflow policy, evict callback, TTL,
+// age bias, RNG) are ignored by custom strategies; decorating options such as
+// WithCategoryLimit are applied to every box.
+func NewByName[T any](name string, opts ...Option) (BlackBox[T], error) {
+	if strategy, err := ParseStrategy(name); err == nil {
+		return New[T](append(opts[:len(opts):len(opts)], WithStrategy(strategy))...), nil
+	}
+
+	key := strings.ToLower(strings.TrimSpace(name))
+	registryMu.RLock()
+	factory, ok := registry[key]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownStrategy, key)
+	}
+	fn, ok := factory.(func(maxSize, capacity int) BlackBox[T])
+	if !ok {
+		return nil, fmt.Errorf("%w: %q is registered for another item type", ErrUnknownStrategy, key)
+	}
+	cfg := parseOptions(opts)
+	return decorate(fn(cfg.maxSize, cfg.initialCapacity), cfg), nil
+}
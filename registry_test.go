@@ -0,0 +1,75 @@
+package blackbox
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseStrategy(t *testing.T) {
+	for _, strategy := range []Strategy{StrategyRandom, StrategyFIFO, StrategyLIFO} {
+		parsed, err := ParseStrategy(strategy.String())
+		if err != nil || parsed != strategy {
+			t.Errorf("Strategy=%v Expected round trip, got %v, %v", strategy, parsed, err)
+		}
+	}
+	if parsed, err := ParseStrategy(" FIFO "); err != nil || parsed != StrategyFIFO {
+		t.Errorf("Expected StrategyFIFO, got %v, %v", parsed, err)
+	}
+	if _, err := ParseStrategy("heap"); !errors.Is(err, ErrUnknownStrategy) {
+		t.Errorf("Expected ErrUnknownStrategy, got %v", err)
+	}
+	if s := Strategy(42).String(); s != "Strategy(42)" {
+		t.Errorf("Expected Strategy(42), got %s", s)
+	}
+}
+
+func TestNewByName(t *testing.T) {
+	box, err := NewByName[int]("lifo", WithMaxSize(2))
+	if err != nil {
+		t.Fatalf("Failed to create box: %v", err)
+	}
+	box.Put(1)
+	box.Put(2)
+	if item, _ := box.Get(); item != 2 {
+		t.Errorf("Expected LIFO order, got %d", item)
+	}
+	if box.MaxSize() != 2 {
+		t.Errorf("Expected max size 2, got %d", box.MaxSize())
+	}
+
+	RegisterStrategy("test-sorted", func(maxSize, capacity int) BlackBox[int] {
+		return NewSorted[int](lessInt, maxSize)
+	})
+	box, err = NewByName[int]("Test-Sorted", WithMaxSize(3))
+	if err != nil {
+		t.Fatalf("Failed to create custom box: %v", err)
+	}
+	box.Put(3)
+	box.Put(1)
+	if item, _ := box.Get(); item != 1 || box.MaxSize() != 3 {
+		t.Errorf("Expected custom sorted box, got %d with max size %d", item, box.MaxSize())
+	}
+
+	if _, err := NewByName[string]("test-sorted"); !errors.Is(err, ErrUnknownStrategy) {
+		t.Errorf("Expected ErrUnknownStrategy for another item type, got %v", err)
+	}
+	if _, err := NewByName[int]("missing"); !errors.Is(err, ErrUnknownStrategy) {
+		t.Errorf("Expected ErrUnknownStrategy, got %v", err)
+	}
+}
+
+func TestRegisterStrategyPanics(t *testing.T) {
+	factory := func(maxSize, capacity int) BlackBox[int] { return NewFIFO[int](maxSize, capacity) }
+	RegisterStrategy("test-dup", factory)
+
+	for _, name := range []string{"fifo", "test-dup"} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Name=%s Expected RegisterStrategy to panic", name)
+				}
+			}()
+			RegisterStrategy(name, factory)
+		}()
+	}
+}
@@ -0,0 +1,118 @@
+package blackbox
+
+// Remover is implemented by blackboxes that can remove items by predicate,
+// e.g. to cancel pending tasks by ID. Removed items are not passed to the
+// evict callback.
+type Remover[T any] interface {
+	// RemoveFunc removes every item for which pred returns true and returns
+	// the number of removed items.
+	RemoveFunc(pred func(item T) bool) int
+	// TakeFunc removes every item for which pred returns true and returns the
+	// removed items, in the order of Items.
+	TakeFunc(pred func(item T) bool) []T
+}
+
+func (b *fifoBox[T]) RemoveFunc(pred func(item T) bool) int {
+	return len(b.removeFunc(pred))
+}
+
+func (b *fifoBox[T]) TakeFunc(pred func(item T) bool) []T {
+	return b.removeFunc(pred)
+}
+
+func (b *dequeBox[T]) RemoveFunc(pred func(item T) bool) int {
+	return len(b.removeFunc(pred))
+}
+
+func (b *dequeBox[T]) TakeFunc(pred func(item T) bool) []T {
+	return b.removeFunc(pred)
+}
+
+func (b *chunkedFIFO[T]) RemoveFunc(pred func(item T) bool) int {
+	return len(b.removeFunc(pred))
+}
+
+func (b *chunkedFIFO[T]) TakeFunc(pred func(item T) bool) []T {
+	return b.removeFunc(pred)
+}
+
+func (b *lifoBox[T]) RemoveFunc(pred func(item T) bool) int {
+	return len(b.removeFunc(pred))
+}
+
+func (b *lifoBox[T]) TakeFunc(pred func(item T) bool) []T {
+	return b.removeFunc(pred)
+}
+
+func (b *randomBox[T]) RemoveFunc(pred func(item T) bool) int {
+	return len(b.removeFunc(pred))
+}
+
+func (b *randomBox[T]) TakeFunc(pred func(item T) bool) []T {
+	return b.removeFunc(pred)
+}
+
+func (b *sortedBox[T]) RemoveFunc(pred func(item T) bool) int {
+	return len(b.removeFunc(pred))
+}
+
+func (b *sortedBox[T]) TakeFunc(pred func(item T) bool) []T {
+	return b.removeFunc(pred)
+}
+
+// RemoveFunc removes every unexpired item for which pred returns true.
+func (t *timedBox[T]) RemoveFunc(pred func(item T) bool) int {
+	return len(t.TakeFunc(pred))
+}
+
+// TakeFunc removes and returns every unexpired item for which pred returns true.
+func (t *timedBox[T]) TakeFunc(pred func(item T) bool) []T {
+	t.purge()
+	r, ok := t.box.(itemRemover[timedItem[T]])
+	if !ok {
+		return nil
+	}
+	removed := r.removeFunc(func(it timedItem[T]) bool {
+		return pred(it.value)
+	})
+	return values(removed)
+}
+
+func (c *concurrentBox[T]) RemoveFunc(pred func(item T) bool) int {
+	return len(c.TakeFunc(pred))
+}
+
+// TakeFunc removes and returns every item for which pred returns true. pred
+// is called with the lock held, so it must not call back into the box.
+// The wrapped box must implement Remover[T], otherwise nothing is removed.
+func (c *concurrentBox[T]) TakeFunc(pred func(item T) bool) []T {
+	r, ok := c.box.(Remover[T])
+	if !ok {
+		return nil
+	}
+	c.mu.Lock()
+	removed := r.TakeFunc(pred)
+	if len(removed) > 0 {
+		c.broadcast()
+	}
+	c.mu.Unlock()
+	return removed
+}
+
+func (c *categoryBox[T, K]) RemoveFunc(pred func(item T) bool) int {
+	return len(c.TakeFunc(pred))
+}
+
+// TakeFunc removes and returns every item for which pred returns true.
+// The wrapped box must implement Remover[T], otherwise nothing is removed.
+func (c *categoryBox[T, K]) TakeFunc(pred func(item T) bool) []T {
+	r, ok := c.box.(Remover[T])
+	if !ok {
+		return nil
+	}
+	removed := r.TakeFunc(pred)
+	for _, item := range removed {
+		c.release(item)
+	}
+	return removed
+}
@@ -0,0 +1,88 @@
+package blackbox
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func isEven(i int) bool { return i%2 == 0 }
+
+func TestRemoveFunc(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5, 6}
+	tests := []struct {
+		name string
+		box  BlackBox[int]
+		kept []int
+	}{
+		{"FIFO", NewFIFOFrom[int](data, 0), []int{1, 3, 5}},
+		{"LIFO", NewLIFOFrom[int](data, 0), []int{5, 3, 1}},
+		{"Chunked", NewChunkedFIFOFrom[int](data, 0, 4), []int{1, 3, 5}},
+		{"Deque", NewDequeFrom[int](data, 0), []int{1, 3, 5}},
+		{"Sorted", NewSortedFrom[int]([]int{6, 5, 4, 3, 2, 1}, lessInt, 0), []int{1, 3, 5}},
+		{"Timed", NewFrom[int](data, WithStrategy(StrategyFIFO), WithTTL(time.Hour)), []int{1, 3, 5}},
+		{"Concurrent", NewConcurrent[int](NewFIFOFrom[int](data, 0)), []int{1, 3, 5}},
+	}
+	for _, tt := range tests {
+		r, ok := tt.box.(Remover[int])
+		if !ok {
+			t.Fatalf("%s: Expected box to implement Remover", tt.name)
+		}
+		if n := r.RemoveFunc(isEven); n != 3 {
+			t.Errorf("%s: Expected 3 removed items, got %d", tt.name, n)
+		}
+		if items := Drain(tt.box); !EqualInts(items, tt.kept) {
+			t.Errorf("%s: Expected %v, got %v", tt.name, tt.kept, items)
+		}
+	}
+}
+
+func TestTakeFunc(t *testing.T) {
+	var evicted int
+	box := NewFrom[int]([]int{1, 2, 3, 4}, WithSeed(1), WithEvictCallback(func(int) { evicted++ }))
+	taken := box.(Remover[int]).TakeFunc(isEven)
+	if len(taken) != 2 || !ContainsInt(taken, 2) || !ContainsInt(taken, 4) {
+		t.Errorf("Expected [2 4], got %v", taken)
+	}
+	if box.Size() != 2 || evicted != 0 {
+		t.Errorf("Expected 2 items left and no evictions, got %d and %d", box.Size(), evicted)
+	}
+
+	category := NewCategoryLimit[int, bool](NewFIFO[int](0, 4), isEven, 2)
+	for _, item := range []int{1, 2, 3, 4} {
+		category.Put(item)
+	}
+	if taken := category.TakeFunc(isEven); !EqualInts(taken, []int{2, 4}) {
+		t.Errorf("Expected [2 4], got %v", taken)
+	}
+	if category.CategorySize(true) != 0 || category.CategorySize(false) != 2 {
+		t.Errorf("Expected category sizes to follow removals, got %d and %d",
+			category.CategorySize(true), category.CategorySize(false))
+	}
+}
+
+func TestSortedRemoveFuncKeepsStructure(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	box := NewSorted[int](lessInt, 0)
+	var want []int
+	for i := 0; i < 1000; i++ {
+		item := rng.Intn(100)
+		box.Put(item)
+		if item%3 != 0 {
+			want = append(want, item)
+		}
+	}
+	box.RemoveFunc(func(i int) bool { return i%3 == 0 })
+	for i := 0; i < 50; i++ {
+		box.Put(1)
+		want = append(want, 1)
+	}
+
+	sorted := NewSortedFrom[int](want, lessInt, 0).Items()
+	if box.Size() != len(sorted) {
+		t.Fatalf("Expected size %d, got %d", len(sorted), box.Size())
+	}
+	if items := Drain[int](box); !EqualInts(items, sorted) {
+		t.Errorf("Expected items in ascending order after RemoveFunc")
+	}
+}
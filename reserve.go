@@ -0,0 +1,96 @@
+package blackbox
+
+// Reserver is implemented by blackboxes backed by growable storage, so that
+// latency-sensitive code can grow it ahead of a burst instead of guessing
+// WithInitialCapacity up front.
+type Reserver interface {
+	// Cap returns the number of items the backing storage holds before it
+	// has to grow.
+	Cap() int
+	// Reserve grows the backing storage so that n more items can be put
+	// without allocating, limited to the maximum size of the box.
+	Reserve(n int)
+}
+
+// reserveCap returns the capacity needed for n more items on top of size,
+// limited to maxSize (0 = unlimited).
+func reserveCap(size, n, maxSize int) int {
+	want := size + n
+	if maxSize > 0 && want > maxSize {
+		want = maxSize
+	}
+	return want
+}
+
+func (b *ring[T]) Cap() int {
+	return len(b.items)
+}
+
+func (b *ring[T]) Reserve(n int) {
+	if want := reserveCap(b.size, n, b.maxSize); want > len(b.items) {
+		b.resize(want)
+	}
+}
+
+func (b *lifoBox[T]) Cap() int {
+	return cap(b.items)
+}
+
+func (b *lifoBox[T]) Reserve(n int) {
+	b.items = reserveSlice(b.items, reserveCap(len(b.items), n, b.maxSize))
+}
+
+func (b *randomBox[T]) Cap() int {
+	return cap(b.items)
+}
+
+func (b *randomBox[T]) Reserve(n int) {
+	b.items = reserveSlice(b.items, reserveCap(len(b.items), n, b.maxSize))
+}
+
+// reserveSlice returns items with a capacity of at least want.
+func reserveSlice[T any](items []T, want int) []T {
+	if want <= cap(items) {
+		return items
+	}
+	newItems := make([]T, len(items), want)
+	copy(newItems, items)
+	return newItems
+}
+
+// Cap returns the capacity of the wrapped box, or 0 if it does not implement Reserver.
+func (t *timedBox[T]) Cap() int {
+	if r, ok := t.box.(Reserver); ok {
+		return r.Cap()
+	}
+	return 0
+}
+
+// Reserve grows the wrapped box if it implements Reserver.
+func (t *timedBox[T]) Reserve(n int) {
+	if r, ok := t.box.(Reserver); ok {
+		r.Reserve(n)
+	}
+}
+
+// Cap returns the capacity of the wrapped box, or 0 if it does not implement Reserver.
+func (c *concurrentBox[T]) Cap() int {
+	r, ok := c.box.(Reserver)
+	if !ok {
+		return 0
+	}
+	c.rlock()
+	defer c.runlock()
+	return r.Cap()
+}
+
+// Reserve grows the wrapped box under the lock if it implements Reserver.
+func (c *concurrentBox[T]) Reserve(n int) {
+	r, ok := c.box.(Reserver)
+	if !ok {
+		return
+	}
+	c.mu.Lock()
+	r.Reserve(n)
+	c.mu.Unlock()
+}
@@ -0,0 +1,59 @@
+package blackbox
+
+import "testing"
+
+func TestReserve(t *testing.T) {
+	boxes := map[string]BlackBox[int]{
+		"fifo":       New[int](WithStrategy(StrategyFIFO), WithInitialCapacity(2)),
+		"lifo":       New[int](WithStrategy(StrategyLIFO), WithInitialCapacity(2)),
+		"random":     New[int](WithStrategy(StrategyRandom), WithInitialCapacity(2)),
+		"deque":      NewDeque[int](0, 2),
+		"timed":      New[int](WithStrategy(StrategyFIFO), WithInitialCapacity(2), WithTTL(0)),
+		"concurrent": NewConcurrent[int](NewFIFO[int](0, 2)),
+	}
+	for name, box := range boxes {
+		r, ok := box.(Reserver)
+		if !ok {
+			t.Errorf("%s: Expected Reserver, got %T", name, box)
+			continue
+		}
+		box.Put(1)
+		box.Put(2)
+		if r.Cap() != 2 {
+			t.Errorf("%s: Expected capacity 2, got %d", name, r.Cap())
+		}
+		r.Reserve(100)
+		if r.Cap() < 102 {
+			t.Errorf("%s: Expected capacity of at least 102, got %d", name, r.Cap())
+		}
+		if box.Size() != 2 || !ContainsInt(box.Items(), 1) || !ContainsInt(box.Items(), 2) {
+			t.Errorf("%s: Expected Reserve to keep the items, got %v", name, box.Items())
+		}
+	}
+}
+
+func TestReserveRing(t *testing.T) {
+	box := NewFIFO[int](10, 4)
+	for i := 1; i <= 4; i++ {
+		box.Put(i)
+	}
+	box.Get()
+	box.Put(5) // wraps around
+
+	box.Reserve(100)
+	if box.Cap() != 10 {
+		t.Errorf("Expected capacity limited to MaxSize 10, got %d", box.Cap())
+	}
+	box.Put(6)
+	if !EqualInts(box.Items(), []int{2, 3, 4, 5, 6}) {
+		t.Errorf("Expected [2 3 4 5 6], got %v", box.Items())
+	}
+
+	full := NewFIFO[int](0, 2)
+	full.Put(1)
+	full.Put(2)
+	full.Reserve(0)
+	if full.Cap() != 2 {
+		t.Errorf("Expected Reserve(0) to keep the capacity, got %d", full.Cap())
+	}
+}
@@ -0,0 +1,38 @@
+package blackbox
+
+import (
+	"context"
+	"time"
+)
+
+// WithRetention drops items once they are older than d, turning the box into
+// a rolling buffer such as "the last 5 minutes of events". Unlike WithTTL, d
+// also bounds the items put with a longer TTL or none with PutWithTTL. Old
+// items are dropped lazily on access like expired ones, and reported to the
+// evict callback; use StartSweeper to drop them in the background.
+func WithRetention(d time.Duration) Option {
+	return func(c *config) {
+		c.retention = d
+		c.useTTL = true
+	}
+}
+
+// StartSweeper drops the expired items of box every interval until ctx is
+// done, so that the evict callback of a WithTTL or WithRetention box is called
+// soon after items expire instead of on the next access. box is accessed from
+// another goroutine and must be goroutine-safe, e.g. from NewConcurrent.
+func StartSweeper[T any](ctx context.Context, box BlackBox[T], interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				// Size purges the expired items of a timed box.
+				box.Size()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
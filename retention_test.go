@@ -0,0 +1,70 @@
+package blackbox
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRetention(t *testing.T) {
+	var evicted []int
+	box := New[int](WithStrategy(StrategyFIFO), WithRetention(5*time.Minute),
+		WithEvictCallback(func(item int) { evicted = append(evicted, item) }))
+	tb := box.(*timedBox[int])
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	tb.clock = clock
+
+	for i := 1; i <= 3; i++ {
+		box.Put(i)
+		clock.Advance(2 * time.Minute)
+	}
+	// Retention also bounds items put without a TTL.
+	tb.PutWithTTL(4, 0)
+	if !EqualInts(box.Items(), []int{2, 3, 4}) {
+		t.Errorf("Expected items of the last 5 minutes, got %v", box.Items())
+	}
+	if !EqualInts(evicted, []int{1}) {
+		t.Errorf("Expected 1 to be evicted, got %v", evicted)
+	}
+	clock.Advance(5 * time.Minute)
+	if !box.IsEmpty() {
+		t.Errorf("Expected all items to be dropped, got %v", box.Items())
+	}
+}
+
+func TestRetentionWithTTL(t *testing.T) {
+	box := New[int](WithStrategy(StrategyFIFO), WithTTL(time.Minute), WithRetention(time.Hour))
+	tb := box.(*timedBox[int])
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	tb.clock = clock
+
+	box.Put(1)
+	tb.PutWithTTL(2, 2*time.Hour)
+	clock.Advance(time.Minute)
+	if !EqualInts(box.Items(), []int{2}) {
+		t.Errorf("Expected the shorter TTL to apply, got %v", box.Items())
+	}
+	clock.Advance(time.Hour)
+	if !box.IsEmpty() {
+		t.Errorf("Expected retention to bound the longer TTL, got %v", box.Items())
+	}
+}
+
+func TestStartSweeper(t *testing.T) {
+	evicted := make(chan int, 1)
+	box := NewConcurrent(New[int](WithRetention(time.Millisecond),
+		WithEvictCallback(func(item int) { evicted <- item })))
+	box.Put(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	StartSweeper[int](ctx, box, time.Millisecond)
+	select {
+	case item := <-evicted:
+		if item != 1 {
+			t.Errorf("Expected 1 to be evicted, got %d", item)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the sweeper to evict the item")
+	}
+}
@@ -0,0 +1,274 @@
+package blackbox
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// ErrRetriesExhausted is returned by Requeue when the attempt exceeds RetryConfig.MaxAttempts.
+var ErrRetriesExhausted = errors.New("blackbox retries exhausted")
+
+// Backoff computes exponentially growing delays with optional jitter.
+type Backoff struct {
+	// Initial is the delay before the first retry.
+	Initial time.Duration
+	// Max caps the delay. 0 means no cap.
+	Max time.Duration
+	// Multiplier is the growth factor per attempt. Values below 1 default to 2.
+	Multiplier float64
+	// Jitter is the fraction of the delay, between 0 and 1, that is randomized
+	// so that retries of many items don't fire at once. 0.5 gives delays
+	// between 50% and 100% of the computed value.
+	Jitter float64
+}
+
+// Delay returns the delay before the given attempt, starting at 1 for the
+// first retry. Jitter is drawn from rng; a nil rng disables jitter.
+func (b Backoff) Delay(attempt int, rng *rand.Rand) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	mult := b.Multiplier
+	if mult < 1 {
+		mult = 2
+	}
+	d := float64(b.Initial) * math.Pow(mult, float64(attempt-1))
+	if b.Max > 0 && d > float64(b.Max) {
+		d = float64(b.Max)
+	}
+	if d > math.MaxInt64 {
+		d = math.MaxInt64
+	}
+	if b.Jitter > 0 && rng != nil {
+		d -= d * math.Min(b.Jitter, 1) * rng.Float64()
+	}
+	return time.Duration(d)
+}
+
+// RetryConfig configures the retry queue returned by NewRetry.
+type RetryConfig[T any, K comparable] struct {
+	// Key identifies an item across attempts.
+	Key func(T) K
+	// Backoff computes the delay before each attempt.
+	Backoff Backoff
+	// MaxAttempts is the number of retries allowed per item. 0 means no limit.
+	MaxAttempts int
+	// Clock is used to schedule retries. When nil, the wall clock is used.
+	Clock Clock
+	// Rand draws the jitter. When nil, a time-seeded RNG is used.
+	Rand *rand.Rand
+}
+
+type scheduledItem[T any] struct {
+	item T
+	due  time.Time
+}
+
+// retryBox schedules failed items to become available again after a backoff.
+type retryBox[T any, K comparable] struct {
+	// mu guards the schedule, the attempts and the RNG, and serializes the
+	// calls on box.
+	mu        sync.Mutex
+	box       BlackBox[T]
+	cfg       RetryConfig[T, K]
+	scheduled []scheduledItem[T]
+	attempts  map[K]int
+	// waker wakes up a concurrent wrapper once the next retry is due.
+	waker wakeTimer
+}
+
+// NewRetry wraps box with a retry subsystem. Requeue schedules an item to be
+// put back into box after the backoff for its attempt and records the attempt
+// for the item's key, so consumers can look it up with Attempts.
+//
+// Scheduled items are released lazily: every call on the returned box first
+// puts the items whose backoff has elapsed into box, in the order they are
+// due. If box is full, the item stays scheduled and is retried on the next
+// call. Size, IsEmpty and Items only reflect items available in box; use
+// Scheduled to see how many items are waiting. Wrapped in NewConcurrent,
+// the items are also released when they are due, so GetCtx and Process see
+// them without another call on the box.
+//
+// The returned box is safe for concurrent use, so several workers can share
+// it; box must then only be accessed through it.
+// Returns a concrete instance of retry blackbox.
+func NewRetry[T any, K comparable](box BlackBox[T], cfg RetryConfig[T, K]) *retryBox[T, K] {
+	if cfg.Clock == nil {
+		cfg.Clock = systemClock{}
+	}
+	if cfg.Rand == nil {
+		cfg.Rand, _ = config{}.rng()
+	}
+	return &retryBox[T, K]{
+		box:      box,
+		cfg:      cfg,
+		attempts: make(map[K]int),
+	}
+}
+
+// release puts the items whose backoff has elapsed into the wrapped box.
+func (r *retryBox[T, K]) release() {
+	if len(r.scheduled) == 0 {
+		return
+	}
+	now := r.cfg.Clock.Now()
+	kept := r.scheduled[:0]
+	for _, s := range r.scheduled {
+		if now.Before(s.due) || r.box.Put(s.item) != nil {
+			kept = append(kept, s)
+		}
+	}
+	var zero scheduledItem[T]
+	for i := len(kept); i < len(r.scheduled); i++ {
+		r.scheduled[i] = zero
+	}
+	r.scheduled = kept
+	r.arm(now)
+}
+
+// arm sets the waker for the next retry that is not due yet.
+func (r *retryBox[T, K]) arm(now time.Time) {
+	for _, s := range r.scheduled {
+		if now.Before(s.due) {
+			r.waker.arm(s.due, now)
+			return
+		}
+	}
+}
+
+func (r *retryBox[T, K]) setWake(wake func()) {
+	r.waker.set(wake)
+	r.mu.Lock()
+	r.arm(r.cfg.Clock.Now())
+	r.mu.Unlock()
+}
+
+func (r *retryBox[T, K]) held() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.scheduled)
+}
+
+// Requeue schedules item to become available again after the backoff delay of
+// attempt, starting at 1 for the first retry, and records attempt for the item.
+// It returns ErrRetriesExhausted without scheduling the item once attempt
+// exceeds MaxAttempts.
+func (r *retryBox[T, K]) Requeue(item T, attempt int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.release()
+	if r.cfg.MaxAttempts > 0 && attempt > r.cfg.MaxAttempts {
+		return ErrRetriesExhausted
+	}
+	r.attempts[r.cfg.Key(item)] = attempt
+	now := r.cfg.Clock.Now()
+	due := now.Add(r.cfg.Backoff.Delay(attempt, r.cfg.Rand))
+	// Keep the schedule sorted by due time, after items due at the same time.
+	i := len(r.scheduled)
+	for i > 0 && r.scheduled[i-1].due.After(due) {
+		i--
+	}
+	r.scheduled = append(r.scheduled, scheduledItem[T]{})
+	copy(r.scheduled[i+1:], r.scheduled[i:])
+	r.scheduled[i] = scheduledItem[T]{item: item, due: due}
+	r.waker.arm(due, now)
+	return nil
+}
+
+// Attempts returns the last attempt recorded by Requeue for the item's key, 0 if none.
+func (r *retryBox[T, K]) Attempts(item T) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.attempts[r.cfg.Key(item)]
+}
+
+// Forget clears the attempt count of the item's key, e.g. once it was
+// processed successfully or given up.
+func (r *retryBox[T, K]) Forget(item T) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.attempts, r.cfg.Key(item))
+}
+
+// Scheduled returns the number of items waiting for their backoff to elapse.
+func (r *retryBox[T, K]) Scheduled() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.release()
+	return len(r.scheduled)
+}
+
+func (r *retryBox[T, K]) Put(item T) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.release()
+	return r.box.Put(item)
+}
+
+func (r *retryBox[T, K]) Get() (T, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.release()
+	return r.box.Get()
+}
+
+func (r *retryBox[T, K]) Peek() (T, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.release()
+	return r.box.Peek()
+}
+
+func (r *retryBox[T, K]) Size() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.release()
+	return r.box.Size()
+}
+
+func (r *retryBox[T, K]) MaxSize() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.box.MaxSize()
+}
+
+func (r *retryBox[T, K]) IsFull() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.release()
+	return r.box.IsFull()
+}
+
+func (r *retryBox[T, K]) IsEmpty() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.release()
+	return r.box.IsEmpty()
+}
+
+// Clean removes all items, including scheduled ones, and clears all attempt counts.
+func (r *retryBox[T, K]) Clean() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.scheduled = nil
+	r.attempts = make(map[K]int)
+	r.waker.stop()
+	r.box.Clean()
+}
+
+func (r *retryBox[T, K]) Items() []T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.release()
+	return r.box.Items()
+}
+
+func (r *retryBox[T, K]) mutatesOnRead() bool {
+	return true
+}
+
+// Compile-time assertion that retryBox implements BlackBox[T].
+var _ BlackBox[any] = (*retryBox[any, string])(nil)
@@ -0,0 +1,149 @@
+package blackbox
+
+import (
+	"context"
+	"math/rand"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBackoffDelay(t *testing.T) {
+	b := Backoff{Initial: time.Second, Max: 10 * time.Second}
+	want := []time.Duration{time.Second, time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second}
+	for attempt, d := range want {
+		if got := b.Delay(attempt, nil); got != d {
+			t.Errorf("Attempt=%d Expected delay %v, got %v", attempt, d, got)
+		}
+	}
+
+	b.Jitter = 0.5
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		if d := b.Delay(3, rng); d < 2*time.Second || d > 4*time.Second {
+			t.Fatalf("Expected jittered delay within [2s, 4s], got %v", d)
+		}
+	}
+}
+
+func TestRetryRequeue(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	box := NewRetry[int, int](NewFIFO[int](0, 4), RetryConfig[int, int]{
+		Key:         func(i int) int { return i },
+		Backoff:     Backoff{Initial: time.Second},
+		MaxAttempts: 2,
+		Clock:       clock,
+	})
+
+	box.Put(1)
+	box.Put(2)
+	one, _ := box.Get()
+	two, _ := box.Get()
+	box.Requeue(one, 2)
+	box.Requeue(two, 1)
+
+	if box.Scheduled() != 2 || !box.IsEmpty() {
+		t.Fatalf("Expected 2 scheduled items and an empty box, got %d and size %d", box.Scheduled(), box.Size())
+	}
+	clock.Advance(time.Second)
+	if item, err := box.Get(); err != nil || item != 2 {
+		t.Errorf("Expected item 2 after its first backoff, got %d (%v)", item, err)
+	}
+	if _, err := box.Get(); err != ErrEmptyBlackBox {
+		t.Errorf("Expected item 1 to still be waiting, got %v", err)
+	}
+	clock.Advance(time.Second)
+	item, _ := box.Get()
+	if item != 1 || box.Attempts(item) != 2 {
+		t.Errorf("Expected item 1 on attempt 2, got %d on attempt %d", item, box.Attempts(item))
+	}
+
+	if err := box.Requeue(item, 3); err != ErrRetriesExhausted {
+		t.Errorf("Expected ErrRetriesExhausted, got %v", err)
+	}
+	box.Forget(item)
+	if box.Attempts(item) != 0 {
+		t.Errorf("Expected attempts to be cleared, got %d", box.Attempts(item))
+	}
+}
+
+func TestRetryConcurrentWorkers(t *testing.T) {
+	box := NewRetry[int, int](NewFIFO[int](0, 8), RetryConfig[int, int]{
+		Key:         func(i int) int { return i },
+		Backoff:     Backoff{Initial: time.Microsecond, Jitter: 0.5},
+		MaxAttempts: 3,
+	})
+	const items = 500
+	for i := 0; i < items; i++ {
+		box.Put(i)
+	}
+	var done int64
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for atomic.LoadInt64(&done) < items {
+				item, err := box.Get()
+				if err != nil {
+					runtime.Gosched()
+					continue
+				}
+				// Every item fails twice before it is processed.
+				if attempt := box.Attempts(item); attempt < 2 {
+					if err := box.Requeue(item, attempt+1); err != nil {
+						t.Errorf("Failed to requeue item %d: %v", item, err)
+					}
+					continue
+				}
+				box.Forget(item)
+				atomic.AddInt64(&done, 1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if box.Scheduled() != 0 || !box.IsEmpty() {
+		t.Errorf("Expected nothing left, got %d scheduled and %d available", box.Scheduled(), box.Size())
+	}
+}
+
+func TestRetryWakesBlockedGet(t *testing.T) {
+	retry := NewRetry[int, int](NewFIFO[int](0, 4), RetryConfig[int, int]{
+		Key:     func(i int) int { return i },
+		Backoff: Backoff{Initial: 10 * time.Millisecond},
+	})
+	box := NewConcurrent[int](retry)
+	retry.Requeue(1, 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	start := time.Now()
+	item, err := box.GetCtx(ctx)
+	if err != nil || item != 1 {
+		t.Fatalf("Expected the retried item, got %d (%v)", item, err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected GetCtx to wake up when the backoff expired, took %v", elapsed)
+	}
+
+	// Process waits for the scheduled retries too.
+	retry.Forget(1)
+	box.Put(1)
+	var mu sync.Mutex
+	var processed []int
+	err = box.Process(ctx, 2, func(ctx context.Context, item int) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if retry.Attempts(item) == 0 {
+			return retry.Requeue(item, 1)
+		}
+		processed = append(processed, item)
+		return nil
+	})
+	if err != nil || !EqualInts(processed, []int{1}) {
+		t.Errorf("Expected the retried item to be processed, got %v (%v)", processed, err)
+	}
+}
@@ -0,0 +1,193 @@
+package blackbox
+
+// ring is a growable ring buffer shared by the FIFO and deque boxes.
+// It grows by growthFactor up to maxSize (0 = unlimited) and never shrinks.
+type ring[T any] struct {
+	items   []T
+	head    int
+	tail    int
+	size    int
+	maxSize int
+}
+
+func newRing[T any](maxSize, capacity int) ring[T] {
+	return ring[T]{
+		items:   make([]T, capacity),
+		head:    0,
+		tail:    0,
+		size:    0,
+		maxSize: maxSize,
+	}
+}
+
+// newRingFrom creates a full ring buffer that owns items.
+func newRingFrom[T any](items []T, maxSize int) ring[T] {
+	if maxSize > 0 && maxSize < len(items) {
+		maxSize = len(items)
+	}
+	return ring[T]{
+		items:   items,
+		head:    0,
+		tail:    0, // the buffer is full, so the tail wraps to the start
+		size:    len(items),
+		maxSize: maxSize,
+	}
+}
+
+func (b *ring[T]) grow() {
+	// Initialize newCapacity
+	var newCapacity int
+	if len(b.items) == 0 {
+		newCapacity = defaultInitialCapacity
+	} else {
+		newCapacity = len(b.items) * growthFactor
+	}
+
+	if b.maxSize > 0 && newCapacity > b.maxSize {
+		newCapacity = b.maxSize
+	}
+
+	b.resize(newCapacity)
+}
+
+// resize moves the items into a new buffer of the given capacity, which must
+// hold at least size items.
+func (b *ring[T]) resize(capacity int) {
+	newItems := make([]T, capacity)
+
+	if b.size > 0 {
+		if b.head < b.tail {
+			copy(newItems, b.items[b.head:b.tail])
+		} else {
+			n := copy(newItems, b.items[b.head:])
+			copy(newItems[n:], b.items[:b.tail])
+		}
+	}
+
+	b.head = 0
+	b.tail = b.size
+	b.items = newItems
+}
+
+// pushBack appends item after the last item. The caller checks capacity.
+func (b *ring[T]) pushBack(item T) {
+	if b.size >= len(b.items) {
+		b.grow()
+	}
+	b.items[b.tail] = item
+	b.tail = (b.tail + 1) % len(b.items)
+	b.size++
+}
+
+// pushFront inserts item before the first item. The caller checks capacity.
+func (b *ring[T]) pushFront(item T) {
+	if b.size >= len(b.items) {
+		b.grow()
+	}
+	b.head = (b.head - 1 + len(b.items)) % len(b.items)
+	b.items[b.head] = item
+	b.size++
+}
+
+// popFront removes and returns the first item. The ring must not be empty.
+func (b *ring[T]) popFront() T {
+	item := b.items[b.head]
+	var zero T
+	b.items[b.head] = zero
+	b.head = (b.head + 1) % len(b.items)
+	b.size--
+	return item
+}
+
+// popBack removes and returns the last item. The ring must not be empty.
+func (b *ring[T]) popBack() T {
+	b.tail = (b.tail - 1 + len(b.items)) % len(b.items)
+	item := b.items[b.tail]
+	var zero T
+	b.items[b.tail] = zero
+	b.size--
+	return item
+}
+
+// front returns the first item. The ring must not be empty.
+func (b *ring[T]) front() T {
+	return b.items[b.head]
+}
+
+// back returns the last item. The ring must not be empty.
+func (b *ring[T]) back() T {
+	return b.items[(b.tail-1+len(b.items))%len(b.items)]
+}
+
+// removeFunc removes every item for which pred returns true, keeping the
+// order of the remaining items, and returns the removed items.
+func (b *ring[T]) removeFunc(pred func(item T) bool) []T {
+	var removed []T
+	if b.size == 0 {
+		return removed
+	}
+	n := len(b.items)
+	w := b.head
+	kept := 0
+	for i := 0; i < b.size; i++ {
+		idx := (b.head + i) % n
+		item := b.items[idx]
+		if pred(item) {
+			removed = append(removed, item)
+			continue
+		}
+		b.items[w] = item
+		w = (w + 1) % n
+		kept++
+	}
+	var zero T
+	for i := kept; i < b.size; i++ {
+		b.items[(b.head+i)%n] = zero
+	}
+	b.tail = w
+	b.size = kept
+	return removed
+}
+
+func (b *ring[T]) Size() int {
+	return b.size
+}
+
+func (b *ring[T]) MaxSize() int {
+	return b.maxSize
+}
+
+func (b *ring[T]) IsFull() bool {
+	return b.maxSize > 0 && b.size >= b.maxSize
+}
+
+func (b *ring[T]) IsEmpty() bool {
+	return b.size == 0
+}
+
+func (b *ring[T]) Clean() {
+	var zero T
+	for i := 0; i < b.size; i++ {
+		idx := (b.head + i) % len(b.items)
+		b.items[idx] = zero
+	}
+	b.head = 0
+	b.tail = 0
+	b.size = 0
+}
+
+// Items returns a copy of all items from front to back.
+func (b *ring[T]) Items() []T {
+	if b.size == 0 {
+		return make([]T, 0)
+	}
+
+	items := make([]T, b.size)
+	if b.head < b.tail {
+		copy(items, b.items[b.head:b.tail])
+	} else {
+		n := copy(items, b.items[b.head:])
+		copy(items[n:], b.items[:b.tail])
+	}
+	return items
+}
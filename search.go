@@ -0,0 +1,101 @@
+package blackbox
+
+// iterator is implemented by the boxes of this package to visit their items in
+// retrieval order without copying them. Iteration stops when fn returns false.
+type iterator[T any] interface {
+	forEach(fn func(item T) bool)
+}
+
+// eachItem visits the items of box in retrieval order when box is an
+// iterator, and in the order of Items otherwise.
+func eachItem[T any](box BlackBox[T], fn func(item T) bool) {
+	if it, ok := box.(iterator[T]); ok {
+		it.forEach(fn)
+		return
+	}
+	for _, item := range box.Items() {
+		if !fn(item) {
+			return
+		}
+	}
+}
+
+// IndexOf returns the position of the first item equal to item according to
+// eq, counted in retrieval order (0 is the item the next Get returns), or -1
+// if there is none. For the Random strategy and boxes of other packages the
+// position follows Items.
+func IndexOf[T any](box BlackBox[T], item T, eq func(a, b T) bool) int {
+	idx, i := -1, 0
+	eachItem(box, func(it T) bool {
+		if eq(it, item) {
+			idx = i
+			return false
+		}
+		i++
+		return true
+	})
+	return idx
+}
+
+// Position returns the place of the first item equal to item according to eq
+// in the line of a queue, counted from 1 for the item the next Get returns, or
+// 0 if there is none. See IndexOf for the order of Random boxes.
+func Position[T any](box BlackBox[T], item T, eq func(a, b T) bool) int {
+	return IndexOf(box, item, eq) + 1
+}
+
+// Contains reports whether the box holds an item equal to item according to
+// eq, e.g. to avoid registering the same lucky-draw participant twice.
+func Contains[T any](box BlackBox[T], item T, eq func(a, b T) bool) bool {
+	return IndexOf(box, item, eq) >= 0
+}
+
+func (b *ring[T]) forEach(fn func(item T) bool) {
+	for i := 0; i < b.size; i++ {
+		if !fn(b.items[(b.head+i)%len(b.items)]) {
+			return
+		}
+	}
+}
+
+func (b *lifoBox[T]) forEach(fn func(item T) bool) {
+	for i := len(b.items) - 1; i >= 0; i-- {
+		if !fn(b.items[i]) {
+			return
+		}
+	}
+}
+
+func (b *randomBox[T]) forEach(fn func(item T) bool) {
+	for _, item := range b.items {
+		if !fn(item) {
+			return
+		}
+	}
+}
+
+func (b *sortedBox[T]) forEach(fn func(item T) bool) {
+	for x := b.head.next[0]; x != nil; x = x.next[0] {
+		if !fn(x.item) {
+			return
+		}
+	}
+}
+
+func (t *timedBox[T]) forEach(fn func(item T) bool) {
+	t.purge()
+	eachItem(t.box, func(it timedItem[T]) bool {
+		return fn(it.value)
+	})
+}
+
+// forEach visits the items with the lock held.
+func (c *concurrentBox[T]) forEach(fn func(item T) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	eachItem(c.box, fn)
+}
+
+func (c *categoryBox[T, K]) forEach(fn func(item T) bool) {
+	eachItem(c.box, fn)
+}
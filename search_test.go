@@ -0,0 +1,47 @@
+package blackbox
+
+import "testing"
+
+func eqInt(a, b int) bool { return a == b }
+
+func TestIndexOf(t *testing.T) {
+	data := []int{1, 2, 3, 2}
+	tests := []struct {
+		name string
+		box  BlackBox[int]
+		want int
+	}{
+		{"FIFO", NewFIFOFrom[int](data, 0), 1},
+		{"LIFO", NewLIFOFrom[int](data, 0), 0},
+		{"Sorted", NewSortedFrom[int](data, lessInt, 0), 1},
+		{"Random", NewRandomFrom[int](data, 0, nil), 1},
+		{"ConcurrentLIFO", NewConcurrent[int](NewLIFOFrom[int](data, 0)), 0},
+		{"CategoryLIFO", NewCategoryLimit[int, int](NewLIFOFrom[int](data, 0), func(i int) int { return i }, 0), 0},
+		{"Chaos", NewChaos[int](NewLIFOFrom[int](data, 0), ChaosConfig{}), 1},
+	}
+	for _, tt := range tests {
+		if idx := IndexOf(tt.box, 2, eqInt); idx != tt.want {
+			t.Errorf("%s: Expected index %d, got %d", tt.name, tt.want, idx)
+		}
+		if idx := IndexOf(tt.box, 9, eqInt); idx != -1 {
+			t.Errorf("%s: Expected index -1, got %d", tt.name, idx)
+		}
+		if !Contains(tt.box, 3, eqInt) || Contains(tt.box, 9, eqInt) {
+			t.Errorf("%s: Unexpected Contains result", tt.name)
+		}
+	}
+}
+
+func TestContainsCustomEquality(t *testing.T) {
+	type participant struct {
+		email string
+		name  string
+	}
+	sameEmail := func(a, b participant) bool { return a.email == b.email }
+
+	box := New[participant]()
+	box.Put(participant{email: "alice@example.com", name: "Alice"})
+	if !Contains(box, participant{email: "alice@example.com", name: "A. Johnson"}, sameEmail) {
+		t.Error("Expected participant to be registered already")
+	}
+}
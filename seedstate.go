@@ -0,0 +1,106 @@
+package blackbox
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/rand"
+)
+
+var (
+	ErrSeedStateUnavailable = errors.New("blackbox RNG state is not reproducible")
+	ErrInvalidSeedState     = errors.New("blackbox RNG state is invalid")
+)
+
+const (
+	// seedStateVersion is the first byte of the state returned by SeedState.
+	seedStateVersion = 1
+	// maxSeedStateDraws bounds the draws replayed by RestoreSeedState, which
+	// take a few seconds at most, so that a forged state can't stall it.
+	maxSeedStateDraws = 1 << 30
+)
+
+// SeedStater is implemented by blackboxes whose RNG state can be
+// checkpointed, e.g. to resume a long-running draw after a restart.
+type SeedStater interface {
+	// SeedState returns the state of the RNG.
+	SeedState() ([]byte, error)
+	// RestoreSeedState resets the RNG to a state returned by SeedState.
+	RestoreSeedState(state []byte) error
+}
+
+// state encodes the seed and the number of draws of the source.
+func (s *seededSource) state() []byte {
+	state := make([]byte, 17)
+	state[0] = seedStateVersion
+	binary.BigEndian.PutUint64(state[1:], uint64(s.seed))
+	binary.BigEndian.PutUint64(state[9:], s.draws)
+	return state
+}
+
+// restoreSource returns a source in the encoded state by replaying the draws
+// from the seed.
+func restoreSource(state []byte) (*seededSource, error) {
+	if len(state) != 17 {
+		return nil, fmt.Errorf("%w: %d bytes", ErrInvalidSeedState, len(state))
+	}
+	if state[0] != seedStateVersion {
+		return nil, fmt.Errorf("%w: version %d", ErrInvalidSeedState, state[0])
+	}
+	src := newSeededSource(int64(binary.BigEndian.Uint64(state[1:])))
+	draws := binary.BigEndian.Uint64(state[9:])
+	if draws > maxSeedStateDraws {
+		return nil, fmt.Errorf("%w: %d draws, at most %d can be replayed", ErrInvalidSeedState, draws, uint64(maxSeedStateDraws))
+	}
+	for src.draws < draws {
+		src.Int63()
+	}
+	return src, nil
+}
+
+// SeedState returns the state of the RNG: its seed and the number of values
+// drawn from it. Restoring it with RestoreSeedState makes the box draw exactly
+// what it would have drawn next, given the same items. It returns
+// ErrSeedStateUnavailable unless the box was created by New, NewFrom or
+// NewFromBlackBox with a seeded or default RNG, or restored from a state, and
+// also once more than 2^30 values were drawn, as restoring would take too long.
+func (b *randomBox[T]) SeedState() ([]byte, error) {
+	if b.src == nil || b.src.draws > maxSeedStateDraws {
+		return nil, ErrSeedStateUnavailable
+	}
+	return b.src.state(), nil
+}
+
+// RestoreSeedState replaces the RNG of the box with one in the given state.
+// Restoring replays the draws from the seed, so it takes time proportional to
+// the number of values drawn before the checkpoint; states with more than
+// 2^30 draws are rejected with ErrInvalidSeedState.
+func (b *randomBox[T]) RestoreSeedState(state []byte) error {
+	src, err := restoreSource(state)
+	if err != nil {
+		return err
+	}
+	b.src = src
+	b.rng = rand.New(src)
+	return nil
+}
+
+// SeedState returns the RNG state of the wrapped box with the lock held.
+func (c *concurrentBox[T]) SeedState() ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if s, ok := c.box.(SeedStater); ok {
+		return s.SeedState()
+	}
+	return nil, ErrSeedStateUnavailable
+}
+
+// RestoreSeedState restores the RNG state of the wrapped box with the lock held.
+func (c *concurrentBox[T]) RestoreSeedState(state []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if s, ok := c.box.(SeedStater); ok {
+		return s.RestoreSeedState(state)
+	}
+	return ErrSeedStateUnavailable
+}
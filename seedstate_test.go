@@ -0,0 +1,86 @@
+package blackbox
+
+import (
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestSeedStateResume(t *testing.T) {
+	box := New[int](WithSeed(42))
+	for i := 0; i < 20; i++ {
+		box.Put(i)
+	}
+	for i := 0; i < 5; i++ {
+		box.Get()
+	}
+
+	state, err := box.(SeedStater).SeedState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(box)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resumed := New[int]()
+	if err := json.Unmarshal(data, resumed); err != nil {
+		t.Fatal(err)
+	}
+	if err := resumed.(SeedStater).RestoreSeedState(state); err != nil {
+		t.Fatal(err)
+	}
+	for box.Size() > 0 {
+		want, _ := box.Get()
+		got, _ := resumed.Get()
+		if got != want {
+			t.Fatalf("Expected resumed box to draw %d, got %d", want, got)
+		}
+	}
+}
+
+func TestSeedStateConcurrent(t *testing.T) {
+	box := NewConcurrent(New[int](WithSeed(1)))
+	if _, err := box.SeedState(); err != nil {
+		t.Errorf("Expected the state of the wrapped box, got %v", err)
+	}
+}
+
+func TestSeedStateErrors(t *testing.T) {
+	box := NewRandom[int](0, 0, rand.New(rand.NewSource(1)))
+	if _, err := box.SeedState(); err != ErrSeedStateUnavailable {
+		t.Errorf("Expected ErrSeedStateUnavailable, got %v", err)
+	}
+	if err := box.RestoreSeedState([]byte{1, 2, 3}); !errors.Is(err, ErrInvalidSeedState) {
+		t.Errorf("Expected ErrInvalidSeedState, got %v", err)
+	}
+	state := newSeededSource(7).state()
+	state[0] = 9
+	if err := box.RestoreSeedState(state); !errors.Is(err, ErrInvalidSeedState) {
+		t.Errorf("Expected ErrInvalidSeedState for version 9, got %v", err)
+	}
+	src := newSeededSource(7)
+	src.draws = maxSeedStateDraws + 1
+	start := time.Now()
+	if err := box.RestoreSeedState(src.state()); !errors.Is(err, ErrInvalidSeedState) {
+		t.Errorf("Expected ErrInvalidSeedState for too many draws, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the state to be rejected without replaying it, took %v", elapsed)
+	}
+	src.draws = ^uint64(0)
+	if err := box.RestoreSeedState(src.state()); !errors.Is(err, ErrInvalidSeedState) {
+		t.Errorf("Expected ErrInvalidSeedState for too many draws, got %v", err)
+	}
+	seeded := New[int](WithSeed(7)).(*randomBox[int])
+	seeded.src.draws = maxSeedStateDraws + 1
+	if _, err := seeded.SeedState(); err != ErrSeedStateUnavailable {
+		t.Errorf("Expected ErrSeedStateUnavailable past the replay limit, got %v", err)
+	}
+	if _, err := NewConcurrent[int](NewFIFO[int](0, 0)).SeedState(); err != ErrSeedStateUnavailable {
+		t.Errorf("Expected ErrSeedStateUnavailable for FIFO, got %v", err)
+	}
+}
@@ -0,0 +1,145 @@
+package blackbox
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// shardedBox spreads items across independently locked shards.
+type shardedBox[T any] struct {
+	// puts and gets pick the shard where the next Put and Get start. They
+	// come first so that they are 64-bit aligned on 32-bit platforms.
+	puts   uint64
+	gets   uint64
+	shards []*concurrentBox[T]
+}
+
+// NewSharded returns a goroutine-safe box that spreads items across shards
+// boxes created by factory, each behind its own lock, so that Put and Get on
+// many cores don't contend on a single mutex like NewConcurrent does.
+//
+// Put and Get pick shards round-robin and move on to the next shard when one
+// is full or empty. Ordering is therefore only kept per shard, e.g. FIFO
+// shards give roughly, not strictly, FIFO order. Size and Items aggregate all
+// shards without a global lock, so they are only a snapshot while other
+// goroutines are modifying the box.
+// Returns a concrete instance of sharded blackbox.
+func NewSharded[T any](shards int, factory func() BlackBox[T]) *shardedBox[T] {
+	if shards < 1 {
+		shards = 1
+	}
+	s := &shardedBox[T]{shards: make([]*concurrentBox[T], shards)}
+	for i := range s.shards {
+		s.shards[i] = NewConcurrent(factory())
+	}
+	return s
+}
+
+// start returns the shard index for the next operation using counter.
+func (s *shardedBox[T]) start(counter *uint64) int {
+	return int((atomic.AddUint64(counter, 1) - 1) % uint64(len(s.shards)))
+}
+
+func (s *shardedBox[T]) Put(item T) error {
+	start := s.start(&s.puts)
+	var err error
+	for i := 0; i < len(s.shards); i++ {
+		err = s.shards[(start+i)%len(s.shards)].Put(item)
+		if !errors.Is(err, ErrBlackBoxFull) {
+			return err
+		}
+	}
+	return err
+}
+
+func (s *shardedBox[T]) Get() (T, error) {
+	start := s.start(&s.gets)
+	for i := 0; i < len(s.shards); i++ {
+		item, err := s.shards[(start+i)%len(s.shards)].Get()
+		if !errors.Is(err, ErrEmptyBlackBox) {
+			return item, err
+		}
+	}
+	var zero T
+	return zero, ErrEmptyBlackBox
+}
+
+// Peek returns the item the next Get would start looking at.
+func (s *shardedBox[T]) Peek() (T, error) {
+	start := int(atomic.LoadUint64(&s.gets) % uint64(len(s.shards)))
+	for i := 0; i < len(s.shards); i++ {
+		item, err := s.shards[(start+i)%len(s.shards)].Peek()
+		if !errors.Is(err, ErrEmptyBlackBox) {
+			return item, err
+		}
+	}
+	var zero T
+	return zero, ErrEmptyBlackBox
+}
+
+func (s *shardedBox[T]) Size() int {
+	size := 0
+	for _, shard := range s.shards {
+		size += shard.Size()
+	}
+	return size
+}
+
+// MaxSize returns the combined maximum size of the shards, 0 if any is unbounded.
+func (s *shardedBox[T]) MaxSize() int {
+	maxSize := 0
+	for _, shard := range s.shards {
+		m := shard.MaxSize()
+		if m == 0 {
+			return 0
+		}
+		maxSize += m
+	}
+	return maxSize
+}
+
+func (s *shardedBox[T]) IsFull() bool {
+	for _, shard := range s.shards {
+		if !shard.IsFull() {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *shardedBox[T]) IsEmpty() bool {
+	for _, shard := range s.shards {
+		if !shard.IsEmpty() {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *shardedBox[T]) Clean() {
+	for _, shard := range s.shards {
+		shard.Clean()
+	}
+}
+
+// Items returns the items of every shard, shard by shard.
+func (s *shardedBox[T]) Items() []T {
+	var items []T
+	for _, shard := range s.shards {
+		items = append(items, shard.Items()...)
+	}
+	return items
+}
+
+// mutatesOnRead reports whether the boxes of the shards purge lazily on reads.
+func (s *shardedBox[T]) mutatesOnRead() bool {
+	for _, shard := range s.shards {
+		if mutatesOnRead(shard) {
+			return true
+		}
+	}
+	return false
+}
+
+// Compile-time assertion that shardedBox implements BlackBox[T].
+var _ BlackBox[any] = (*shardedBox[any])(nil)
@@ -0,0 +1,89 @@
+package blackbox
+
+import (
+	"sync"
+	"testing"
+	"time"
+	"unsafe"
+)
+
+func TestSharded(t *testing.T) {
+	box := NewSharded[int](4, func() BlackBox[int] { return NewFIFO[int](2, 2) })
+	if box.MaxSize() != 8 {
+		t.Errorf("Expected MaxSize 8, got %d", box.MaxSize())
+	}
+	for i := 0; i < 8; i++ {
+		if err := box.Put(i); err != nil {
+			t.Fatalf("Failed to put item %d: %v", i, err)
+		}
+	}
+	if err := box.Put(8); err != ErrBlackBoxFull {
+		t.Errorf("Expected ErrBlackBoxFull, got %v", err)
+	}
+	if !box.IsFull() || box.Size() != 8 || len(box.Items()) != 8 {
+		t.Errorf("Expected a full box of 8 items, got size %d", box.Size())
+	}
+
+	var got []int
+	for !box.IsEmpty() {
+		item, err := box.Get()
+		if err != nil {
+			t.Fatalf("Failed to get item: %v", err)
+		}
+		got = append(got, item)
+	}
+	for i := 0; i < 8; i++ {
+		if !ContainsInt(got, i) {
+			t.Errorf("Expected item %d to be retrieved", i)
+		}
+	}
+	if _, err := box.Get(); err != ErrEmptyBlackBox {
+		t.Errorf("Expected ErrEmptyBlackBox, got %v", err)
+	}
+}
+
+func TestShardedConcurrent(t *testing.T) {
+	box := NewSharded[int](8, func() BlackBox[int] { return NewFIFO[int](0, 16) })
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				box.Put(g*100 + i)
+				if i%2 == 0 {
+					box.Get()
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	if box.Size() != 400 {
+		t.Errorf("Expected 400 items, got %d", box.Size())
+	}
+}
+
+func TestShardedTimedNotCached(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	sharded := NewSharded[int](2, func() BlackBox[int] {
+		return New[int](WithStrategy(StrategyFIFO), WithTTL(time.Minute), WithClock(clock))
+	})
+	box := NewConcurrent[int](sharded)
+	if box.cached {
+		t.Fatal("Expected shards purging on reads to turn off the cached size")
+	}
+	box.Put(1)
+	clock.Advance(time.Minute)
+	if !box.IsEmpty() {
+		t.Errorf("Expected the expired item to be purged, got %v", box.Items())
+	}
+}
+
+func TestShardedAlignment(t *testing.T) {
+	// 64-bit atomics need 64-bit alignment on 32-bit platforms.
+	var b shardedBox[int]
+	if unsafe.Offsetof(b.puts)%8 != 0 || unsafe.Offsetof(b.gets)%8 != 0 {
+		t.Errorf("Expected aligned counters, got offsets %d and %d", unsafe.Offsetof(b.puts), unsafe.Offsetof(b.gets))
+	}
+}
@@ -0,0 +1,93 @@
+package blackbox
+
+import "math/rand"
+
+// shedBox randomly rejects Puts as a bounded box approaches its maximum size.
+type shedBox[T any] struct {
+	box      BlackBox[T]
+	fraction float64
+	rng      *rand.Rand
+}
+
+// NewLoadShedding wraps box so that Put randomly rejects items with ErrShed
+// once box holds more than fraction of its MaxSize, see WithLoadShedding.
+// Rejections are drawn from rng, or from a time-seeded RNG if rng is nil.
+// Returns a concrete instance of load shedding blackbox.
+func NewLoadShedding[T any](box BlackBox[T], fraction float64, rng *rand.Rand) *shedBox[T] {
+	if rng == nil {
+		rng, _ = config{}.rng()
+	}
+	return &shedBox[T]{box: box, fraction: fraction, rng: rng}
+}
+
+// shedProbability returns the probability of rejecting the next Put.
+func (s *shedBox[T]) shedProbability() float64 {
+	maxSize := s.box.MaxSize()
+	if maxSize <= 0 || s.fraction >= 1 {
+		return 0
+	}
+	size := s.box.Size()
+	if size >= maxSize {
+		// Let the box apply its overflow policy.
+		return 0
+	}
+	start := s.fraction * float64(maxSize)
+	if float64(size) <= start {
+		return 0
+	}
+	return (float64(size) - start) / (float64(maxSize) - start)
+}
+
+func (s *shedBox[T]) Put(item T) error {
+	if p := s.shedProbability(); p > 0 && s.rng.Float64() < p {
+		return ErrShed
+	}
+	return s.box.Put(item)
+}
+
+func (s *shedBox[T]) Get() (T, error) {
+	return s.box.Get()
+}
+
+func (s *shedBox[T]) Peek() (T, error) {
+	return s.box.Peek()
+}
+
+func (s *shedBox[T]) Size() int {
+	return s.box.Size()
+}
+
+func (s *shedBox[T]) MaxSize() int {
+	return s.box.MaxSize()
+}
+
+func (s *shedBox[T]) IsFull() bool {
+	return s.box.IsFull()
+}
+
+func (s *shedBox[T]) IsEmpty() bool {
+	return s.box.IsEmpty()
+}
+
+func (s *shedBox[T]) Clean() {
+	s.box.Clean()
+}
+
+func (s *shedBox[T]) Items() []T {
+	return s.box.Items()
+}
+
+func (s *shedBox[T]) mutatesOnRead() bool {
+	return mutatesOnRead(s.box)
+}
+
+func (s *shedBox[T]) mutatesOnPeek() bool {
+	return mutatesOnPeek(s.box)
+}
+
+func (s *shedBox[T]) drawPlan() drawPlan[T] {
+	return planOf(s.box)
+}
+
+// Compile-time assertion that shedBox implements BlackBox[T].
+var _ BlackBox[any] = (*shedBox[any])(nil)
@@ -0,0 +1,47 @@
+package blackbox
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestLoadShedding(t *testing.T) {
+	box := New[int](WithStrategy(StrategyFIFO), WithMaxSize(100), WithLoadShedding(0.5), WithSeed(1))
+	shed := 0
+	for i := 0; i < 1000 && !box.IsFull(); i++ {
+		switch err := box.Put(i); err {
+		case nil:
+		case ErrShed:
+			if box.Size() <= 50 {
+				t.Fatalf("Expected no shedding below half capacity, got one at size %d", box.Size())
+			}
+			shed++
+		default:
+			t.Fatalf("Unexpected error %v", err)
+		}
+	}
+	if shed == 0 {
+		t.Error("Expected some items to be shed near capacity")
+	}
+	if err := box.Put(0); err != ErrBlackBoxFull {
+		t.Errorf("Expected ErrBlackBoxFull once full, got %v", err)
+	}
+}
+
+func TestShedProbability(t *testing.T) {
+	box := NewLoadShedding[int](NewFIFO[int](10, 10), 0.5, rand.New(rand.NewSource(1)))
+	want := []float64{0, 0, 0, 0, 0, 0, 0.2, 0.4, 0.6, 0.8, 0}
+	for size, p := range want {
+		if got := box.shedProbability(); !almostEqual(got, p) {
+			t.Errorf("Size=%d Expected probability %v, got %v", size, p, got)
+		}
+		box.box.Put(size)
+	}
+
+	unbounded := NewLoadShedding[int](NewFIFO[int](0, 1), 0.5, nil)
+	for i := 0; i < 100; i++ {
+		if err := unbounded.Put(i); err != nil {
+			t.Fatalf("Expected unbounded box never to shed, got %v", err)
+		}
+	}
+}
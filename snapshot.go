@@ -0,0 +1,98 @@
+package blackbox
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// SaveSnapshot writes the content of box to w as the JSON encoding of an
+// Envelope, so that it can be restored with LoadSnapshot, e.g. to keep a task
+// queue across process restarts. Only WithSnapshotCompression is used from
+// opts.
+func SaveSnapshot[T any](w io.Writer, box BlackBox[T], opts ...Option) error {
+	e, err := asEnveloper(box)
+	if err != nil {
+		return err
+	}
+	env, err := e.envelope()
+	if err != nil {
+		return err
+	}
+	cw, err := compressWriter(w, opts)
+	if err != nil {
+		return err
+	}
+	env.Version = EnvelopeVersion
+	if err := json.NewEncoder(cw).Encode(env); err != nil {
+		cw.Close()
+		return err
+	}
+	return cw.Close()
+}
+
+// LoadSnapshot creates a new BlackBox from a snapshot written by SaveSnapshot.
+// Like NewFromJSON, opts are applied after the saved strategy, maximum size
+// and seed, so options that are not saved, such as WithEvictCallback, can be
+// passed again. Compressed snapshots are decompressed, see
+// WithSnapshotCompression.
+func LoadSnapshot[T any](r io.Reader, opts ...Option) (BlackBox[T], error) {
+	dr, err := decompressReader(r, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer dr.Close()
+	var data json.RawMessage
+	if err := json.NewDecoder(dr).Decode(&data); err != nil {
+		return nil, err
+	}
+	env, err := decodeEnvelope[T](data)
+	if err != nil {
+		return nil, err
+	}
+	return newFromEnvelope(env, opts)
+}
+
+// SaveFile saves a snapshot of box to the file at path. The snapshot is
+// written to a temporary file that then replaces path, so a crash while
+// saving never leaves a truncated snapshot behind. opts are passed to
+// SaveSnapshot.
+func SaveFile[T any](path string, box BlackBox[T], opts ...Option) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+	if err := SaveSnapshot(f, box, opts...); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// LoadFile creates a new BlackBox from the snapshot file at path, see
+// LoadSnapshot. When the file does not exist, the returned error satisfies
+// errors.Is(err, fs.ErrNotExist).
+func LoadFile[T any](path string, opts ...Option) (BlackBox[T], error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return LoadSnapshot[T](f, opts...)
+}
@@ -0,0 +1,61 @@
+package blackbox
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSnapshot(t *testing.T) {
+	box := New[string](WithStrategy(StrategyFIFO), WithMaxSize(10))
+	box.Put("build")
+	box.Put("deploy")
+
+	var buf bytes.Buffer
+	if err := SaveSnapshot(&buf, box); err != nil {
+		t.Fatalf("Failed to save snapshot: %v", err)
+	}
+	var evicted []string
+	restored, err := LoadSnapshot[string](&buf, WithEvictCallback(func(item string) {
+		evicted = append(evicted, item)
+	}))
+	if err != nil {
+		t.Fatalf("Failed to load snapshot: %v", err)
+	}
+	if item, _ := restored.Peek(); item != "build" || restored.MaxSize() != 10 {
+		t.Errorf("Expected restored FIFO box, got %s with max size %d", item, restored.MaxSize())
+	}
+	restored.Clean()
+	if len(evicted) != 2 {
+		t.Errorf("Expected options to be applied, got %v", evicted)
+	}
+}
+
+func TestSnapshotFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.json")
+	if _, err := LoadFile[int](path); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("Expected fs.ErrNotExist, got %v", err)
+	}
+
+	if err := SaveFile[int](path, NewLIFOFrom[int]([]int{1, 2}, 0)); err != nil {
+		t.Fatalf("Failed to save file: %v", err)
+	}
+	if err := SaveFile[int](path, NewLIFOFrom[int]([]int{1, 2, 3}, 0)); err != nil {
+		t.Fatalf("Failed to overwrite file: %v", err)
+	}
+	box, err := LoadFile[int](path)
+	if err != nil {
+		t.Fatalf("Failed to load file: %v", err)
+	}
+	if items := Drain(box); !EqualInts(items, []int{3, 2, 1}) {
+		t.Errorf("Expected [3 2 1], got %v", items)
+	}
+
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("Expected temporary files to be cleaned up, got %d entries", len(entries))
+	}
+}
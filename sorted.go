@@ -0,0 +1,182 @@
+package blackbox
+
+import "math/rand"
+
+const (
+	sortedMaxLevel = 32
+	// sortedLevelMask gives each node a 1/4 chance of being promoted to the next level.
+	sortedLevelMask = 3
+)
+
+type skipNode[T any] struct {
+	item T
+	next []*skipNode[T]
+}
+
+// sortedBox keeps its items ordered by a less function using a skip list,
+// so Put, Get and Peek stay O(log n) even with millions of items.
+// Get and Peek always return the smallest item; equal items come out in insertion order.
+type sortedBox[T any] struct {
+	head    *skipNode[T]
+	level   int
+	size    int
+	maxSize int
+	less    func(a, b T) bool
+	rng     *rand.Rand
+}
+
+// NewSorted creates a new sorted blackbox ordered by less with the specified maximum size.
+// Returns a concrete instance of sorted blackbox without interface.
+func NewSorted[T any](less func(a, b T) bool, maxSize int) *sortedBox[T] {
+	return &sortedBox[T]{
+		head:    &skipNode[T]{next: make([]*skipNode[T], sortedMaxLevel)},
+		level:   1,
+		maxSize: maxSize,
+		less:    less,
+		// Levels only affect performance, so a fixed seed keeps the layout reproducible.
+		rng: rand.New(rand.NewSource(1)),
+	}
+}
+
+// NewSortedFrom creates a new sorted blackbox from a slice of items and the specified maximum size.
+// items are copied so it safe to use the original slice after the blackbox is created.
+func NewSortedFrom[T any](items []T, less func(a, b T) bool, maxSize int) *sortedBox[T] {
+	if maxSize > 0 && maxSize < len(items) {
+		maxSize = len(items)
+	}
+	b := NewSorted[T](less, maxSize)
+	for _, item := range items {
+		b.insert(item)
+	}
+	return b
+}
+
+func (b *sortedBox[T]) randomLevel() int {
+	level := 1
+	for level < sortedMaxLevel && b.rng.Int63()&sortedLevelMask == 0 {
+		level++
+	}
+	return level
+}
+
+func (b *sortedBox[T]) insert(item T) {
+	var update [sortedMaxLevel]*skipNode[T]
+	x := b.head
+	for i := b.level - 1; i >= 0; i-- {
+		// Walk past equal items so that equal items keep insertion order.
+		for x.next[i] != nil && !b.less(item, x.next[i].item) {
+			x = x.next[i]
+		}
+		update[i] = x
+	}
+
+	level := b.randomLevel()
+	if level > b.level {
+		for i := b.level; i < level; i++ {
+			update[i] = b.head
+		}
+		b.level = level
+	}
+
+	node := &skipNode[T]{item: item, next: make([]*skipNode[T], level)}
+	for i := 0; i < level; i++ {
+		node.next[i] = update[i].next[i]
+		update[i].next[i] = node
+	}
+	b.size++
+}
+
+func (b *sortedBox[T]) Put(item T) error {
+	if b.maxSize > 0 && b.size >= b.maxSize {
+		return ErrBlackBoxFull
+	}
+	b.insert(item)
+	return nil
+}
+
+func (b *sortedBox[T]) Get() (T, error) {
+	first := b.head.next[0]
+	if first == nil {
+		var zero T
+		return zero, ErrEmptyBlackBox
+	}
+	// The first node is the successor of the head on every level it occupies.
+	for i := range first.next {
+		b.head.next[i] = first.next[i]
+	}
+	for b.level > 1 && b.head.next[b.level-1] == nil {
+		b.level--
+	}
+	b.size--
+	return first.item, nil
+}
+
+func (b *sortedBox[T]) Peek() (T, error) {
+	first := b.head.next[0]
+	if first == nil {
+		var zero T
+		return zero, ErrEmptyBlackBox
+	}
+	return first.item, nil
+}
+
+// removeFunc removes every item for which pred returns true and returns the
+// removed items in ascending order.
+func (b *sortedBox[T]) removeFunc(pred func(item T) bool) []T {
+	var removed []T
+	// update[i] is the last kept node seen so far that occupies level i.
+	var update [sortedMaxLevel]*skipNode[T]
+	for i := 0; i < b.level; i++ {
+		update[i] = b.head
+	}
+	for x := b.head.next[0]; x != nil; x = x.next[0] {
+		if !pred(x.item) {
+			for i := range x.next {
+				update[i] = x
+			}
+			continue
+		}
+		for i := range x.next {
+			update[i].next[i] = x.next[i]
+		}
+		removed = append(removed, x.item)
+		b.size--
+	}
+	for b.level > 1 && b.head.next[b.level-1] == nil {
+		b.level--
+	}
+	return removed
+}
+
+func (b *sortedBox[T]) Size() int {
+	return b.size
+}
+
+func (b *sortedBox[T]) MaxSize() int {
+	return b.maxSize
+}
+
+func (b *sortedBox[T]) IsFull() bool {
+	return b.maxSize > 0 && b.size >= b.maxSize
+}
+
+func (b *sortedBox[T]) IsEmpty() bool {
+	return b.size == 0
+}
+
+func (b *sortedBox[T]) Clean() {
+	for i := range b.head.next {
+		b.head.next[i] = nil
+	}
+	b.level = 1
+	b.size = 0
+}
+
+// Items returns a copy of all items in ascending order.
+func (b *sortedBox[T]) Items() []T {
+	items := make([]T, 0, b.size)
+	for x := b.head.next[0]; x != nil; x = x.next[0] {
+		items = append(items, x.item)
+	}
+	return items
+}
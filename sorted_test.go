@@ -0,0 +1,99 @@
+package blackbox
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func lessInt(a, b int) bool { return a < b }
+
+func TestSortedOrder(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	box := NewSorted[int](lessInt, 0)
+
+	data := make([]int, 1000)
+	for i := range data {
+		data[i] = rng.Intn(500)
+		if err := box.Put(data[i]); err != nil {
+			t.Fatalf("Failed to put item %d: %v", data[i], err)
+		}
+	}
+	sort.Ints(data)
+
+	if box.Size() != len(data) {
+		t.Fatalf("Expected size %d, got %d", len(data), box.Size())
+	}
+	if items := box.Items(); !EqualInts(items, data) {
+		t.Fatalf("Expected Items() in ascending order")
+	}
+
+	for i, want := range data {
+		peeked, err := box.Peek()
+		if err != nil {
+			t.Fatalf("Failed to peek: %v", err)
+		}
+		item, err := box.Get()
+		if err != nil {
+			t.Fatalf("Failed to get item: %v", err)
+		}
+		if item != want || peeked != want {
+			t.Fatalf("At %d expected %d, got peek %d get %d", i, want, peeked, item)
+		}
+	}
+
+	if !box.IsEmpty() {
+		t.Error("Box should be empty")
+	}
+	if _, err := box.Get(); err != ErrEmptyBlackBox {
+		t.Errorf("Expected ErrEmptyBlackBox, got %v", err)
+	}
+	if _, err := box.Peek(); err != ErrEmptyBlackBox {
+		t.Errorf("Expected ErrEmptyBlackBox, got %v", err)
+	}
+}
+
+func TestSortedStableForEqualItems(t *testing.T) {
+	type task struct {
+		priority int
+		id       int
+	}
+	box := NewSorted[task](func(a, b task) bool { return a.priority < b.priority }, 0)
+	box.Put(task{priority: 2, id: 1})
+	box.Put(task{priority: 1, id: 2})
+	box.Put(task{priority: 2, id: 3})
+	box.Put(task{priority: 1, id: 4})
+
+	want := []int{2, 4, 1, 3}
+	for _, id := range want {
+		item, _ := box.Get()
+		if item.id != id {
+			t.Errorf("Expected task %d, got %d", id, item.id)
+		}
+	}
+}
+
+func TestSortedMaxSizeAndClean(t *testing.T) {
+	box := NewSortedFrom[int]([]int{3, 1, 2}, lessInt, 1)
+	if box.MaxSize() != 3 {
+		t.Errorf("Expected max size 3, got %d", box.MaxSize())
+	}
+	if !box.IsFull() {
+		t.Error("Box should be full")
+	}
+	if err := box.Put(4); err != ErrBlackBoxFull {
+		t.Errorf("Expected ErrBlackBoxFull, got %v", err)
+	}
+	if items := box.Items(); !EqualInts(items, []int{1, 2, 3}) {
+		t.Errorf("Expected items [1 2 3], got %v", items)
+	}
+
+	box.Clean()
+	if !box.IsEmpty() || box.Size() != 0 {
+		t.Errorf("Expected empty box after Clean(), got size %d", box.Size())
+	}
+	box.Put(5)
+	if item, _ := box.Get(); item != 5 {
+		t.Errorf("Expected item 5 after Clean(), got %d", item)
+	}
+}
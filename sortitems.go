@@ -0,0 +1,75 @@
+package blackbox
+
+import "sort"
+
+// Sorter is implemented by blackboxes whose items can be reordered in place,
+// e.g. to re-prioritize an existing backlog by deadline without rebuilding the
+// box.
+type Sorter[T any] interface {
+	// SortItems reorders the items so that they are retrieved in ascending
+	// order according to less; equal items keep their retrieval order. Items
+	// put afterwards follow the strategy of the box as usual.
+	SortItems(less func(a, b T) bool)
+}
+
+// sortItems reorders the items from front to back according to less.
+func (b *ring[T]) sortItems(less func(a, b T) bool) {
+	items := b.Items()
+	sort.SliceStable(items, func(i, j int) bool {
+		return less(items[i], items[j])
+	})
+	for i, item := range items {
+		b.items[(b.head+i)%len(b.items)] = item
+	}
+}
+
+func (b *fifoBox[T]) SortItems(less func(a, b T) bool) {
+	b.sortItems(less)
+}
+
+func (b *dequeBox[T]) SortItems(less func(a, b T) bool) {
+	b.sortItems(less)
+}
+
+// SortItems reorders the stack so that the smallest item is on top.
+func (b *lifoBox[T]) SortItems(less func(a, b T) bool) {
+	// The top of the stack is the end of the slice: sort the items in
+	// retrieval order, then put them back.
+	reverse(b.items)
+	sort.SliceStable(b.items, func(i, j int) bool {
+		return less(b.items[i], b.items[j])
+	})
+	reverse(b.items)
+}
+
+// SortItems reorders the unexpired items of a FIFO or LIFO box.
+func (t *timedBox[T]) SortItems(less func(a, b T) bool) {
+	t.purge()
+	if s, ok := t.box.(Sorter[timedItem[T]]); ok {
+		s.SortItems(func(a, b timedItem[T]) bool {
+			return less(a.value, b.value)
+		})
+	}
+}
+
+// SortItems reorders the items with the lock held. less is called with the
+// lock held, so it must not call back into the box. The wrapped box must
+// implement Sorter[T], otherwise the items keep their order.
+func (c *concurrentBox[T]) SortItems(less func(a, b T) bool) {
+	s, ok := c.box.(Sorter[T])
+	if !ok {
+		return
+	}
+	c.mu.Lock()
+	s.SortItems(less)
+	c.refresh()
+	c.mu.Unlock()
+}
+
+// SortItems reorders the items of the wrapped box, which must implement
+// Sorter[T], otherwise the items keep their order.
+func (c *categoryBox[T, K]) SortItems(less func(a, b T) bool) {
+	if s, ok := c.box.(Sorter[T]); ok {
+		s.SortItems(less)
+	}
+}
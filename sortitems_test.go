@@ -0,0 +1,76 @@
+package blackbox
+
+import (
+	"testing"
+	"time"
+)
+
+type deadlineTask struct {
+	name     string
+	deadline int
+}
+
+func byDeadline(a, b deadlineTask) bool {
+	return a.deadline < b.deadline
+}
+
+func drainNames(box BlackBox[deadlineTask]) []string {
+	var names []string
+	for !box.IsEmpty() {
+		task, _ := box.Get()
+		names = append(names, task.name)
+	}
+	return names
+}
+
+func TestSortItems(t *testing.T) {
+	tasks := []deadlineTask{{"a", 3}, {"b", 1}, {"c", 2}, {"d", 1}}
+	boxes := map[string]BlackBox[deadlineTask]{
+		"fifo":       NewFIFOFrom(tasks, 0),
+		"lifo":       NewLIFOFrom(tasks, 0),
+		"deque":      NewDequeFrom(tasks, 0),
+		"concurrent": NewConcurrent[deadlineTask](NewFIFOFrom(tasks, 0)),
+		"ttl":        NewFrom(tasks, WithStrategy(StrategyLIFO), WithTTL(time.Hour)),
+	}
+	expected := map[string][]string{
+		"fifo":       {"b", "d", "c", "a"},
+		"lifo":       {"d", "b", "c", "a"},
+		"deque":      {"b", "d", "c", "a"},
+		"concurrent": {"b", "d", "c", "a"},
+		"ttl":        {"d", "b", "c", "a"},
+	}
+	for name, box := range boxes {
+		box.(Sorter[deadlineTask]).SortItems(byDeadline)
+		if got := drainNames(box); !equalStrings(got, expected[name]) {
+			t.Errorf("%s: Expected %v, got %v", name, expected[name], got)
+		}
+	}
+}
+
+func TestSortItemsWrapped(t *testing.T) {
+	// A FIFO ring that wrapped around keeps its order after sorting.
+	box := NewFIFO[int](4, 4)
+	for i := 1; i <= 4; i++ {
+		box.Put(i * 10)
+	}
+	box.Get()
+	box.Get()
+	box.Put(5)
+	box.Put(15)
+	box.SortItems(func(a, b int) bool { return a < b })
+	if !EqualInts(box.Items(), []int{5, 15, 30, 40}) {
+		t.Errorf("Expected sorted items, got %v", box.Items())
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
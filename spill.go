@@ -0,0 +1,231 @@
+package blackbox
+
+import (
+	"bufio"
+	"io"
+	"os"
+)
+
+// spillBox is a FIFO blackbox that keeps up to hot items in memory and spills
+// the overflow to a temporary file, so an unbounded queue doesn't exhaust
+// memory while consumers are down.
+type spillBox[T any] struct {
+	mem     ring[T]
+	hot     int
+	maxSize int
+	codec   ItemCodec[T]
+
+	file      *os.File
+	readOff   int64 // offset of the oldest spilled record
+	writeOff  int64 // end of the spilled records
+	diskCount int
+	compactAt int64 // readOff from which the read records are dropped
+}
+
+// spillCompactSize is the size of the read records from which the spill file
+// is compacted, so it doesn't grow forever under a steady producer.
+const spillCompactSize = 1 << 20
+
+// NewSpill creates a FIFO blackbox holding at most hot items in memory and
+// spilling the others, encoded with codec, to a temporary file in dir (the
+// default temporary directory if dir is empty). A maxSize of 0 means unlimited.
+// The spill file is not meant to survive restarts; call Close to remove it.
+// Returns a concrete instance of spill blackbox without interface.
+func NewSpill[T any](dir string, hot, maxSize int, codec ItemCodec[T]) (*spillBox[T], error) {
+	if hot < 1 {
+		hot = 1
+	}
+	f, err := os.CreateTemp(dir, "blackbox-spill-*")
+	if err != nil {
+		return nil, err
+	}
+	return &spillBox[T]{
+		mem:       newRing[T](hot, 0),
+		hot:       hot,
+		maxSize:   maxSize,
+		codec:     codec,
+		file:      f,
+		compactAt: spillCompactSize,
+	}, nil
+}
+
+// spill appends an item to the spill file.
+func (b *spillBox[T]) spill(item T) error {
+	data, err := b.codec.Encode(item)
+	if err != nil {
+		return err
+	}
+	rec := append(uvarintBytes(uint64(len(data))), data...)
+	if _, err := b.file.WriteAt(rec, b.writeOff); err != nil {
+		return err
+	}
+	b.writeOff += int64(len(rec))
+	b.diskCount++
+	return nil
+}
+
+// refill moves up to hot spilled items back into memory once it is empty.
+// The items are only moved once they all decode, so a failed refill can be
+// retried without duplicating them.
+func (b *spillBox[T]) refill() error {
+	if b.mem.size > 0 || b.diskCount == 0 {
+		return nil
+	}
+	r := bufio.NewReader(io.NewSectionReader(b.file, b.readOff, b.writeOff-b.readOff))
+	off := b.readOff
+	items := make([]T, 0, minInt(b.hot, b.diskCount))
+	for len(items) < b.hot && len(items) < b.diskCount {
+		data, err := readBytes(r)
+		if err != nil {
+			return err
+		}
+		item, err := b.codec.Decode(data)
+		if err != nil {
+			return err
+		}
+		items = append(items, item)
+		off += int64(len(uvarintBytes(uint64(len(data))))) + int64(len(data))
+	}
+	for _, item := range items {
+		b.mem.pushBack(item)
+	}
+	b.readOff = off
+	b.diskCount -= len(items)
+	if b.diskCount == 0 {
+		return b.resetFile()
+	}
+	if b.readOff >= b.compactAt && b.readOff >= b.writeOff-b.readOff {
+		return b.compact()
+	}
+	return nil
+}
+
+// compact moves the unread records to the start of the spill file and drops
+// the read ones. The unread records are not longer than the read ones, so
+// they are copied without overlapping.
+func (b *spillBox[T]) compact() error {
+	buf := make([]byte, 32<<10)
+	var n int64
+	for b.readOff+n < b.writeOff {
+		m, err := b.file.ReadAt(buf[:minInt(len(buf), int(b.writeOff-b.readOff-n))], b.readOff+n)
+		if err != nil {
+			return err
+		}
+		if _, err := b.file.WriteAt(buf[:m], n); err != nil {
+			return err
+		}
+		n += int64(m)
+	}
+	if err := b.file.Truncate(n); err != nil {
+		return err
+	}
+	b.readOff, b.writeOff = 0, n
+	return nil
+}
+
+// resetFile empties the spill file.
+func (b *spillBox[T]) resetFile() error {
+	b.readOff, b.writeOff, b.diskCount = 0, 0, 0
+	return b.file.Truncate(0)
+}
+
+// Spilled returns the number of items currently on disk.
+func (b *spillBox[T]) Spilled() int {
+	return b.diskCount
+}
+
+func (b *spillBox[T]) Put(item T) error {
+	if b.IsFull() {
+		return ErrBlackBoxFull
+	}
+	// Once items are on disk, new items must follow them to keep FIFO order.
+	if b.diskCount > 0 || b.mem.size >= b.hot {
+		return b.spill(item)
+	}
+	b.mem.pushBack(item)
+	return nil
+}
+
+// Get removes and returns the oldest item, reading spilled items back from
+// disk when memory runs empty.
+func (b *spillBox[T]) Get() (T, error) {
+	if err := b.refill(); err != nil {
+		var zero T
+		return zero, err
+	}
+	if b.mem.size == 0 {
+		var zero T
+		return zero, ErrEmptyBlackBox
+	}
+	return b.mem.popFront(), nil
+}
+
+func (b *spillBox[T]) Peek() (T, error) {
+	if err := b.refill(); err != nil {
+		var zero T
+		return zero, err
+	}
+	if b.mem.size == 0 {
+		var zero T
+		return zero, ErrEmptyBlackBox
+	}
+	return b.mem.front(), nil
+}
+
+func (b *spillBox[T]) Size() int {
+	return b.mem.size + b.diskCount
+}
+
+func (b *spillBox[T]) MaxSize() int {
+	return b.maxSize
+}
+
+func (b *spillBox[T]) IsFull() bool {
+	return b.maxSize > 0 && b.Size() >= b.maxSize
+}
+
+func (b *spillBox[T]) IsEmpty() bool {
+	return b.Size() == 0
+}
+
+func (b *spillBox[T]) Clean() {
+	b.mem.Clean()
+	b.resetFile()
+}
+
+// Items returns a copy of all items in FIFO order, including the spilled ones.
+// Spilled items that cannot be read back are left out.
+func (b *spillBox[T]) Items() []T {
+	items := b.mem.Items()
+	r := bufio.NewReader(io.NewSectionReader(b.file, b.readOff, b.writeOff-b.readOff))
+	for i := 0; i < b.diskCount; i++ {
+		data, err := readBytes(r)
+		if err != nil {
+			break
+		}
+		item, err := b.codec.Decode(data)
+		if err != nil {
+			break
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// mutatesOnPeek reports true: Peek refills the memory from the spill file,
+// so concurrent Peeks must not share a read lock.
+func (b *spillBox[T]) mutatesOnPeek() bool {
+	return true
+}
+
+// Close removes the spill file. The box must not be used afterwards.
+func (b *spillBox[T]) Close() error {
+	err := b.file.Close()
+	if rerr := os.Remove(b.file.Name()); err == nil {
+		err = rerr
+	}
+	return err
+}
+
+// Compile-time assertion that spillBox implements BlackBox[T].
+var _ BlackBox[any] = (*spillBox[any])(nil)
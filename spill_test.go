@@ -0,0 +1,153 @@
+package blackbox
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"testing"
+)
+
+func TestSpill(t *testing.T) {
+	dir := t.TempDir()
+	box, err := NewSpill[int](dir, 3, 0, DefaultItemCodec[int]())
+	if err != nil {
+		t.Fatalf("Failed to create spill box: %v", err)
+	}
+	defer box.Close()
+
+	for i := 1; i <= 10; i++ {
+		if err := box.Put(i); err != nil {
+			t.Fatalf("Failed to put item %d: %v", i, err)
+		}
+	}
+	if box.Size() != 10 || box.Spilled() != 7 {
+		t.Errorf("Expected 10 items with 7 spilled, got %d and %d", box.Size(), box.Spilled())
+	}
+	if items := box.Items(); !EqualInts(items, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}) {
+		t.Errorf("Expected items in FIFO order, got %v", items)
+	}
+
+	var got []int
+	for i := 0; i < 5; i++ {
+		item, _ := box.Get()
+		got = append(got, item)
+	}
+	box.Put(11)
+	for !box.IsEmpty() {
+		item, err := box.Get()
+		if err != nil {
+			t.Fatalf("Failed to get item: %v", err)
+		}
+		got = append(got, item)
+	}
+	if !EqualInts(got, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}) {
+		t.Errorf("Expected FIFO order across spills, got %v", got)
+	}
+	if info, _ := box.file.Stat(); info.Size() != 0 {
+		t.Errorf("Expected spill file to be emptied, got %d bytes", info.Size())
+	}
+	if _, err := box.Peek(); err != ErrEmptyBlackBox {
+		t.Errorf("Expected ErrEmptyBlackBox, got %v", err)
+	}
+}
+
+func TestSpillMaxSizeCleanClose(t *testing.T) {
+	box, _ := NewSpill[string](t.TempDir(), 1, 3, DefaultItemCodec[string]())
+	box.Put("a")
+	box.Put("b")
+	box.Put("c")
+	if err := box.Put("d"); err != ErrBlackBoxFull {
+		t.Errorf("Expected ErrBlackBoxFull, got %v", err)
+	}
+	box.Clean()
+	if !box.IsEmpty() || box.Spilled() != 0 {
+		t.Errorf("Expected empty box after Clean(), got size %d", box.Size())
+	}
+
+	name := box.file.Name()
+	if err := box.Close(); err != nil {
+		t.Fatalf("Failed to close: %v", err)
+	}
+	if _, err := os.Stat(name); !os.IsNotExist(err) {
+		t.Errorf("Expected spill file to be removed, got %v", err)
+	}
+}
+
+func TestSpillCompacts(t *testing.T) {
+	box, _ := NewSpill[int](t.TempDir(), 2, 0, DefaultItemCodec[int]())
+	defer box.Close()
+	box.compactAt = 16
+
+	next, want := 0, 0
+	for i := 0; i < 1000; i++ {
+		for box.Spilled() < 5 {
+			box.Put(next)
+			next++
+		}
+		item, err := box.Get()
+		if err != nil || item != want {
+			t.Fatalf("Expected %d, got %d (%v)", want, item, err)
+		}
+		want++
+	}
+	if info, _ := box.file.Stat(); info.Size() > 64 {
+		t.Errorf("Expected the spill file to be compacted, got %d bytes", info.Size())
+	}
+}
+
+func TestSpillRefillDecodeError(t *testing.T) {
+	codec := DefaultItemCodec[int]()
+	decode := codec.Decode
+	fail := true
+	codec.Decode = func(data []byte) (int, error) {
+		item, err := decode(data)
+		if item == 4 && fail {
+			fail = false
+			return 0, errors.New("decode failed")
+		}
+		return item, err
+	}
+	box, _ := NewSpill[int](t.TempDir(), 2, 0, codec)
+	defer box.Close()
+	for i := 1; i <= 5; i++ {
+		box.Put(i)
+	}
+	box.Get()
+	box.Get()
+	if _, err := box.Get(); err == nil {
+		t.Fatal("Expected the refill to fail")
+	}
+	if got := Drain[int](box); !EqualInts(got, []int{3, 4, 5}) {
+		t.Errorf("Expected [3 4 5] after the failed refill, got %v", got)
+	}
+}
+
+func TestSpillConcurrentPeek(t *testing.T) {
+	spill, err := NewSpill[int](t.TempDir(), 2, 0, DefaultItemCodec[int]())
+	if err != nil {
+		t.Fatalf("Failed to create spill box: %v", err)
+	}
+	defer spill.Close()
+	box := NewConcurrentRW[int](spill)
+	if box.readShared || !box.cached {
+		t.Error("Expected a spill box to use the exclusive lock and the cached size")
+	}
+	for i := 1; i <= 6; i++ {
+		box.Put(i)
+	}
+	box.Get()
+	box.Get()
+
+	// Peek refills the memory from the spill file.
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if item, err := box.Peek(); err != nil || item != 3 {
+				t.Errorf("Expected 3, got %d (%v)", item, err)
+			}
+		}()
+	}
+	wg.Wait()
+}
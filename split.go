@@ -0,0 +1,62 @@
+package blackbox
+
+// Split distributes the items of box round-robin across n new boxes, e.g. to
+// shard a big work queue across workers. Each shard is a clone of box with
+// the same strategy, maximum size and options, and items keep their relative
+// order. box itself is not modified.
+//
+// A box that cannot be cloned, such as one with WithLoadShedding or
+// WithAudit, is split into FIFO boxes with the same maximum size holding the
+// items in retrieval order, like Filter. A n below 1 is treated as 1.
+func Split[T any](box BlackBox[T], n int) []BlackBox[T] {
+	if n < 1 {
+		n = 1
+	}
+	shards := make([]BlackBox[T], n)
+	for s := range shards {
+		s := s
+		i := 0
+		shards[s] = filterClone(box, func(T) bool {
+			keep := i%n == s
+			i++
+			return keep
+		})
+	}
+	return shards
+}
+
+// Partition returns two clones of box: match holds the items for which pred
+// returns true and rest holds the others, e.g. to separate urgent items.
+// pred is called twice for every item and must be deterministic. box itself
+// is not modified.
+//
+// A box that cannot be cloned is partitioned into FIFO boxes, as for Split.
+func Partition[T any](box BlackBox[T], pred func(item T) bool) (match, rest BlackBox[T]) {
+	match = filterClone(box, pred)
+	rest = filterClone(box, func(item T) bool {
+		return !pred(item)
+	})
+	return match, rest
+}
+
+// filterClone clones box and keeps only the items for which keep returns
+// true. keep is called once for every item, in retrieval order when box
+// cannot be cloned.
+func filterClone[T any](box BlackBox[T], keep func(item T) bool) BlackBox[T] {
+	if c, err := cloneBox(box, nil); err == nil {
+		if remover, ok := c.(Remover[T]); ok {
+			remover.RemoveFunc(func(item T) bool {
+				return !keep(item)
+			})
+			return c
+		}
+	}
+	var items []T
+	eachItem(box, func(item T) bool {
+		if keep(item) {
+			items = append(items, item)
+		}
+		return true
+	})
+	return &fifoBox[T]{ring: newRingFrom(items, box.MaxSize())}
+}
@@ -0,0 +1,79 @@
+package blackbox
+
+import (
+	"io"
+	"testing"
+)
+
+func TestSplit(t *testing.T) {
+	box := NewFIFOFrom[int]([]int{1, 2, 3, 4, 5, 6, 7}, 10)
+	shards := Split[int](box, 3)
+	want := [][]int{{1, 4, 7}, {2, 5}, {3, 6}}
+	if len(shards) != len(want) {
+		t.Fatalf("Expected %d shards, got %d", len(want), len(shards))
+	}
+	for i, shard := range shards {
+		if shard.MaxSize() != 10 {
+			t.Errorf("Shard %d: Expected max size 10, got %d", i, shard.MaxSize())
+		}
+		if items := Drain(shard); !EqualInts(items, want[i]) {
+			t.Errorf("Shard %d: Expected %v, got %v", i, want[i], items)
+		}
+	}
+	if box.Size() != 7 {
+		t.Errorf("Expected original box to be untouched, got size %d", box.Size())
+	}
+
+	if shards := Split[int](box, 0); len(shards) != 1 || shards[0].Size() != 7 {
+		t.Errorf("Expected a single shard holding every item")
+	}
+}
+
+func TestPartition(t *testing.T) {
+	box := New[int](WithStrategy(StrategyLIFO))
+	for i := 1; i <= 6; i++ {
+		box.Put(i)
+	}
+	match, rest := Partition(box, isEven)
+	if items := Drain(match); !EqualInts(items, []int{6, 4, 2}) {
+		t.Errorf("Expected [6 4 2], got %v", items)
+	}
+	if items := Drain(rest); !EqualInts(items, []int{5, 3, 1}) {
+		t.Errorf("Expected [5 3 1], got %v", items)
+	}
+	if box.Size() != 6 {
+		t.Errorf("Expected original box to be untouched, got size %d", box.Size())
+	}
+}
+
+func TestSplitNonClonable(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5, 6}
+	fifo := []Option{WithStrategy(StrategyFIFO), WithMaxSize(10)}
+	boxes := map[string]BlackBox[int]{
+		"shedding": NewFrom(data, append(fifo, WithLoadShedding(0.9))...),
+		"tickets":  NewFrom(data, append(fifo, WithTickets())...),
+		"chunked":  NewFrom(data, append(fifo, WithChunkSize(2))...),
+		"lockfree": NewFrom(data, append(fifo, WithLockFree())...),
+		"audit":    NewFrom(data, append(fifo, WithAudit(io.Discard))...),
+		"metered":  NewMetered(NewFrom(data, fifo...)),
+	}
+	for name, box := range boxes {
+		shards := Split(box, 2)
+		if items := Drain(shards[0]); !EqualInts(items, []int{1, 3, 5}) {
+			t.Errorf("%s: Expected shard [1 3 5], got %v", name, items)
+		}
+		if items := Drain(shards[1]); !EqualInts(items, []int{2, 4, 6}) {
+			t.Errorf("%s: Expected shard [2 4 6], got %v", name, items)
+		}
+		match, rest := Partition(box, isEven)
+		if items := Drain(match); !EqualInts(items, []int{2, 4, 6}) {
+			t.Errorf("%s: Expected match [2 4 6], got %v", name, items)
+		}
+		if items := Drain(rest); !EqualInts(items, []int{1, 3, 5}) {
+			t.Errorf("%s: Expected rest [1 3 5], got %v", name, items)
+		}
+		if box.Size() != 6 {
+			t.Errorf("%s: Expected original box to be untouched, got size %d", name, box.Size())
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package blackbox
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// statsInterval is the minimum time between two samples of Stats.
+	statsInterval = time.Second
+	// statsHistory is the number of samples kept by Stats.
+	statsHistory = 60
+)
+
+// Stats holds the throughput of a metered blackbox over its recent history.
+type Stats struct {
+	Metrics
+	// PutRate, GetRate and RejectRate are per second over the time covered
+	// by SizeHistory.
+	PutRate    float64 `json:"putRate"`
+	GetRate    float64 `json:"getRate"`
+	RejectRate float64 `json:"rejectRate"`
+	// SizeHistory holds the size of the box sampled at most once per second
+	// on Put, Get and Clean, oldest first, for the last 60 samples.
+	SizeHistory []SizeSample `json:"sizeHistory"`
+}
+
+// SizeSample is the size of a box at a point in time.
+type SizeSample struct {
+	Time time.Time `json:"time"`
+	Size int       `json:"size"`
+}
+
+// statsSample is a SizeSample along with the counters at that time.
+type statsSample struct {
+	SizeSample
+	puts, gets, rejected int64
+}
+
+// sample records the size and counters if a second has passed since the
+// last sample. The common path is a single atomic load.
+func (m *meteredBox[T]) sample() {
+	now := m.clock.Now()
+	if now.UnixNano() < atomic.LoadInt64(&m.nextSample) {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if now.UnixNano() < atomic.LoadInt64(&m.nextSample) {
+		return
+	}
+	s := statsSample{
+		SizeSample: SizeSample{Time: now, Size: int(atomic.LoadInt64(&m.size))},
+		puts:       atomic.LoadInt64(&m.puts),
+		gets:       atomic.LoadInt64(&m.gets),
+		rejected:   atomic.LoadInt64(&m.rejected),
+	}
+	if len(m.history) < statsHistory {
+		m.history = append(m.history, s)
+	} else {
+		m.history[m.head] = s
+		m.head = (m.head + 1) % statsHistory
+	}
+	atomic.StoreInt64(&m.nextSample, now.Add(statsInterval).UnixNano())
+}
+
+// Stats returns the counters along with the rates and size history. It is
+// safe to call concurrently with the other methods.
+func (m *meteredBox[T]) Stats() Stats {
+	stats := Stats{Metrics: m.Metrics()}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.history) == 0 {
+		return stats
+	}
+	stats.SizeHistory = make([]SizeSample, len(m.history))
+	for i := range m.history {
+		stats.SizeHistory[i] = m.history[(m.head+i)%len(m.history)].SizeSample
+	}
+	oldest := m.history[m.head]
+	if elapsed := m.clock.Now().Sub(oldest.Time).Seconds(); elapsed > 0 {
+		stats.PutRate = float64(stats.Puts-oldest.puts) / elapsed
+		stats.GetRate = float64(stats.Gets-oldest.gets) / elapsed
+		stats.RejectRate = float64(stats.Rejected-oldest.rejected) / elapsed
+	}
+	return stats
+}
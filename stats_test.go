@@ -0,0 +1,52 @@
+package blackbox
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMeteredStats(t *testing.T) {
+	m := NewMetered[int](NewFIFO[int](5, 0))
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	m.clock = clock
+
+	if stats := m.Stats(); stats.SizeHistory != nil || stats.PutRate != 0 {
+		t.Errorf("Expected no history yet, got %+v", stats)
+	}
+	// 2 puts and 1 get per second for 10 seconds; the box fills up after 5.
+	for i := 0; i < 10; i++ {
+		m.Put(i)
+		m.Put(i)
+		m.Get()
+		clock.Advance(time.Second)
+	}
+	stats := m.Stats()
+	if len(stats.SizeHistory) != 10 {
+		t.Fatalf("Expected 10 samples, got %d", len(stats.SizeHistory))
+	}
+	if first := stats.SizeHistory[0]; first.Size != 1 || !first.Time.Equal(time.Unix(0, 0)) {
+		t.Errorf("Expected the first sample after the first Put, got %+v", first)
+	}
+	if last := stats.SizeHistory[9]; last.Size != 5 {
+		t.Errorf("Expected a full box, got %+v", last)
+	}
+	// The first Put predates the oldest sample.
+	if !almostEqual(stats.GetRate, 1) || !almostEqual(stats.PutRate+stats.RejectRate, 1.9) {
+		t.Errorf("Expected about 1 get and 2 puts per second, got %v, %v and %v", stats.GetRate, stats.PutRate, stats.RejectRate)
+	}
+	if stats.Puts+stats.Rejected != 20 || stats.Gets != 10 {
+		t.Errorf("Expected the counters, got %+v", stats.Metrics)
+	}
+
+	for i := 0; i < statsHistory; i++ {
+		m.Clean()
+		clock.Advance(time.Second)
+	}
+	stats = m.Stats()
+	if len(stats.SizeHistory) != statsHistory {
+		t.Errorf("Expected %d samples, got %d", statsHistory, len(stats.SizeHistory))
+	}
+	if !stats.SizeHistory[0].Time.Before(stats.SizeHistory[statsHistory-1].Time) {
+		t.Error("Expected samples oldest first")
+	}
+}
@@ -0,0 +1,128 @@
+package blackbox
+
+import "time"
+
+// suppressBox drops items whose key was put within a time window.
+type suppressBox[T any] struct {
+	box    BlackBox[T]
+	key    func(T) string
+	window time.Duration
+	clock  Clock
+	// seen holds when each key was last accepted within the window.
+	seen map[string]time.Time
+	// pruneAt is when the keys older than the window are next forgotten.
+	pruneAt    time.Time
+	suppressed int
+}
+
+// NewSuppress wraps box so that Put silently drops an item, returning nil,
+// when an item with the same key, as returned by keyFn, was accepted less
+// than window ago, e.g. to absorb repeated webhook deliveries of the same
+// event. Unlike NewDedup, the key is released after window whether or not the
+// item is still in the box; unlike NewDebounce, the first item is enqueued
+// right away and the repeats are dropped. Only WithClock is used from opts.
+// Returns a concrete instance of suppressing blackbox.
+func NewSuppress[T any](box BlackBox[T], keyFn func(T) string, window time.Duration, opts ...Option) *suppressBox[T] {
+	return &suppressBox[T]{
+		box:    box,
+		key:    keyFn,
+		window: window,
+		clock:  parseOptions(opts).now(),
+		seen:   make(map[string]time.Time),
+	}
+}
+
+// WithSuppressWindow drops items whose key, as returned by keyFn, was put
+// less than window ago, see NewSuppress.
+func WithSuppressWindow[T any](keyFn func(T) string, window time.Duration) Option {
+	return func(c *config) {
+		c.decorators = append(c.decorators, func(box BlackBox[T]) BlackBox[T] {
+			return NewSuppress(box, keyFn, window, WithClock(c.now()))
+		})
+	}
+}
+
+// Suppressed returns the number of items dropped as duplicates.
+func (s *suppressBox[T]) Suppressed() int {
+	return s.suppressed
+}
+
+// prune forgets the keys accepted more than a window ago, at most once per window.
+func (s *suppressBox[T]) prune(now time.Time) {
+	if now.Before(s.pruneAt) {
+		return
+	}
+	for k, t := range s.seen {
+		if now.Sub(t) >= s.window {
+			delete(s.seen, k)
+		}
+	}
+	s.pruneAt = now.Add(s.window)
+}
+
+func (s *suppressBox[T]) Put(item T) error {
+	now := s.clock.Now()
+	s.prune(now)
+	k := s.key(item)
+	if t, ok := s.seen[k]; ok && now.Sub(t) < s.window {
+		s.suppressed++
+		return nil
+	}
+	if err := s.box.Put(item); err != nil {
+		return err
+	}
+	s.seen[k] = now
+	return nil
+}
+
+func (s *suppressBox[T]) Get() (T, error) {
+	return s.box.Get()
+}
+
+func (s *suppressBox[T]) Peek() (T, error) {
+	return s.box.Peek()
+}
+
+func (s *suppressBox[T]) Size() int {
+	return s.box.Size()
+}
+
+func (s *suppressBox[T]) MaxSize() int {
+	return s.box.MaxSize()
+}
+
+func (s *suppressBox[T]) IsFull() bool {
+	return s.box.IsFull()
+}
+
+func (s *suppressBox[T]) IsEmpty() bool {
+	return s.box.IsEmpty()
+}
+
+// Clean removes the items but keeps the window of the keys put recently.
+func (s *suppressBox[T]) Clean() {
+	s.box.Clean()
+}
+
+func (s *suppressBox[T]) Items() []T {
+	return s.box.Items()
+}
+
+func (s *suppressBox[T]) forEach(fn func(item T) bool) {
+	eachItem(s.box, fn)
+}
+
+func (s *suppressBox[T]) mutatesOnRead() bool {
+	return mutatesOnRead(s.box)
+}
+
+func (s *suppressBox[T]) mutatesOnPeek() bool {
+	return mutatesOnPeek(s.box)
+}
+
+func (s *suppressBox[T]) drawPlan() drawPlan[T] {
+	return planOf(s.box)
+}
+
+// Compile-time assertion that suppressBox implements BlackBox[T].
+var _ BlackBox[any] = (*suppressBox[any])(nil)
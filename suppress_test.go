@@ -0,0 +1,59 @@
+package blackbox
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+type delivery struct {
+	id   string
+	body string
+}
+
+func TestSuppressWindow(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	box := NewSuppress[delivery](NewFIFO[delivery](0, 0), func(d delivery) string { return d.id }, time.Minute)
+	box.clock = clock
+
+	box.Put(delivery{"evt-1", "first"})
+	clock.Advance(30 * time.Second)
+	if err := box.Put(delivery{"evt-1", "retry"}); err != nil {
+		t.Errorf("Expected the duplicate to be dropped silently, got %v", err)
+	}
+	box.Put(delivery{"evt-2", "other"})
+	if box.Size() != 2 || box.Suppressed() != 1 {
+		t.Errorf("Expected 2 items and 1 suppressed, got %d and %d", box.Size(), box.Suppressed())
+	}
+	if d, _ := box.Get(); d.body != "first" {
+		t.Errorf("Expected the first delivery to be kept, got %s", d.body)
+	}
+
+	// The window starts at the accepted delivery, not at the retries.
+	clock.Advance(30 * time.Second)
+	box.Put(delivery{"evt-1", "late"})
+	if box.Size() != 2 {
+		t.Errorf("Expected evt-1 to be accepted after the window, got size %d", box.Size())
+	}
+	clock.Advance(2 * time.Minute)
+	box.Put(delivery{"evt-3", "prune"})
+	if len(box.seen) != 1 {
+		t.Errorf("Expected keys older than the window to be forgotten, got %v", box.seen)
+	}
+}
+
+func TestSuppressRejectedPut(t *testing.T) {
+	box := New[int](WithMaxSize(1), WithStrategy(StrategyFIFO),
+		WithSuppressWindow(func(i int) string { return strconv.Itoa(i % 10) }, time.Hour))
+	box.Put(1)
+	if err := box.Put(11); err != nil {
+		t.Errorf("Expected 11 to be suppressed, got %v", err)
+	}
+	if err := box.Put(2); err == nil {
+		t.Error("Expected the full box to reject 2")
+	}
+	box.Get()
+	if err := box.Put(12); err != nil || box.Size() != 1 {
+		t.Errorf("Expected a rejected item not to open a window, got %v", err)
+	}
+}
@@ -0,0 +1,143 @@
+package blackbox
+
+import (
+	"errors"
+	"fmt"
+)
+
+// TeeError reports which boxes of a tee rejected an item.
+type TeeError struct {
+	// Errs holds the error of each box in the order passed to NewTee, nil
+	// for the boxes that accepted the item.
+	Errs []error
+}
+
+func (e *TeeError) Error() string {
+	failed, first := 0, -1
+	for i, err := range e.Errs {
+		if err != nil {
+			failed++
+			if first < 0 {
+				first = i
+			}
+		}
+	}
+	return fmt.Sprintf("blackbox: %d of %d tee targets rejected the item, box %d: %v", failed, len(e.Errs), first, e.Errs[first])
+}
+
+// Unwrap returns the error of the first box that rejected the item. Use Is
+// and As, or Errs, to look at the errors of the other boxes.
+func (e *TeeError) Unwrap() error {
+	for _, err := range e.Errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Is reports whether the error of any box matches target.
+func (e *TeeError) Is(target error) bool {
+	for _, err := range e.Errs {
+		if err != nil && errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first error of a box that matches target, like errors.As.
+func (e *TeeError) As(target any) bool {
+	for _, err := range e.Errs {
+		if err != nil && errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// teeBox writes every item into several boxes.
+type teeBox[T any] struct {
+	boxes []BlackBox[T]
+}
+
+// NewTee returns a box whose Put writes the item into every box, e.g. to feed
+// both a processing queue and an audit buffer from one producer. All other
+// methods read from the first box, which is treated as the primary; Clean
+// empties every box. It panics if no box is given.
+//
+// Put tries every box even if some fail and returns a *TeeError with the
+// error of each box when any of them rejects the item.
+// Returns a concrete instance of tee blackbox.
+func NewTee[T any](boxes ...BlackBox[T]) *teeBox[T] {
+	if len(boxes) == 0 {
+		panic("blackbox: NewTee needs at least one box")
+	}
+	return &teeBox[T]{boxes: boxes}
+}
+
+func (t *teeBox[T]) Put(item T) error {
+	var errs []error
+	for i, box := range t.boxes {
+		if err := box.Put(item); err != nil {
+			if errs == nil {
+				errs = make([]error, len(t.boxes))
+			}
+			errs[i] = err
+		}
+	}
+	if errs != nil {
+		return &TeeError{Errs: errs}
+	}
+	return nil
+}
+
+func (t *teeBox[T]) Get() (T, error) {
+	return t.boxes[0].Get()
+}
+
+func (t *teeBox[T]) Peek() (T, error) {
+	return t.boxes[0].Peek()
+}
+
+func (t *teeBox[T]) Size() int {
+	return t.boxes[0].Size()
+}
+
+func (t *teeBox[T]) MaxSize() int {
+	return t.boxes[0].MaxSize()
+}
+
+func (t *teeBox[T]) IsFull() bool {
+	return t.boxes[0].IsFull()
+}
+
+func (t *teeBox[T]) IsEmpty() bool {
+	return t.boxes[0].IsEmpty()
+}
+
+// Clean removes all items from every box.
+func (t *teeBox[T]) Clean() {
+	for _, box := range t.boxes {
+		box.Clean()
+	}
+}
+
+func (t *teeBox[T]) Items() []T {
+	return t.boxes[0].Items()
+}
+
+func (t *teeBox[T]) mutatesOnRead() bool {
+	return mutatesOnRead(t.boxes[0])
+}
+
+func (t *teeBox[T]) mutatesOnPeek() bool {
+	return mutatesOnPeek(t.boxes[0])
+}
+
+func (t *teeBox[T]) drawPlan() drawPlan[T] {
+	return planOf(t.boxes[0])
+}
+
+// Compile-time assertion that teeBox implements BlackBox[T].
+var _ BlackBox[any] = (*teeBox[any])(nil)
@@ -0,0 +1,65 @@
+package blackbox
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTee(t *testing.T) {
+	queue := NewFIFO[int](0, 4)
+	audit := NewFIFO[int](2, 2)
+	box := NewTee[int](queue, audit)
+
+	for i := 1; i <= 2; i++ {
+		if err := box.Put(i); err != nil {
+			t.Fatalf("Failed to put item: %v", err)
+		}
+	}
+	err := box.Put(3)
+	te, ok := err.(*TeeError)
+	if !ok {
+		t.Fatalf("Expected *TeeError, got %v", err)
+	}
+	if len(te.Errs) != 2 || te.Errs[0] != nil || te.Errs[1] != ErrBlackBoxFull {
+		t.Errorf("Expected only the audit box to fail, got %v", te.Errs)
+	}
+	if te.Unwrap() != ErrBlackBoxFull || !errors.Is(err, ErrBlackBoxFull) {
+		t.Errorf("Expected the error to wrap ErrBlackBoxFull, got %v", te.Unwrap())
+	}
+
+	if !EqualInts(queue.Items(), []int{1, 2, 3}) || !EqualInts(audit.Items(), []int{1, 2}) {
+		t.Errorf("Expected [1 2 3] and [1 2], got %v and %v", queue.Items(), audit.Items())
+	}
+	if item, _ := box.Get(); item != 1 || box.Size() != 2 || audit.Size() != 2 {
+		t.Errorf("Expected Get to consume the primary only, got %d with sizes %d and %d", item, box.Size(), audit.Size())
+	}
+
+	box.Clean()
+	if !queue.IsEmpty() || !audit.IsEmpty() {
+		t.Error("Expected Clean to empty every box")
+	}
+}
+
+func TestTeeErrorMatchesEveryBox(t *testing.T) {
+	errOdd := errors.New("odd")
+	strict := NewValidator[int](NewFIFO[int](0, 4), func(item int) error {
+		if item%2 != 0 {
+			return errOdd
+		}
+		return nil
+	})
+	box := NewTee[int](strict, NewFIFO[int](0, 4), NewFIFO[int](1, 1))
+	box.Put(0)
+
+	err := box.Put(1)
+	if !errors.Is(err, ErrInvalidItem) || !errors.Is(err, errOdd) || !errors.Is(err, ErrBlackBoxFull) {
+		t.Errorf("Expected the error to match the errors of both failing boxes, got %v", err)
+	}
+	if errors.Is(err, ErrEmptyBlackBox) {
+		t.Errorf("Expected the error not to match ErrEmptyBlackBox, got %v", err)
+	}
+	var invalid *InvalidItemError
+	if !errors.As(err, &invalid) || invalid.Err != errOdd {
+		t.Errorf("Expected errors.As to find the *InvalidItemError, got %v", err)
+	}
+}
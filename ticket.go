@@ -0,0 +1,144 @@
+package blackbox
+
+// Ticket identifies an item put with PutTicket.
+type Ticket uint64
+
+// Ticketer is implemented by blackboxes created with WithTickets.
+type Ticketer[T any] interface {
+	BlackBox[T]
+	// PutTicket inserts an item like Put and returns the ticket of the item.
+	PutTicket(item T) (Ticket, error)
+	// Cancel removes the item of ticket and reports whether it was still in
+	// the box. The item is not passed to the evict callback.
+	Cancel(ticket Ticket) bool
+	// Inspect returns the item of ticket and whether it is still in the box.
+	Inspect(ticket Ticket) (T, bool)
+}
+
+// ticketItem is an item stored along with its ticket.
+type ticketItem[T any] struct {
+	value  T
+	ticket Ticket
+}
+
+// ticketBox stores ticketed items in a box of the configured strategy. Items
+// put with Put get a ticket too, it is just not returned.
+type ticketBox[T any] struct {
+	box  BlackBox[ticketItem[T]]
+	last Ticket
+}
+
+// newTicketBox creates a ticket box of the configured strategy, holding data when fromData is set.
+func newTicketBox[T any](cfg config, data []T, fromData bool) *ticketBox[T] {
+	t := &ticketBox[T]{}
+
+	innerCfg := cfg
+	innerCfg.lockFree = false
+	if onEvict, ok := cfg.onEvict.(func(item T)); ok {
+		innerCfg.onEvict = func(it ticketItem[T]) {
+			onEvict(it.value)
+		}
+	}
+
+	items := make([]ticketItem[T], len(data))
+	for i, value := range data {
+		items[i] = t.issue(value)
+	}
+	t.box = buildTimed(innerCfg, items, fromData)
+	return t
+}
+
+// issue wraps value with a new ticket.
+func (t *ticketBox[T]) issue(value T) ticketItem[T] {
+	t.last++
+	return ticketItem[T]{value: value, ticket: t.last}
+}
+
+func (t *ticketBox[T]) PutTicket(item T) (Ticket, error) {
+	it := t.issue(item)
+	if err := t.box.Put(it); err != nil {
+		return 0, err
+	}
+	return it.ticket, nil
+}
+
+func (t *ticketBox[T]) Cancel(ticket Ticket) bool {
+	return t.box.(Remover[ticketItem[T]]).RemoveFunc(func(it ticketItem[T]) bool {
+		return it.ticket == ticket
+	}) > 0
+}
+
+func (t *ticketBox[T]) Inspect(ticket Ticket) (T, bool) {
+	var (
+		item  T
+		found bool
+	)
+	eachItem(t.box, func(it ticketItem[T]) bool {
+		if it.ticket == ticket {
+			item, found = it.value, true
+		}
+		return !found
+	})
+	return item, found
+}
+
+func (t *ticketBox[T]) Put(item T) error {
+	_, err := t.PutTicket(item)
+	return err
+}
+
+func (t *ticketBox[T]) Get() (T, error) {
+	it, err := t.box.Get()
+	return it.value, err
+}
+
+func (t *ticketBox[T]) Peek() (T, error) {
+	it, err := t.box.Peek()
+	return it.value, err
+}
+
+func (t *ticketBox[T]) Size() int {
+	return t.box.Size()
+}
+
+func (t *ticketBox[T]) MaxSize() int {
+	return t.box.MaxSize()
+}
+
+func (t *ticketBox[T]) IsFull() bool {
+	return t.box.IsFull()
+}
+
+func (t *ticketBox[T]) IsEmpty() bool {
+	return t.box.IsEmpty()
+}
+
+func (t *ticketBox[T]) Clean() {
+	t.box.Clean()
+}
+
+func (t *ticketBox[T]) Items() []T {
+	items := t.box.Items()
+	result := make([]T, len(items))
+	for i, it := range items {
+		result[i] = it.value
+	}
+	return result
+}
+
+func (t *ticketBox[T]) forEach(fn func(item T) bool) {
+	eachItem(t.box, func(it ticketItem[T]) bool {
+		return fn(it.value)
+	})
+}
+
+func (t *ticketBox[T]) mutatesOnRead() bool {
+	return mutatesOnRead(t.box)
+}
+
+func (t *ticketBox[T]) mutatesOnPeek() bool {
+	return mutatesOnPeek(t.box)
+}
+
+// Compile-time assertion that ticketBox implements Ticketer[T].
+var _ Ticketer[any] = (*ticketBox[any])(nil)
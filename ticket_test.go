@@ -0,0 +1,61 @@
+package blackbox
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTickets(t *testing.T) {
+	for _, strategy := range []Strategy{StrategyFIFO, StrategyLIFO, StrategyRandom} {
+		box := New[string](WithStrategy(strategy), WithTickets(), WithLockFree()).(Ticketer[string])
+		box.Put("alice")
+		first, _ := box.PutTicket("bob")
+		second, err := box.PutTicket("bob")
+		if err != nil || first == second {
+			t.Fatalf("%v: Expected distinct tickets, got %d and %d (%v)", strategy, first, second, err)
+		}
+
+		if item, ok := box.Inspect(second); !ok || item != "bob" {
+			t.Errorf("%v: Expected to inspect bob, got %q (%v)", strategy, item, ok)
+		}
+		if !box.Cancel(first) {
+			t.Errorf("%v: Expected first ticket to be cancelled", strategy)
+		}
+		if box.Cancel(first) {
+			t.Errorf("%v: Expected a cancelled ticket not to be found again", strategy)
+		}
+		if _, ok := box.Inspect(first); ok {
+			t.Errorf("%v: Expected a cancelled ticket not to be inspected", strategy)
+		}
+		if box.Size() != 2 || !Contains[string](box, "bob", func(a, b string) bool { return a == b }) {
+			t.Errorf("%v: Expected alice and the second bob to remain, got %v", strategy, box.Items())
+		}
+	}
+}
+
+func TestTicketsFull(t *testing.T) {
+	var evicted []int
+	box := New[int](WithStrategy(StrategyFIFO), WithTickets(), WithMaxSize(1),
+		WithEvictCallback(func(item int) { evicted = append(evicted, item) })).(Ticketer[int])
+	if _, err := box.PutTicket(1); err != nil {
+		t.Fatal(err)
+	}
+	if ticket, err := box.PutTicket(2); err == nil || ticket != 0 {
+		t.Errorf("Expected no ticket for a full box, got %d (%v)", ticket, err)
+	}
+	box.Clean()
+	if !EqualInts(evicted, []int{1}) {
+		t.Errorf("Expected 1 to be evicted, got %v", evicted)
+	}
+}
+
+func TestTicketsWithTTL(t *testing.T) {
+	box := New[int](WithStrategy(StrategyFIFO), WithTickets(), WithTTL(time.Hour)).(*ticketBox[int])
+	clock := &fakeClock{now: time.Now()}
+	box.box.(*timedBox[ticketItem[int]]).clock = clock
+	ticket, _ := box.PutTicket(1)
+	clock.Advance(2 * time.Hour)
+	if _, ok := box.Inspect(ticket); ok {
+		t.Error("Expected an expired item not to be inspected")
+	}
+}
@@ -0,0 +1,187 @@
+package blackbox
+
+import "time"
+
+// Expirable is implemented by blackboxes created with WithTTL.
+type Expirable[T any] interface {
+	BlackBox[T]
+	// PutWithTTL inserts an item that expires ttl after now, overriding the
+	// default TTL of the box. A ttl of 0 means the item never expires.
+	PutWithTTL(item T, ttl time.Duration) error
+}
+
+// timedItem is an item stored along with its timestamps.
+type timedItem[T any] struct {
+	value    T
+	putAt    time.Time
+	expireAt time.Time // zero means never
+}
+
+func (it timedItem[T]) expired(now time.Time) bool {
+	return !it.expireAt.IsZero() && !now.Before(it.expireAt)
+}
+
+// itemRemover is implemented by the concrete boxes of this package.
+type itemRemover[T any] interface {
+	removeFunc(pred func(item T) bool) []T
+}
+
+// timedBox stores timestamped items in a box of the configured strategy and
+// drops expired items lazily whenever it is accessed.
+type timedBox[T any] struct {
+	box BlackBox[timedItem[T]]
+	ttl time.Duration
+	// retention bounds the age of every item, whatever its TTL, see WithRetention.
+	retention time.Duration
+	clock     Clock
+	onEvict   func(item T)
+	// nextExpiry is the earliest expiry among the stored items, zero if none
+	// expires. Nothing needs to be purged before that time.
+	nextExpiry time.Time
+}
+
+// newTimedBox creates a timed box of the configured strategy, holding data when fromData is set.
+func newTimedBox[T any](cfg config, data []T, fromData bool) *timedBox[T] {
+	t := &timedBox[T]{
+		ttl:       cfg.ttl,
+		retention: cfg.retention,
+		clock:     cfg.now(),
+	}
+	t.onEvict, _ = cfg.onEvict.(func(item T))
+
+	// Expired items are removed from the middle of the box, which the
+	// lock-free boxes cannot do.
+	innerCfg := cfg
+	innerCfg.lockFree = false
+	if t.onEvict != nil {
+		innerCfg.onEvict = func(it timedItem[T]) {
+			t.onEvict(it.value)
+		}
+	}
+
+	now := t.clock.Now()
+	items := make([]timedItem[T], len(data))
+	for i, value := range data {
+		items[i] = t.stamp(value, t.ttl, now)
+	}
+	t.box = build(innerCfg, items, fromData)
+	return t
+}
+
+// stamp wraps value with its timestamps and keeps track of the next expiry.
+func (t *timedBox[T]) stamp(value T, ttl time.Duration, now time.Time) timedItem[T] {
+	it := timedItem[T]{value: value, putAt: now}
+	if t.retention > 0 && (ttl <= 0 || ttl > t.retention) {
+		ttl = t.retention
+	}
+	if ttl > 0 {
+		it.expireAt = now.Add(ttl)
+		if t.nextExpiry.IsZero() || it.expireAt.Before(t.nextExpiry) {
+			t.nextExpiry = it.expireAt
+		}
+	}
+	return it
+}
+
+// purge removes the expired items once the next expiry has passed.
+func (t *timedBox[T]) purge() {
+	if t.nextExpiry.IsZero() {
+		return
+	}
+	now := t.clock.Now()
+	if now.Before(t.nextExpiry) {
+		return
+	}
+	r, ok := t.box.(itemRemover[timedItem[T]])
+	if !ok {
+		return
+	}
+	var next time.Time
+	expired := r.removeFunc(func(it timedItem[T]) bool {
+		if it.expired(now) {
+			return true
+		}
+		if !it.expireAt.IsZero() && (next.IsZero() || it.expireAt.Before(next)) {
+			next = it.expireAt
+		}
+		return false
+	})
+	t.nextExpiry = next
+	if t.onEvict != nil {
+		for _, it := range expired {
+			t.onEvict(it.value)
+		}
+	}
+}
+
+// PutWithTTL inserts an item that expires ttl after now. A ttl of 0 means the item never expires.
+func (t *timedBox[T]) PutWithTTL(item T, ttl time.Duration) error {
+	t.purge()
+	next := t.nextExpiry
+	err := t.box.Put(t.stamp(item, ttl, t.clock.Now()))
+	if err != nil {
+		t.nextExpiry = next
+	}
+	return err
+}
+
+func (t *timedBox[T]) Put(item T) error {
+	return t.PutWithTTL(item, t.ttl)
+}
+
+func (t *timedBox[T]) Get() (T, error) {
+	t.purge()
+	it, err := t.box.Get()
+	return it.value, err
+}
+
+func (t *timedBox[T]) Peek() (T, error) {
+	t.purge()
+	it, err := t.box.Peek()
+	return it.value, err
+}
+
+func (t *timedBox[T]) Size() int {
+	t.purge()
+	return t.box.Size()
+}
+
+func (t *timedBox[T]) MaxSize() int {
+	return t.box.MaxSize()
+}
+
+func (t *timedBox[T]) IsFull() bool {
+	t.purge()
+	return t.box.IsFull()
+}
+
+func (t *timedBox[T]) IsEmpty() bool {
+	t.purge()
+	return t.box.IsEmpty()
+}
+
+func (t *timedBox[T]) Clean() {
+	t.box.Clean()
+	t.nextExpiry = time.Time{}
+}
+
+func (t *timedBox[T]) Items() []T {
+	t.purge()
+	return values(t.box.Items())
+}
+
+// values extracts the values of timed items.
+func values[T any](items []timedItem[T]) []T {
+	result := make([]T, len(items))
+	for i, it := range items {
+		result[i] = it.value
+	}
+	return result
+}
+
+func (t *timedBox[T]) mutatesOnRead() bool {
+	return true
+}
+
+// Compile-time assertion that timedBox implements Expirable[T].
+var _ Expirable[any] = (*timedBox[any])(nil)
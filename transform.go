@@ -0,0 +1,82 @@
+package blackbox
+
+// transformBox rewrites items before they reach any BlackBox[T].
+type transformBox[T any] struct {
+	box       BlackBox[T]
+	transform func(item T) T
+}
+
+// NewTransform wraps box so that Put inserts transform(item) instead of item,
+// e.g. to trim strings, assign IDs or stamp the insertion time in one place
+// rather than at every call site.
+// Returns a concrete instance of transforming blackbox.
+func NewTransform[T any](box BlackBox[T], transform func(item T) T) *transformBox[T] {
+	return &transformBox[T]{box: box, transform: transform}
+}
+
+// WithPutTransform inserts fn(item) instead of item, see NewTransform.
+// Each option wraps the box built by the previous ones, so Put runs the last
+// option first: pass WithPutValidator before WithPutTransform to validate the
+// transformed items.
+func WithPutTransform[T any](fn func(item T) T) Option {
+	return func(c *config) {
+		c.decorators = append(c.decorators, func(box BlackBox[T]) BlackBox[T] {
+			return NewTransform(box, fn)
+		})
+	}
+}
+
+func (x *transformBox[T]) Put(item T) error {
+	return x.box.Put(x.transform(item))
+}
+
+func (x *transformBox[T]) Get() (T, error) {
+	return x.box.Get()
+}
+
+func (x *transformBox[T]) Peek() (T, error) {
+	return x.box.Peek()
+}
+
+func (x *transformBox[T]) Size() int {
+	return x.box.Size()
+}
+
+func (x *transformBox[T]) MaxSize() int {
+	return x.box.MaxSize()
+}
+
+func (x *transformBox[T]) IsFull() bool {
+	return x.box.IsFull()
+}
+
+func (x *transformBox[T]) IsEmpty() bool {
+	return x.box.IsEmpty()
+}
+
+func (x *transformBox[T]) Clean() {
+	x.box.Clean()
+}
+
+func (x *transformBox[T]) Items() []T {
+	return x.box.Items()
+}
+
+func (x *transformBox[T]) forEach(fn func(item T) bool) {
+	eachItem(x.box, fn)
+}
+
+func (x *transformBox[T]) mutatesOnRead() bool {
+	return mutatesOnRead(x.box)
+}
+
+func (x *transformBox[T]) mutatesOnPeek() bool {
+	return mutatesOnPeek(x.box)
+}
+
+func (x *transformBox[T]) drawPlan() drawPlan[T] {
+	return planOf(x.box)
+}
+
+// Compile-time assertion that transformBox implements BlackBox[T].
+var _ BlackBox[any] = (*transformBox[any])(nil)
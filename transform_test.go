@@ -0,0 +1,35 @@
+package blackbox
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestPutTransform(t *testing.T) {
+	box := New[string](WithStrategy(StrategyFIFO), WithPutTransform(strings.TrimSpace))
+	box.Put("  alice \n")
+	if item, _ := box.Get(); item != "alice" {
+		t.Errorf("Expected the trimmed item, got %q", item)
+	}
+}
+
+func TestPutTransformOrder(t *testing.T) {
+	errEmpty := errors.New("empty name")
+	notEmpty := WithPutValidator(func(name string) error {
+		if name == "" {
+			return errEmpty
+		}
+		return nil
+	})
+
+	box := New[string](notEmpty, WithPutTransform(strings.TrimSpace))
+	if err := box.Put("   "); !errors.Is(err, errEmpty) {
+		t.Errorf("Expected the transformed item to be validated, got %v", err)
+	}
+
+	box = New[string](WithPutTransform(strings.TrimSpace), notEmpty)
+	if err := box.Put("   "); err != nil {
+		t.Errorf("Expected the raw item to be validated, got %v", err)
+	}
+}
@@ -0,0 +1,72 @@
+package blackbox
+
+// UndoManager keeps the history of an undo/redo feature in two LIFO boxes.
+// It only records actions: the caller applies and reverts them. It is not
+// goroutine-safe.
+type UndoManager[T any] struct {
+	undo *lifoBox[T]
+	redo *lifoBox[T]
+}
+
+// NewUndoManager creates an undo manager remembering up to limit actions;
+// once the limit is reached, Do forgets the oldest action. Zero means
+// unlimited.
+func NewUndoManager[T any](limit int) *UndoManager[T] {
+	return &UndoManager[T]{undo: NewLIFODropOldest[T](limit, 0), redo: NewLIFO[T](0, 0)}
+}
+
+// Do records an action that was just applied. A new action invalidates the
+// actions that were undone, so the redo history is cleared.
+func (m *UndoManager[T]) Do(action T) {
+	m.undo.Put(action)
+	m.redo.Clean()
+}
+
+// Undo returns the last applied action for the caller to revert and moves it
+// to the redo history. It returns ErrEmptyBlackBox if there is nothing to undo.
+func (m *UndoManager[T]) Undo() (T, error) {
+	action, err := m.undo.Get()
+	if err != nil {
+		return action, err
+	}
+	m.redo.Put(action)
+	return action, nil
+}
+
+// Redo returns the last undone action for the caller to apply again and moves
+// it back to the undo history. It returns ErrEmptyBlackBox if there is nothing
+// to redo.
+func (m *UndoManager[T]) Redo() (T, error) {
+	action, err := m.redo.Get()
+	if err != nil {
+		return action, err
+	}
+	m.undo.Put(action)
+	return action, nil
+}
+
+// CanUndo reports whether there is an action to undo.
+func (m *UndoManager[T]) CanUndo() bool {
+	return !m.undo.IsEmpty()
+}
+
+// CanRedo reports whether there is an action to redo.
+func (m *UndoManager[T]) CanRedo() bool {
+	return !m.redo.IsEmpty()
+}
+
+// UndoSize returns the number of actions that can be undone.
+func (m *UndoManager[T]) UndoSize() int {
+	return m.undo.Size()
+}
+
+// RedoSize returns the number of actions that can be redone.
+func (m *UndoManager[T]) RedoSize() int {
+	return m.redo.Size()
+}
+
+// Clear forgets the whole history, e.g. after saving a document.
+func (m *UndoManager[T]) Clear() {
+	m.undo.Clean()
+	m.redo.Clean()
+}
@@ -0,0 +1,65 @@
+package blackbox
+
+import "testing"
+
+func TestUndoManager(t *testing.T) {
+	m := NewUndoManager[int](0)
+	if m.CanUndo() || m.CanRedo() {
+		t.Error("Expected an empty history")
+	}
+	if _, err := m.Undo(); err != ErrEmptyBlackBox {
+		t.Errorf("Expected ErrEmptyBlackBox, got %v", err)
+	}
+	for i := 1; i <= 3; i++ {
+		m.Do(i)
+	}
+	if action, _ := m.Undo(); action != 3 {
+		t.Errorf("Expected to undo 3, got %d", action)
+	}
+	if action, _ := m.Undo(); action != 2 {
+		t.Errorf("Expected to undo 2, got %d", action)
+	}
+	if !m.CanRedo() || m.RedoSize() != 2 {
+		t.Errorf("Expected 2 actions to redo, got %d", m.RedoSize())
+	}
+	if action, _ := m.Redo(); action != 2 {
+		t.Errorf("Expected to redo 2, got %d", action)
+	}
+
+	m.Do(4)
+	if m.CanRedo() {
+		t.Error("Expected a new action to clear the redo history")
+	}
+	if _, err := m.Redo(); err != ErrEmptyBlackBox {
+		t.Errorf("Expected ErrEmptyBlackBox, got %v", err)
+	}
+	if m.UndoSize() != 3 {
+		t.Errorf("Expected 3 actions to undo, got %d", m.UndoSize())
+	}
+
+	m.Clear()
+	if m.CanUndo() || m.CanRedo() {
+		t.Error("Expected Clear to forget the history")
+	}
+}
+
+func TestUndoManagerLimit(t *testing.T) {
+	m := NewUndoManager[int](2)
+	for i := 1; i <= 4; i++ {
+		m.Do(i)
+	}
+	var undone []int
+	for m.CanUndo() {
+		action, _ := m.Undo()
+		undone = append(undone, action)
+	}
+	if !EqualInts(undone, []int{4, 3}) {
+		t.Errorf("Expected to undo [4 3], got %v", undone)
+	}
+	for m.CanRedo() {
+		m.Redo()
+	}
+	if m.UndoSize() != 2 {
+		t.Errorf("Expected 2 actions to undo after redoing, got %d", m.UndoSize())
+	}
+}
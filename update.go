@@ -0,0 +1,104 @@
+package blackbox
+
+// Updater is implemented by blackboxes whose items can be modified in place,
+// e.g. to bump the retry count of a queued task without removing and
+// re-adding it.
+type Updater[T any] interface {
+	// UpdateFunc calls fn with a pointer to every item, in the order of Items.
+	// fn returns true when it changed the item, and UpdateFunc returns the
+	// number of changed items.
+	UpdateFunc(fn func(item *T) bool) int
+}
+
+func (b *ring[T]) UpdateFunc(fn func(item *T) bool) int {
+	changed := 0
+	for i := 0; i < b.size; i++ {
+		if fn(&b.items[(b.head+i)%len(b.items)]) {
+			changed++
+		}
+	}
+	return changed
+}
+
+func (b *lifoBox[T]) UpdateFunc(fn func(item *T) bool) int {
+	return updateSlice(b.items, fn)
+}
+
+func (b *randomBox[T]) UpdateFunc(fn func(item *T) bool) int {
+	return updateSlice(b.items, fn)
+}
+
+func updateSlice[T any](items []T, fn func(item *T) bool) int {
+	changed := 0
+	for i := range items {
+		if fn(&items[i]) {
+			changed++
+		}
+	}
+	return changed
+}
+
+// UpdateFunc calls fn with a pointer to a copy of every item. Changed items
+// are moved to their new position, behind the items they now equal; changes
+// to items for which fn returns false are discarded.
+func (b *sortedBox[T]) UpdateFunc(fn func(item *T) bool) int {
+	var changed []T
+	b.removeFunc(func(item T) bool {
+		if fn(&item) {
+			changed = append(changed, item)
+			return true
+		}
+		return false
+	})
+	for _, item := range changed {
+		b.insert(item)
+	}
+	return len(changed)
+}
+
+// UpdateFunc calls fn with a pointer to every unexpired item. Expiry is not affected.
+func (t *timedBox[T]) UpdateFunc(fn func(item *T) bool) int {
+	t.purge()
+	u, ok := t.box.(Updater[timedItem[T]])
+	if !ok {
+		return 0
+	}
+	return u.UpdateFunc(func(it *timedItem[T]) bool {
+		return fn(&it.value)
+	})
+}
+
+// UpdateFunc calls fn with a pointer to every item with the lock held, so fn
+// must not call back into the box. The wrapped box must implement Updater[T],
+// otherwise nothing is updated.
+func (c *concurrentBox[T]) UpdateFunc(fn func(item *T) bool) int {
+	u, ok := c.box.(Updater[T])
+	if !ok {
+		return 0
+	}
+	c.mu.Lock()
+	changed := u.UpdateFunc(fn)
+	c.refresh()
+	c.mu.Unlock()
+	return changed
+}
+
+// UpdateFunc calls fn with a pointer to every item. Changed items are
+// recounted under their new category, which may then exceed the limit.
+// The wrapped box must implement Updater[T], otherwise nothing is updated.
+func (c *categoryBox[T, K]) UpdateFunc(fn func(item *T) bool) int {
+	u, ok := c.box.(Updater[T])
+	if !ok {
+		return 0
+	}
+	return u.UpdateFunc(func(item *T) bool {
+		before := *item
+		if !fn(item) {
+			return false
+		}
+		c.release(before)
+		c.counts[c.key(*item)]++
+		c.size++
+		return true
+	})
+}
@@ -0,0 +1,92 @@
+package blackbox
+
+import (
+	"testing"
+	"time"
+)
+
+type retryTask struct {
+	id      int
+	retries int
+}
+
+func TestUpdateFunc(t *testing.T) {
+	data := []retryTask{{id: 1}, {id: 2}, {id: 3}}
+	boxes := map[string]BlackBox[retryTask]{
+		"FIFO":       NewFIFOFrom[retryTask](data, 0),
+		"LIFO":       NewLIFOFrom[retryTask](data, 0),
+		"Random":     NewRandomFrom[retryTask](data, 0, nil),
+		"Deque":      NewDequeFrom[retryTask](data, 0),
+		"Timed":      NewFrom[retryTask](data, WithStrategy(StrategyFIFO), WithTTL(time.Hour)),
+		"Concurrent": NewConcurrent[retryTask](NewFIFOFrom[retryTask](data, 0)),
+	}
+	for name, box := range boxes {
+		n := box.(Updater[retryTask]).UpdateFunc(func(task *retryTask) bool {
+			if task.id != 2 {
+				return false
+			}
+			task.retries++
+			return true
+		})
+		if n != 1 {
+			t.Errorf("%s: Expected 1 changed item, got %d", name, n)
+		}
+		for _, task := range box.Items() {
+			want := 0
+			if task.id == 2 {
+				want = 1
+			}
+			if task.retries != want {
+				t.Errorf("%s: Expected task %d to have %d retries, got %d", name, task.id, want, task.retries)
+			}
+		}
+	}
+}
+
+func TestSortedUpdateFuncReorders(t *testing.T) {
+	box := NewSortedFrom[retryTask]([]retryTask{{id: 1}, {id: 2, retries: 1}, {id: 3, retries: 1}},
+		func(a, b retryTask) bool { return a.retries < b.retries }, 0)
+	box.UpdateFunc(func(task *retryTask) bool {
+		if task.id != 1 {
+			return false
+		}
+		task.retries = 1
+		return true
+	})
+	var ids []int
+	for _, task := range Drain[retryTask](box) {
+		ids = append(ids, task.id)
+	}
+	if !EqualInts(ids, []int{2, 3, 1}) {
+		t.Errorf("Expected the updated task behind equal tasks, got %v", ids)
+	}
+}
+
+func TestCategoryUpdateFuncRecounts(t *testing.T) {
+	byRetries := func(task retryTask) int { return task.retries }
+	box := NewCategoryLimit[retryTask, int](NewFIFO[retryTask](0, 4), byRetries, 0)
+	box.Put(retryTask{id: 1})
+	box.Put(retryTask{id: 2})
+	box.UpdateFunc(func(task *retryTask) bool {
+		task.retries++
+		return true
+	})
+	if box.CategorySize(0) != 0 || box.CategorySize(1) != 2 {
+		t.Errorf("Expected items to move category, got %d and %d", box.CategorySize(0), box.CategorySize(1))
+	}
+}
+
+func TestConcurrentUpdateFuncRefreshesSnapshot(t *testing.T) {
+	box := NewConcurrent[int](NewFIFO[int](0, 4))
+	box.Put(1)
+	box.Put(2)
+	box.Snapshot()
+
+	box.UpdateFunc(func(item *int) bool {
+		*item *= 10
+		return true
+	})
+	if items := box.Snapshot().Items(); !EqualInts(items, []int{10, 20}) {
+		t.Errorf("Expected a snapshot of [10 20] after the update, got %v", items)
+	}
+}
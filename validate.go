@@ -0,0 +1,65 @@
+package blackbox
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidOption = errors.New("blackbox option is invalid")
+
+// validate reports the first invalid or contradictory option of c.
+func (c config) validate() error {
+	if _, ok := strategyNames[c.strategy]; !ok {
+		return fmt.Errorf("%w: unknown strategy %v", ErrInvalidOption, c.strategy)
+	}
+	if c.maxSize < 0 {
+		return fmt.Errorf("%w: negative max size %d", ErrInvalidOption, c.maxSize)
+	}
+	if c.capacityArg < 0 {
+		return fmt.Errorf("%w: negative initial capacity %d", ErrInvalidOption, c.capacityArg)
+	}
+	if c.maxSize > 0 && c.capacityArg > c.maxSize {
+		return fmt.Errorf("%w: initial capacity %d exceeds max size %d", ErrInvalidOption, c.capacityArg, c.maxSize)
+	}
+	if c.strategy != StrategyRandom {
+		if c.useSeed || c.source != nil || c.userRand != nil {
+			return fmt.Errorf("%w: seed or RNG set for the %s strategy", ErrInvalidOption, c.strategy)
+		}
+		if c.ageBias != nil {
+			return fmt.Errorf("%w: age bias set for the %s strategy", ErrInvalidOption, c.strategy)
+		}
+	}
+	if c.strategy == StrategyRing && c.maxSize == 0 {
+		return fmt.Errorf("%w: ring strategy set without a max size", ErrInvalidOption)
+	}
+	if c.shedAt < 0 || c.shedAt > 1 {
+		return fmt.Errorf("%w: load shedding fraction %v outside [0, 1]", ErrInvalidOption, c.shedAt)
+	}
+	if c.useAdaptive {
+		if c.adaptiveMin < 1 || c.adaptiveMax < c.adaptiveMin {
+			return fmt.Errorf("%w: adaptive max size bounds [%d, %d] are invalid", ErrInvalidOption, c.adaptiveMin, c.adaptiveMax)
+		}
+		if c.useMaxSize || c.strategy == StrategyRing {
+			return fmt.Errorf("%w: adaptive max size set with a fixed max size", ErrInvalidOption)
+		}
+	}
+	if c.lockFree && c.useTTL {
+		return fmt.Errorf("%w: lock-free box set with a TTL or retention", ErrInvalidOption)
+	}
+	if c.shedAt > 0 && c.maxSize == 0 && !c.useAdaptive {
+		return fmt.Errorf("%w: load shedding set without a max size", ErrInvalidOption)
+	}
+	return nil
+}
+
+// NewE is like New, but returns an error wrapping ErrInvalidOption for
+// invalid or contradictory options that New silently ignores, such as a
+// negative max size, an initial capacity above the max size, or a seed for a
+// strategy other than StrategyRandom.
+func NewE[T any](opts ...Option) (BlackBox[T], error) {
+	cfg := parseOptions(opts)
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return assemble[T](cfg, nil, false), nil
+}
@@ -0,0 +1,39 @@
+package blackbox
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewE(t *testing.T) {
+	box, err := NewE[int](WithStrategy(StrategyFIFO), WithMaxSize(4), WithInitialCapacity(4))
+	if err != nil {
+		t.Fatalf("Expected valid options, got %v", err)
+	}
+	if box.MaxSize() != 4 {
+		t.Errorf("Expected MaxSize 4, got %d", box.MaxSize())
+	}
+	if _, err := NewE[int](WithSeed(1)); err != nil {
+		t.Errorf("Expected a seed to be valid for the Random strategy, got %v", err)
+	}
+
+	invalid := map[string][]Option{
+		"negative max size":        {WithMaxSize(-1)},
+		"negative capacity":        {WithInitialCapacity(-1)},
+		"capacity above max size":  {WithMaxSize(2), WithInitialCapacity(8)},
+		"seed on fifo":             {WithStrategy(StrategyFIFO), WithSeed(1)},
+		"rand on lifo":             {WithStrategy(StrategyLIFO), WithCryptoRand()},
+		"unknown strategy":         {WithStrategy(Strategy(42))},
+		"shedding without maxSize": {WithLoadShedding(0.5)},
+		"ring without maxSize":     {WithStrategy(StrategyRing)},
+		"lock-free with TTL":       {WithStrategy(StrategyLIFO), WithLockFree(), WithTTL(time.Minute)},
+		"MPMC with retention":      {WithStrategy(StrategyFIFO), WithLockFree(), WithMaxSize(4), WithRetention(time.Minute)},
+	}
+	for name, opts := range invalid {
+		box, err := NewE[int](opts...)
+		if !errors.Is(err, ErrInvalidOption) || box != nil {
+			t.Errorf("%s: Expected ErrInvalidOption, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package blackbox
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidItem matches the errors returned by Put on a box created with
+// WithPutValidator when the validator rejects an item.
+var ErrInvalidItem = errors.New("blackbox item is invalid")
+
+// InvalidItemError is returned by Put when the validator of the box rejects
+// an item. It matches both ErrInvalidItem and the validator error with
+// errors.Is:
+//
+//	if errors.Is(err, blackbox.ErrInvalidItem) {
+//		http.Error(w, err.Error(), http.StatusBadRequest)
+//	}
+type InvalidItemError struct {
+	Err error
+}
+
+func (e *InvalidItemError) Error() string {
+	return fmt.Sprintf("%v: %v", ErrInvalidItem, e.Err)
+}
+
+// Unwrap returns the validator error.
+func (e *InvalidItemError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrInvalidItem.
+func (e *InvalidItemError) Is(target error) bool {
+	return target == ErrInvalidItem
+}
+
+// validatorBox checks items before they reach any BlackBox[T].
+type validatorBox[T any] struct {
+	box      BlackBox[T]
+	validate func(item T) error
+}
+
+// NewValidator wraps box so that Put rejects the items for which validate
+// returns an error, so malformed items never reach the consumers. Put returns
+// an *InvalidItemError wrapping that error.
+// Returns a concrete instance of validating blackbox.
+func NewValidator[T any](box BlackBox[T], validate func(item T) error) *validatorBox[T] {
+	return &validatorBox[T]{box: box, validate: validate}
+}
+
+// WithPutValidator rejects the items for which fn returns an error, see NewValidator.
+func WithPutValidator[T any](fn func(item T) error) Option {
+	return func(c *config) {
+		c.decorators = append(c.decorators, func(box BlackBox[T]) BlackBox[T] {
+			return NewValidator(box, fn)
+		})
+	}
+}
+
+func (v *validatorBox[T]) Put(item T) error {
+	if err := v.validate(item); err != nil {
+		return &InvalidItemError{Err: err}
+	}
+	return v.box.Put(item)
+}
+
+func (v *validatorBox[T]) Get() (T, error) {
+	return v.box.Get()
+}
+
+func (v *validatorBox[T]) Peek() (T, error) {
+	return v.box.Peek()
+}
+
+func (v *validatorBox[T]) Size() int {
+	return v.box.Size()
+}
+
+func (v *validatorBox[T]) MaxSize() int {
+	return v.box.MaxSize()
+}
+
+func (v *validatorBox[T]) IsFull() bool {
+	return v.box.IsFull()
+}
+
+func (v *validatorBox[T]) IsEmpty() bool {
+	return v.box.IsEmpty()
+}
+
+func (v *validatorBox[T]) Clean() {
+	v.box.Clean()
+}
+
+func (v *validatorBox[T]) Items() []T {
+	return v.box.Items()
+}
+
+func (v *validatorBox[T]) forEach(fn func(item T) bool) {
+	eachItem(v.box, fn)
+}
+
+func (v *validatorBox[T]) mutatesOnRead() bool {
+	return mutatesOnRead(v.box)
+}
+
+func (v *validatorBox[T]) mutatesOnPeek() bool {
+	return mutatesOnPeek(v.box)
+}
+
+func (v *validatorBox[T]) drawPlan() drawPlan[T] {
+	return planOf(v.box)
+}
+
+// Compile-time assertion that validatorBox implements BlackBox[T].
+var _ BlackBox[any] = (*validatorBox[any])(nil)
@@ -0,0 +1,33 @@
+package blackbox
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPutValidator(t *testing.T) {
+	errNegative := errors.New("negative amount")
+	box := New[int](WithPutValidator(func(amount int) error {
+		if amount < 0 {
+			return errNegative
+		}
+		return nil
+	}))
+	if err := box.Put(10); err != nil {
+		t.Fatal(err)
+	}
+	err := box.Put(-5)
+	if !errors.Is(err, ErrInvalidItem) || !errors.Is(err, errNegative) {
+		t.Errorf("Expected ErrInvalidItem wrapping the validator error, got %v", err)
+	}
+	var invalid *InvalidItemError
+	if !errors.As(err, &invalid) || invalid.Err != errNegative {
+		t.Errorf("Expected an *InvalidItemError, got %T", err)
+	}
+	if err.Error() != "blackbox item is invalid: negative amount" {
+		t.Errorf("Expected a descriptive error, got %q", err)
+	}
+	if box.Size() != 1 {
+		t.Errorf("Expected the invalid item to be rejected, got size %d", box.Size())
+	}
+}
@@ -0,0 +1,133 @@
+package blackbox
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// EnvelopeVersion is the version of the Envelope layout written by this
+// package, also written in the header of binary encodings. Envelopes written
+// by older versions are migrated when they are loaded, see RegisterMigration;
+// newer versions are rejected with ErrUnsupportedVersion.
+const EnvelopeVersion = 1
+
+var ErrUnsupportedVersion = errors.New("blackbox envelope version is unsupported")
+
+// Migration upgrades the JSON object of an Envelope by one version, in place.
+type Migration func(doc map[string]json.RawMessage) error
+
+var (
+	migrationsMu sync.RWMutex
+	// migrations holds the migration from every older Envelope version to
+	// the next one. Add an entry whenever the layout changes and
+	// EnvelopeVersion is raised, so that snapshots written by older versions
+	// of the package keep loading.
+	migrations = map[int]Migration{
+		// Envelopes written before versioning have no version field, which
+		// decodes as 0; their layout is the one of version 1.
+		0: func(doc map[string]json.RawMessage) error { return nil },
+	}
+	// registered holds the versions whose migration was replaced with
+	// RegisterMigration.
+	registered = map[int]bool{}
+)
+
+// RegisterMigration replaces the migration that upgrades an Envelope from
+// version from to from+1. Envelopes are migrated one version at a time up to
+// EnvelopeVersion when they are loaded, from JSON or from a binary encoding,
+// so only versions below EnvelopeVersion can be migrated. For now that is
+// version 0, the envelopes written before versioning, which are loaded as
+// they are by default; a migration for it lets e.g. the snapshots of a fork
+// of the package that renamed a field keep loading.
+//
+// RegisterMigration panics if m is nil, if from is negative or not below
+// EnvelopeVersion, or if it was already called for that version, like
+// RegisterStrategy.
+func RegisterMigration(from int, m Migration) {
+	if m == nil {
+		panic("blackbox: RegisterMigration migration is nil")
+	}
+	if from < 0 || from >= EnvelopeVersion {
+		panic(fmt.Sprintf("blackbox: RegisterMigration called for version %d, want 0 to %d", from, EnvelopeVersion-1))
+	}
+	migrationsMu.Lock()
+	defer migrationsMu.Unlock()
+	if registered[from] {
+		panic(fmt.Sprintf("blackbox: RegisterMigration called twice for version %d", from))
+	}
+	registered[from] = true
+	migrations[from] = m
+}
+
+// decodeEnvelope decodes the JSON encoding of an Envelope of any supported
+// version.
+func decodeEnvelope[T any](data []byte) (Envelope[T], error) {
+	var env Envelope[T]
+	var probe struct {
+		Version int `json:"version"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return env, err
+	}
+	if probe.Version != EnvelopeVersion {
+		var err error
+		if data, err = migrateToCurrent(data, probe.Version); err != nil {
+			return env, err
+		}
+	}
+	err := json.Unmarshal(data, &env)
+	return env, err
+}
+
+// migrateHeader migrates the fields of env other than its items from
+// env.Version to EnvelopeVersion. It is used for binary encodings, whose
+// items are encoded by an ItemCodec rather than as JSON.
+func migrateHeader[T any](env *Envelope[T]) error {
+	header := Envelope[json.RawMessage]{Version: env.Version, Strategy: env.Strategy, MaxSize: env.MaxSize, Seed: env.Seed}
+	data, err := json.Marshal(header)
+	if err != nil {
+		return err
+	}
+	if data, err = migrateToCurrent(data, env.Version); err != nil {
+		return err
+	}
+	header = Envelope[json.RawMessage]{}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return err
+	}
+	env.Version, env.Strategy, env.MaxSize, env.Seed = header.Version, header.Strategy, header.MaxSize, header.Seed
+	return nil
+}
+
+// migrateToCurrent upgrades the JSON encoding of an Envelope from version to
+// EnvelopeVersion with the registered migrations.
+func migrateToCurrent(data []byte, version int) ([]byte, error) {
+	migrationsMu.RLock()
+	defer migrationsMu.RUnlock()
+	return migrate(data, version, migrations, EnvelopeVersion)
+}
+
+// migrate upgrades the JSON encoding of an Envelope from version to the
+// version to with the given migrations.
+func migrate(data []byte, version int, table map[int]Migration, to int) ([]byte, error) {
+	if version > to || version < 0 {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, version)
+	}
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	for v := version; v < to; v++ {
+		m, ok := table[v]
+		if !ok {
+			return nil, fmt.Errorf("%w: no migration from version %d", ErrUnsupportedVersion, v)
+		}
+		if err := m(doc); err != nil {
+			return nil, fmt.Errorf("blackbox: migrating envelope from version %d: %w", v, err)
+		}
+	}
+	doc["version"] = json.RawMessage(fmt.Sprint(to))
+	return json.Marshal(doc)
+}
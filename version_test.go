@@ -0,0 +1,130 @@
+package blackbox
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestEnvelopeVersions(t *testing.T) {
+	// Written before envelopes were versioned.
+	box, err := NewFromJSON[string]([]byte(`{"strategy":"fifo","maxSize":0,"items":["a","b"]}`))
+	if err != nil || box.Size() != 2 {
+		t.Fatalf("Expected an unversioned envelope to load, got %v", err)
+	}
+	if _, err := NewFromJSON[string]([]byte(`{"version":2,"strategy":"fifo","items":[]}`)); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("Expected ErrUnsupportedVersion, got %v", err)
+	}
+	lifo := NewLIFO[string](0, 0)
+	if err := json.Unmarshal([]byte(`{"version":-1,"items":[]}`), lifo); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("Expected ErrUnsupportedVersion, got %v", err)
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	// Version 1 named the items "values", version 2 renamed them.
+	table := map[int]Migration{
+		1: func(doc map[string]json.RawMessage) error {
+			doc["items"] = doc["values"]
+			delete(doc, "values")
+			return nil
+		},
+	}
+	data, err := migrate([]byte(`{"version":1,"strategy":"lifo","values":[1,2]}`), 1, table, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var env Envelope[int]
+	json.Unmarshal(data, &env)
+	if env.Version != 2 || env.Strategy != "lifo" || !EqualInts(env.Items, []int{1, 2}) {
+		t.Errorf("Unexpected migrated envelope %+v", env)
+	}
+	if _, err := migrate(data, 0, table, 2); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("Expected ErrUnsupportedVersion for a missing migration, got %v", err)
+	}
+}
+
+func TestRegisterMigration(t *testing.T) {
+	migrationsMu.Lock()
+	orig := migrations[0]
+	migrationsMu.Unlock()
+	defer func() {
+		migrationsMu.Lock()
+		migrations[0] = orig
+		delete(registered, 0)
+		migrationsMu.Unlock()
+	}()
+
+	// A fork wrote unversioned envelopes with the items named "values".
+	RegisterMigration(0, func(doc map[string]json.RawMessage) error {
+		if values, ok := doc["values"]; ok {
+			doc["items"] = values
+			delete(doc, "values")
+		}
+		return nil
+	})
+	box, err := NewFromJSON[int]([]byte(`{"strategy":"fifo","values":[1,2]}`))
+	if err != nil {
+		t.Fatalf("Failed to load the old envelope: %v", err)
+	}
+	if !EqualInts(box.Items(), []int{1, 2}) {
+		t.Errorf("Expected the migrated items [1 2], got %v", box.Items())
+	}
+
+	noop := func(doc map[string]json.RawMessage) error { return nil }
+	for name, register := range map[string]func(){
+		"nil":       func() { RegisterMigration(0, nil) },
+		"negative":  func() { RegisterMigration(-1, noop) },
+		"current":   func() { RegisterMigration(EnvelopeVersion, noop) },
+		"duplicate": func() { RegisterMigration(0, noop) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: Expected RegisterMigration to panic", name)
+				}
+			}()
+			register()
+		}()
+	}
+}
+
+func TestBinaryMigrated(t *testing.T) {
+	// Pretend that version 0 encodings named the FIFO strategy "queue".
+	migrationsMu.Lock()
+	orig := migrations[0]
+	migrations[0] = func(doc map[string]json.RawMessage) error {
+		if string(doc["strategy"]) == `"queue"` {
+			doc["strategy"] = json.RawMessage(`"fifo"`)
+		}
+		return nil
+	}
+	migrationsMu.Unlock()
+	defer func() {
+		migrationsMu.Lock()
+		migrations[0] = orig
+		migrationsMu.Unlock()
+	}()
+
+	var buf bytes.Buffer
+	buf.WriteString(binaryMagic)
+	buf.WriteByte(0)
+	bw := bufio.NewWriter(&buf)
+	writeBytes(bw, []byte("queue"))
+	bw.Write(uvarintBytes(4))
+	bw.WriteByte(0)
+	bw.Write(uvarintBytes(2))
+	writeBytes(bw, []byte("a"))
+	writeBytes(bw, []byte("b"))
+	bw.Flush()
+
+	box, err := DecodeBinary[string](&buf, DefaultItemCodec[string]())
+	if err != nil {
+		t.Fatalf("Failed to decode: %v", err)
+	}
+	if item, _ := box.Get(); item != "a" || box.MaxSize() != 4 {
+		t.Errorf("Expected a FIFO box of max size 4 starting with a, got %s and %d", item, box.MaxSize())
+	}
+}
@@ -0,0 +1,91 @@
+package blackbox
+
+import "errors"
+
+// ErrReadOnly is returned by Put on a view that cannot insert items.
+var ErrReadOnly = errors.New("blackbox view is read-only")
+
+// mapView exposes a BlackBox[T] as a BlackBox[U] by converting items on the
+// way out.
+type mapView[T, U any] struct {
+	box  BlackBox[T]
+	conv func(T) U
+}
+
+// MapView returns a view of box whose items are converted by conv as they
+// are read, e.g. to expose a box of internal structs to another component as
+// a box of DTOs without copying it. Get and Clean act on box; Put returns
+// ErrReadOnly since items cannot be converted back.
+// Returns a concrete instance of map view.
+func MapView[T, U any](box BlackBox[T], conv func(T) U) *mapView[T, U] {
+	return &mapView[T, U]{box: box, conv: conv}
+}
+
+func (v *mapView[T, U]) Put(item U) error {
+	return ErrReadOnly
+}
+
+func (v *mapView[T, U]) Get() (U, error) {
+	item, err := v.box.Get()
+	if err != nil {
+		var zero U
+		return zero, err
+	}
+	return v.conv(item), nil
+}
+
+func (v *mapView[T, U]) Peek() (U, error) {
+	item, err := v.box.Peek()
+	if err != nil {
+		var zero U
+		return zero, err
+	}
+	return v.conv(item), nil
+}
+
+func (v *mapView[T, U]) Size() int {
+	return v.box.Size()
+}
+
+func (v *mapView[T, U]) MaxSize() int {
+	return v.box.MaxSize()
+}
+
+func (v *mapView[T, U]) IsFull() bool {
+	return v.box.IsFull()
+}
+
+func (v *mapView[T, U]) IsEmpty() bool {
+	return v.box.IsEmpty()
+}
+
+func (v *mapView[T, U]) Clean() {
+	v.box.Clean()
+}
+
+// Items returns the converted items of the box.
+func (v *mapView[T, U]) Items() []U {
+	items := make([]U, 0, v.box.Size())
+	v.forEach(func(item U) bool {
+		items = append(items, item)
+		return true
+	})
+	return items
+}
+
+func (v *mapView[T, U]) forEach(fn func(item U) bool) {
+	eachItem(v.box, func(item T) bool {
+		return fn(v.conv(item))
+	})
+}
+
+func (v *mapView[T, U]) mutatesOnRead() bool {
+	return mutatesOnRead(v.box)
+}
+
+func (v *mapView[T, U]) mutatesOnPeek() bool {
+	return mutatesOnPeek(v.box)
+}
+
+// Compile-time assertion that mapView implements BlackBox[U].
+var _ BlackBox[any] = (*mapView[int, any])(nil)
@@ -0,0 +1,42 @@
+package blackbox
+
+import (
+	"strconv"
+	"testing"
+)
+
+type order struct {
+	id     int
+	secret string
+}
+
+type orderDTO struct {
+	ID string
+}
+
+func TestMapView(t *testing.T) {
+	box := NewFIFOFrom([]order{{1, "a"}, {2, "b"}}, 5)
+	view := MapView[order, orderDTO](box, func(o order) orderDTO {
+		return orderDTO{ID: strconv.Itoa(o.id)}
+	})
+
+	if items := view.Items(); len(items) != 2 || items[0].ID != "1" || items[1].ID != "2" {
+		t.Errorf("Expected converted items, got %v", items)
+	}
+	if dto, _ := view.Peek(); dto.ID != "1" {
+		t.Errorf("Expected to peek 1, got %v", dto)
+	}
+	if view.Size() != 2 || view.MaxSize() != 5 || view.IsFull() || view.IsEmpty() {
+		t.Errorf("Expected the size of the box, got %d/%d", view.Size(), view.MaxSize())
+	}
+	if err := view.Put(orderDTO{ID: "3"}); err != ErrReadOnly {
+		t.Errorf("Expected ErrReadOnly, got %v", err)
+	}
+	if dto, _ := view.Get(); dto.ID != "1" || box.Size() != 1 {
+		t.Errorf("Expected Get to take 1 from the box, got %v", dto)
+	}
+	view.Clean()
+	if _, err := view.Get(); err != ErrEmptyBlackBox {
+		t.Errorf("Expected ErrEmptyBlackBox, got %v", err)
+	}
+}